@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/emicklei/proto v1.9.0
+	github.com/fsnotify/fsnotify v1.4.9
 	github.com/fullstorydev/grpcurl v1.4.0
 	github.com/gobuffalo/flect v0.2.1
 	github.com/gofrs/flock v0.7.1
@@ -17,7 +18,7 @@ require (
 	go.uber.org/zap v1.14.0
 	golang.org/x/lint v0.0.0-20200302205851-738671d3881b // indirect
 	golang.org/x/net v0.0.0-20200301022130-244492dfa37a // indirect
-	golang.org/x/sys v0.0.0-20200302150141-5c8b2ff67527 // indirect
+	golang.org/x/sys v0.0.0-20200302150141-5c8b2ff67527
 	golang.org/x/text v0.3.2 // indirect
 	golang.org/x/tools v0.0.0-20200311222014-c807066ff753 // indirect
 	google.golang.org/genproto v0.0.0-20200311144346-b662892dd51b // indirect
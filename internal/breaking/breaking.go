@@ -30,34 +30,73 @@ import (
 var (
 	// AllCheckers are all known Checkers.
 	//
-	// Purposely not configurable - there are some dependencies between linters, for example if a message is deleted,
-	// ENUMS_NOT_DELETED will not print out any nested enums that were deleted.
+	// The order is purposely not configurable - there are some dependencies between linters, for example if a message
+	// is deleted, ENUMS_NOT_DELETED will not print out any nested enums that were deleted. Checkers can be added or
+	// removed in the config, but are always run in this order.
+	//
+	// Only the non-optional Checkers for the configured BreakMode are run by default, see GetCheckers.
 	AllCheckers = []Checker{
 		{
 			ID:      "ENUMS_NOT_DELETED",
 			Purpose: "Checks that no enums have been deleted.",
 			Check:   checkEnumsNotDeleted,
+			Modes:   []settings.BreakMode{settings.BreakModeSource},
 		},
 		{
 			ID:      "ENUM_VALUES_NOT_DELETED",
 			Purpose: "Checks that no enum values have been deleted.",
 			Check:   checkEnumValuesNotDeleted,
 		},
+		{
+			ID:       "ENUM_VALUES_DELETED_MUST_BE_RESERVED",
+			Purpose:  "Checks that enum values that have been deleted have their numbers reserved.",
+			Check:    checkEnumValuesDeletedMustBeReserved,
+			Optional: true,
+		},
 		{
 			ID:      "ENUM_VALUES_SAME_NAME",
 			Purpose: "Checks that enum values have the same name.",
 			Check:   checkEnumValuesSameName,
+			Modes:   []settings.BreakMode{settings.BreakModeSource, settings.BreakModeWireJSON},
+		},
+		{
+			ID:      "EXTENSIONS_NOT_DELETED",
+			Purpose: "Checks that no extensions have been deleted.",
+			Check:   checkExtensionsNotDeleted,
+		},
+		{
+			ID:      "EXTENSIONS_SAME_EXTENDEE",
+			Purpose: "Checks that extensions extend the same message.",
+			Check:   checkExtensionsSameExtendee,
+		},
+		{
+			ID:      "EXTENSIONS_SAME_NUMBER",
+			Purpose: "Checks that extensions have the same number.",
+			Check:   checkExtensionsSameNumber,
+		},
+		{
+			ID:       "FIELDS_DELETED_MUST_BE_RESERVED",
+			Purpose:  "Checks that message fields that have been deleted have their numbers reserved.",
+			Check:    checkFieldsDeletedMustBeReserved,
+			Optional: true,
 		},
 		{
 			ID:      "MESSAGES_NOT_DELETED",
 			Purpose: "Checks that no messages have been deleted.",
 			Check:   checkMessagesNotDeleted,
+			Modes:   []settings.BreakMode{settings.BreakModeSource},
 		},
 		{
 			ID:      "MESSAGE_FIELDS_NOT_DELETED",
 			Purpose: "Checks that no message fields have been deleted.",
 			Check:   checkMessageFieldsNotDeleted,
 		},
+		{
+			ID:      "MESSAGE_FIELDS_SAME_JSON_NAME",
+			Purpose: "Checks that message fields have the same JSON name.",
+			Check:   checkMessageFieldsSameJSONName,
+			Modes:   []settings.BreakMode{settings.BreakModeSource, settings.BreakModeWireJSON},
+		},
 		{
 			ID:      "MESSAGE_FIELDS_SAME_LABEL",
 			Purpose: "Checks that message fields have the same label.",
@@ -67,6 +106,7 @@ var (
 			ID:      "MESSAGE_FIELDS_SAME_NAME",
 			Purpose: "Checks that message fields have the same name.",
 			Check:   checkMessageFieldsSameName,
+			Modes:   []settings.BreakMode{settings.BreakModeSource, settings.BreakModeWireJSON},
 		},
 		{
 			ID:      "MESSAGE_FIELDS_SAME_ONEOF",
@@ -77,11 +117,19 @@ var (
 			ID:      "MESSAGE_FIELDS_SAME_TYPE",
 			Purpose: "Checks that message fields have the same type.",
 			Check:   checkMessageFieldsSameType,
+			Modes:   []settings.BreakMode{settings.BreakModeSource, settings.BreakModeWireJSON},
+		},
+		{
+			ID:      "MESSAGE_FIELDS_WIRE_COMPATIBLE_TYPE",
+			Purpose: "Checks that message fields have wire-compatible types.",
+			Check:   checkMessageFieldsWireCompatibleType,
+			Modes:   []settings.BreakMode{settings.BreakModeWire},
 		},
 		{
 			ID:      "MESSAGE_ONEOFS_NOT_DELETED",
 			Purpose: "Checks that no message oneofs have been deleted.",
 			Check:   checkMessageOneofsNotDeleted,
+			Modes:   []settings.BreakMode{settings.BreakModeSource},
 		},
 		{
 			ID:      "MESSAGE_ONEOFS_FIELDS_NOT_REMOVED",
@@ -92,6 +140,24 @@ var (
 			ID:      "PACKAGES_NOT_DELETED",
 			Purpose: "Checks that no packages have been deleted.",
 			Check:   checkPackagesNotDeleted,
+			Modes:   []settings.BreakMode{settings.BreakModeSource},
+		},
+		{
+			ID:       "PACKAGES_NO_LESS_STABLE_DEPS",
+			Purpose:  "Checks that packages do not depend on less stable packages.",
+			Check:    checkPackagesNoLessStableDeps,
+			Optional: true,
+		},
+		{
+			ID:      "RESERVED_NAMES_NOT_REMOVED",
+			Purpose: "Checks that no reserved names have been removed from messages and enums.",
+			Check:   checkReservedNamesNotRemoved,
+			Modes:   []settings.BreakMode{settings.BreakModeSource, settings.BreakModeWireJSON},
+		},
+		{
+			ID:      "RESERVED_RANGES_NOT_REMOVED",
+			Purpose: "Checks that no reserved numbers have been removed from messages and enums.",
+			Check:   checkReservedRangesNotRemoved,
 		},
 		{
 			ID:      "SERVICES_NOT_DELETED",
@@ -144,6 +210,15 @@ type Checker struct {
 	//
 	// Returns an error only if there is a system error.
 	Check func(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error
+	// The BreakModes this Checker is run for.
+	// If empty, this Checker is run for all BreakModes.
+	Modes []settings.BreakMode
+	// If true, this Checker is not run unless it is added in the config.
+	//
+	// This is used for Checkers that are alternatives to the default Checkers,
+	// for example FIELDS_DELETED_MUST_BE_RESERVED is a relaxed version of
+	// MESSAGE_FIELDS_NOT_DELETED.
+	Optional bool
 }
 
 // Runner runs a series of Checkers.
@@ -167,6 +242,13 @@ func RunnerWithLogger(logger *zap.Logger) RunnerOption {
 	}
 }
 
+// GetCheckers returns the Checkers that are run for the given config.
+//
+// Returns an error if the config refers to an unknown Checker ID.
+func GetCheckers(config settings.BreakConfig) ([]Checker, error) {
+	return getCheckers(AllCheckers, config)
+}
+
 // NewRunner returns a new Runner.
 func NewRunner(options ...RunnerOption) Runner {
 	return newRunner(options...)
@@ -25,7 +25,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/protoc"
+	"github.com/uber/prototool/internal/protostrs"
 	"github.com/uber/prototool/internal/reflect"
+	reflectv1 "github.com/uber/prototool/internal/reflect/gen/uber/proto/reflect/v1"
 	"github.com/uber/prototool/internal/settings"
 	ptesting "github.com/uber/prototool/internal/testing"
 	"github.com/uber/prototool/internal/text"
@@ -93,8 +96,11 @@ func TestRunOne(t *testing.T) {
 		newMessagesNotDeletedFailure("foo.v1.OneTwoRequest"),
 		newMessagesNotDeletedFailure("foo.v1.OneTwoResponse"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen.NestedNestedTen", "test", 3),
@@ -174,8 +180,11 @@ func TestRunOneIncludeBeta(t *testing.T) {
 		newMessagesNotDeletedFailure("foo.v1.OneTwoRequest"),
 		newMessagesNotDeletedFailure("foo.v1.OneTwoResponse"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen.NestedNestedTen", "test", 3),
@@ -244,8 +253,11 @@ func TestRunOneIncludeBeta(t *testing.T) {
 		newMessagesNotDeletedFailure("foo.v1beta1.OneTwoRequest"),
 		newMessagesNotDeletedFailure("foo.v1beta1.OneTwoResponse"),
 		newMessageFieldsSameNameFailure("foo.v1beta1.Nine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1beta1.Nine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1beta1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1beta1.Nine.NestedNine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1beta1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1beta1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1beta1.Ten", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1beta1.Ten.NestedTen", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1beta1.Ten.NestedTen.NestedNestedTen", "test", 3),
@@ -260,6 +272,400 @@ func TestRunOneIncludeBeta(t *testing.T) {
 	)
 }
 
+func TestRunOneWire(t *testing.T) {
+	testRunMode(
+		t,
+		"one",
+		settings.BreakModeWire,
+		newPackagesNoBetaDepsFailure("foo.v1", "bar.v1beta1"),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three.NestedThree", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three.NestedThree.NestedNestedThree", 2),
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.Four", 3, "foo.v1.Four.NestedFour", "foo.v1.One"),
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.Four.NestedFour", 3, "foo.v1.Four.NestedFour.NestedNestedFour", "foo.v1.One"),
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.Four", 5, "enum", "double"),
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.Four.NestedFour", 5, "enum", "double"),
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 5, "enum", "double"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 1, "optional", "repeated"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 2, "optional", "repeated"),
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.Five", 2, "string", "message"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 3, "repeated", "optional"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 4, "repeated", "optional"),
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.Five", 4, "message", "int64"),
+		newEnumValuesNotDeletedFailure("foo.v1.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.NestedNestedSeven.EnumSeven", 2),
+		newServicesNotDeletedFailure("foo.v1.TwoAPI"),
+		newServiceMethodsNotDeletedFailure("foo.v1.OneAPI", "OneTwo"),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen.NestedNestedTen", "test", 3),
+		newServiceMethodsSameRequestTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneRequest", "foo.v1.OneOneRequest"),
+		newServiceMethodsSameResponseTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneResponse", "foo.v1.OneOneResponse"),
+		newServiceMethodsSameClientStreamingFailure("foo.v1.ThreeAPI", "ThreeTwo", true),
+		newServiceMethodsSameClientStreamingFailure("foo.v1.ThreeAPI", "ThreeThree", false),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFour", true),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFive", false),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven.NestedNestedEleven", 1, "test"),
+	)
+}
+
+func TestRunOneWireJSON(t *testing.T) {
+	testRunMode(
+		t,
+		"one",
+		settings.BreakModeWireJSON,
+		newPackagesNoBetaDepsFailure("foo.v1", "bar.v1beta1"),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three.NestedThree", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three.NestedThree.NestedNestedThree", 2),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 2, "string", "bytes"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 2, "string", "bytes"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 2, "string", "bytes"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 3, "foo.v1.Four.NestedFour", "foo.v1.One"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 3, "foo.v1.Four.NestedFour.NestedNestedFour", "foo.v1.One"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 4, "foo.v1.EnumOne", "foo.v1.EnumThree"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 4, "foo.v1.EnumOne", "foo.v1.EnumThree"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 4, "foo.v1.EnumOne", "foo.v1.EnumThree"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 5, "enum", "double"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 5, "enum", "double"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 5, "enum", "double"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 6, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 6, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 6, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.SevenEntry", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.SevenEntry", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour.SevenEntry", 1, "int64", "int32"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 1, "optional", "repeated"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 2, "optional", "repeated"),
+		newMessageFieldsSameTypeFailure("foo.v1.Five", 2, "string", "message"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 3, "repeated", "optional"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 4, "repeated", "optional"),
+		newMessageFieldsSameTypeFailure("foo.v1.Five", 4, "message", "int64"),
+		newEnumValuesNotDeletedFailure("foo.v1.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.NestedNestedSeven.EnumSeven", 2),
+		newEnumValuesSameNameFailure("foo.v1.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newEnumValuesSameNameFailure("foo.v1.Seven.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newEnumValuesSameNameFailure("foo.v1.Seven.NestedSeven.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newEnumValuesSameNameFailure("foo.v1.Seven.NestedSeven.NestedNestedSeven.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newServicesNotDeletedFailure("foo.v1.TwoAPI"),
+		newServiceMethodsNotDeletedFailure("foo.v1.OneAPI", "OneTwo"),
+		newMessageFieldsSameNameFailure("foo.v1.Nine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine", 1, "one", "two"),
+		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen.NestedNestedTen", "test", 3),
+		newServiceMethodsSameRequestTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneRequest", "foo.v1.OneOneRequest"),
+		newServiceMethodsSameResponseTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneResponse", "foo.v1.OneOneResponse"),
+		newServiceMethodsSameClientStreamingFailure("foo.v1.ThreeAPI", "ThreeTwo", true),
+		newServiceMethodsSameClientStreamingFailure("foo.v1.ThreeAPI", "ThreeThree", false),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFour", true),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFive", false),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven.NestedNestedEleven", 1, "test"),
+	)
+}
+
+func TestRunOneRules(t *testing.T) {
+	testRunConfig(
+		t,
+		"one",
+		settings.BreakConfig{
+			Mode: settings.BreakModeWire,
+			IncludeIDs: []string{
+				"PACKAGES_NOT_DELETED",
+			},
+			ExcludeIDs: []string{
+				"MESSAGE_FIELDS_NOT_DELETED",
+				"PACKAGES_NO_BETA_DEPS",
+			},
+			IgnoreIDToPackages: map[string][]string{
+				"MESSAGE_FIELDS_WIRE_COMPATIBLE_TYPE": {"foo.v1"},
+			},
+			IgnoreIDToFileNames: map[string][]string{
+				"SERVICE_METHODS_SAME_CLIENT_STREAMING": {"foo/v1/foo.proto"},
+			},
+		},
+		newPackagesNotDeletedFailure("bar.v1"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 1, "optional", "repeated"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 2, "optional", "repeated"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 3, "repeated", "optional"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 4, "repeated", "optional"),
+		newEnumValuesNotDeletedFailure("foo.v1.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.NestedNestedSeven.EnumSeven", 2),
+		newServicesNotDeletedFailure("foo.v1.TwoAPI"),
+		newServiceMethodsNotDeletedFailure("foo.v1.OneAPI", "OneTwo"),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen.NestedNestedTen", "test", 3),
+		newServiceMethodsSameRequestTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneRequest", "foo.v1.OneOneRequest"),
+		newServiceMethodsSameResponseTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneResponse", "foo.v1.OneOneResponse"),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFour", true),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFive", false),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven.NestedNestedEleven", 1, "test"),
+	)
+}
+
+func TestRunOneIgnoreUnstablePackages(t *testing.T) {
+	testRunConfig(
+		t,
+		"one",
+		settings.BreakConfig{
+			IncludeBeta:            true,
+			IgnoreUnstablePackages: true,
+		},
+		newPackagesNotDeletedFailure("bar.v1"),
+		newMessagesNotDeletedFailure("foo.v1.One.NestedOne.NestedNestedTwo"),
+		newMessagesNotDeletedFailure("foo.v1.One.NestedTwo"),
+		newMessagesNotDeletedFailure("foo.v1.Two"),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three.NestedThree", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.Three.NestedThree.NestedNestedThree", 2),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 2, "string", "bytes"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 2, "string", "bytes"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 2, "string", "bytes"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 3, "foo.v1.Four.NestedFour", "foo.v1.One"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 3, "foo.v1.Four.NestedFour.NestedNestedFour", "foo.v1.One"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 4, "foo.v1.EnumOne", "foo.v1.EnumThree"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 4, "foo.v1.EnumOne", "foo.v1.EnumThree"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 4, "foo.v1.EnumOne", "foo.v1.EnumThree"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 5, "enum", "double"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 5, "enum", "double"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 5, "enum", "double"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four", 6, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour", 6, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour", 6, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.SevenEntry", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.SevenEntry", 1, "int64", "int32"),
+		newMessageFieldsSameTypeFailure("foo.v1.Four.NestedFour.NestedNestedFour.SevenEntry", 1, "int64", "int32"),
+		newMessagesNotDeletedFailure("foo.v1.Five.FourEntry"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 1, "optional", "repeated"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 2, "optional", "repeated"),
+		newMessageFieldsSameTypeFailure("foo.v1.Five", 2, "string", "message"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 3, "repeated", "optional"),
+		newMessageFieldsSameLabelFailure("foo.v1.Five", 4, "repeated", "optional"),
+		newMessageFieldsSameTypeFailure("foo.v1.Five", 4, "message", "int64"),
+		newEnumsNotDeletedFailure("foo.v1.EnumTwo"),
+		newEnumsNotDeletedFailure("foo.v1.Six.Foo"),
+		newEnumsNotDeletedFailure("foo.v1.Six.NestedSix.Foo"),
+		newEnumsNotDeletedFailure("foo.v1.Six.NestedSix.NestedNestedSix.Foo"),
+		newMessagesNotDeletedFailure("foo.v1.Six.NestedSix.NestedNestedSixDelete"),
+		newEnumValuesNotDeletedFailure("foo.v1.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.EnumSeven", 2),
+		newEnumValuesNotDeletedFailure("foo.v1.Seven.NestedSeven.NestedNestedSeven.EnumSeven", 2),
+		newEnumValuesSameNameFailure("foo.v1.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newEnumValuesSameNameFailure("foo.v1.Seven.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newEnumValuesSameNameFailure("foo.v1.Seven.NestedSeven.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newEnumValuesSameNameFailure("foo.v1.Seven.NestedSeven.NestedNestedSeven.EnumSeven", 1, "ENUM_SEVEN_ONE", "ENUM_SEVEN_TWO"),
+		newMessageOneofsNotDeletedFailure("foo.v1.Eight", "test"),
+		newMessageOneofsNotDeletedFailure("foo.v1.Eight.NestedEight", "test"),
+		newMessageOneofsNotDeletedFailure("foo.v1.Eight.NestedEight.NestedNestedEight", "test"),
+		newServicesNotDeletedFailure("foo.v1.TwoAPI"),
+		newServiceMethodsNotDeletedFailure("foo.v1.OneAPI", "OneTwo"),
+		newMessagesNotDeletedFailure("foo.v1.OneTwoRequest"),
+		newMessagesNotDeletedFailure("foo.v1.OneTwoResponse"),
+		newMessageFieldsSameNameFailure("foo.v1.Nine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine", 1, "one", "two"),
+		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen", "test", 3),
+		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen.NestedNestedTen", "test", 3),
+		newServiceMethodsSameRequestTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneRequest", "foo.v1.OneOneRequest"),
+		newServiceMethodsSameResponseTypeFailure("foo.v1.ThreeAPI", "ThreeOne", "foo.v1.ThreeOneResponse", "foo.v1.OneOneResponse"),
+		newMessagesNotDeletedFailure("foo.v1.ThreeOneRequest"),
+		newMessagesNotDeletedFailure("foo.v1.ThreeOneResponse"),
+		newServiceMethodsSameClientStreamingFailure("foo.v1.ThreeAPI", "ThreeTwo", true),
+		newServiceMethodsSameClientStreamingFailure("foo.v1.ThreeAPI", "ThreeThree", false),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFour", true),
+		newServiceMethodsSameServerStreamingFailure("foo.v1.ThreeAPI", "ThreeFive", false),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven", 1, "test"),
+		newMessageFieldsSameOneofFailure("foo.v1.Eleven.NestedEleven.NestedNestedEleven", 1, "test"),
+	)
+}
+
+func TestRunReserved(t *testing.T) {
+	testRun(
+		t,
+		"reserved",
+		false,
+		false,
+		newEnumValuesNotDeletedFailure("foo.v1.EnumOne", 1),
+		newEnumValuesNotDeletedFailure("foo.v1.EnumOne", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.One", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.One", 3),
+		newMessageFieldsNotDeletedFailure("foo.v1.One.NestedOne", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.One.NestedOne", 3),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Two", 1, "one", "first"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Two", 2, "second", "two"),
+		newReservedNamesNotRemovedFailure("enum", "foo.v1.EnumOne", "ENUM_ONE_TEN"),
+		newReservedNamesNotRemovedFailure("message", "foo.v1.One", "twenty"),
+		newReservedNamesNotRemovedFailure("message", "foo.v1.One.NestedOne", "twenty"),
+		newReservedRangesNotRemovedFailure("enum", "foo.v1.EnumOne", &reflectv1.ReservedRange{Start: 10, End: 12}),
+		newReservedRangesNotRemovedFailure("message", "foo.v1.One", &reflectv1.ReservedRange{Start: 20, End: 20}),
+		newReservedRangesNotRemovedFailure("message", "foo.v1.One.NestedOne", &reflectv1.ReservedRange{Start: 20, End: 20}),
+	)
+}
+
+func TestRunReservedWire(t *testing.T) {
+	testRunMode(
+		t,
+		"reserved",
+		settings.BreakModeWire,
+		newEnumValuesNotDeletedFailure("foo.v1.EnumOne", 1),
+		newEnumValuesNotDeletedFailure("foo.v1.EnumOne", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.One", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.One", 3),
+		newMessageFieldsNotDeletedFailure("foo.v1.One.NestedOne", 2),
+		newMessageFieldsNotDeletedFailure("foo.v1.One.NestedOne", 3),
+		newReservedRangesNotRemovedFailure("enum", "foo.v1.EnumOne", &reflectv1.ReservedRange{Start: 10, End: 12}),
+		newReservedRangesNotRemovedFailure("message", "foo.v1.One", &reflectv1.ReservedRange{Start: 20, End: 20}),
+		newReservedRangesNotRemovedFailure("message", "foo.v1.One.NestedOne", &reflectv1.ReservedRange{Start: 20, End: 20}),
+	)
+}
+
+func TestRunReservedDeletedMustBeReserved(t *testing.T) {
+	testRunConfig(
+		t,
+		"reserved",
+		settings.BreakConfig{
+			Mode: settings.BreakModeWire,
+			IncludeIDs: []string{
+				"ENUM_VALUES_DELETED_MUST_BE_RESERVED",
+				"FIELDS_DELETED_MUST_BE_RESERVED",
+			},
+			ExcludeIDs: []string{
+				"ENUM_VALUES_NOT_DELETED",
+				"MESSAGE_FIELDS_NOT_DELETED",
+				"RESERVED_RANGES_NOT_REMOVED",
+			},
+		},
+		newEnumValuesDeletedMustBeReservedFailure("foo.v1.EnumOne", 1),
+		newFieldsDeletedMustBeReservedFailure("foo.v1.One", 3),
+		newFieldsDeletedMustBeReservedFailure("foo.v1.One.NestedOne", 3),
+	)
+}
+
+func TestRunExtensions(t *testing.T) {
+	testRun(
+		t,
+		"extensions",
+		false,
+		false,
+		newExtensionsNotDeletedFailure("foo.v1.field_one"),
+		newExtensionsNotDeletedFailure("foo.v1.One.nested_one"),
+		newExtensionsSameExtendeeFailure("foo.v1.field_three", "google.protobuf.FieldOptions", "google.protobuf.MessageOptions"),
+		newExtensionsSameExtendeeFailure("foo.v1.One.nested_two", "google.protobuf.FieldOptions", "google.protobuf.EnumOptions"),
+		newExtensionsSameNumberFailure("foo.v1.field_two", 50002, 50004),
+		newMessagesNotDeletedFailure("foo.v1.Two"),
+	)
+}
+
+func TestRunStability(t *testing.T) {
+	testRun(
+		t,
+		"stability",
+		false,
+		false,
+		newPackagesNoBetaDepsFailure("foo.v1", "bar.v1alpha1"),
+	)
+}
+
+func TestRunStabilityExcludeStabilities(t *testing.T) {
+	testRunConfig(
+		t,
+		"stability",
+		settings.BreakConfig{
+			IncludeIDs:         []string{"PACKAGES_NO_LESS_STABLE_DEPS"},
+			ExcludeStabilities: []protostrs.Stability{protostrs.StabilityTest},
+		},
+		newPackagesNoBetaDepsFailure("foo.v1", "bar.v1alpha1"),
+		newPackagesNoLessStableDepsFailure("foo.v1", "bar.v1alpha1"),
+		newPackagesNoLessStableDepsFailure("baz.v1beta1", "bar.v1alpha1"),
+		newPackagesNoLessStableDepsFailure("bar.v1alpha1", "qux.v1test1"),
+		newMessagesNotDeletedFailure("bar.v1alpha1.Two"),
+	)
+}
+
+func TestRunStabilityIncludeBeta(t *testing.T) {
+	testRunConfig(
+		t,
+		"stability",
+		settings.BreakConfig{
+			IncludeBeta: true,
+			IncludeIDs:  []string{"PACKAGES_NO_LESS_STABLE_DEPS"},
+		},
+		newPackagesNoLessStableDepsFailure("foo.v1", "bar.v1alpha1"),
+		newPackagesNoLessStableDepsFailure("baz.v1beta1", "bar.v1alpha1"),
+		newPackagesNoLessStableDepsFailure("bar.v1alpha1", "qux.v1test1"),
+		newMessagesNotDeletedFailure("bar.v1alpha1.Two"),
+	)
+}
+
+func TestRunOneLocations(t *testing.T) {
+	fromPackageSet, toPackageSet, err := getPackageSetsForFunc("one", ptesting.GetFileDescriptorSetsWithSourceInfo)
+	require.NoError(t, err)
+	failures, err := NewRunner().Run(settings.BreakConfig{}, fromPackageSet, toPackageSet)
+	require.NoError(t, err)
+	pathToFailure := make(map[string]*text.Failure)
+	for _, failure := range failures {
+		if _, ok := pathToFailure[failure.Path]; !ok {
+			pathToFailure[failure.Path] = failure
+		}
+	}
+	for path, expectedFailure := range map[string]*text.Failure{
+		// deleted, so the location of the enclosing message
+		"foo.v1.Three.2": {Filename: "foo/v1/foo.proto", Line: 39, Column: 1, LintID: "MESSAGE_FIELDS_NOT_DELETED"},
+		"foo.v1.Four.1":  {Filename: "foo/v1/foo.proto", Line: 74, Column: 3, LintID: "MESSAGE_FIELDS_SAME_TYPE"},
+		// deleted with no enclosing element
+		"bar.v1": {LintID: "PACKAGES_NOT_DELETED"},
+	} {
+		failure, ok := pathToFailure[path]
+		require.True(t, ok, path)
+		require.Equal(t, expectedFailure.Filename, failure.Filename, path)
+		require.Equal(t, expectedFailure.Line, failure.Line, path)
+		require.Equal(t, expectedFailure.Column, failure.Column, path)
+		require.Equal(t, expectedFailure.LintID, failure.LintID, path)
+	}
+}
+
+func TestRunOneUnknownID(t *testing.T) {
+	fromPackageSet, toPackageSet, err := getPackageSets("one")
+	require.NoError(t, err)
+	_, err = NewRunner().Run(
+		settings.BreakConfig{
+			ExcludeIDs: []string{"FOO"},
+		},
+		fromPackageSet,
+		toPackageSet,
+	)
+	require.Error(t, err)
+}
+
 func TestRunOneAllowBetaDeps(t *testing.T) {
 	testRun(
 		t,
@@ -321,8 +727,11 @@ func TestRunOneAllowBetaDeps(t *testing.T) {
 		newMessagesNotDeletedFailure("foo.v1.OneTwoRequest"),
 		newMessagesNotDeletedFailure("foo.v1.OneTwoResponse"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine", 1, "one", "two"),
 		newMessageFieldsSameNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
+		newMessageFieldsSameJSONNameFailure("foo.v1.Nine.NestedNine.NestedNestedNine", 1, "one", "two"),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen", "test", 3),
 		newMessageOneofsFieldsNotRemovedFailure("foo.v1.Ten.NestedTen.NestedNestedTen", "test", 3),
@@ -340,14 +749,88 @@ func TestRunOneAllowBetaDeps(t *testing.T) {
 	)
 }
 
+func TestRunRenameWire(t *testing.T) {
+	// renaming a message is not breaking on the wire, but the fields and
+	// methods that use it cannot tell this apart from changing to another message
+	testRunMode(
+		t,
+		"rename",
+		settings.BreakModeWire,
+		newMessageFieldsWireCompatibleTypeFailure("foo.v1.One", 1, "foo.v1.Two", "foo.v1.Three"),
+		newServiceMethodsSameRequestTypeFailure("foo.v1.OneAPI", "Get", "foo.v1.GetRequest", "foo.v1.FetchRequest"),
+		newServiceMethodsSameResponseTypeFailure("foo.v1.OneAPI", "Get", "foo.v1.GetResponse", "foo.v1.FetchResponse"),
+	)
+}
+
+func TestGetChanges(t *testing.T) {
+	fromPackageSet, toPackageSet, err := getPackageSets("changelog")
+	require.NoError(t, err)
+	changes, err := GetChanges(fromPackageSet, toPackageSet)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]*Change{
+			{Type: ChangeTypeAdded, Element: ChangeElementPackage, Name: "bar.v1"},
+			{Type: ChangeTypeAdded, Element: ChangeElementEnumValue, Name: "foo.v1.EnumOne.ENUM_ONE_THREE"},
+			{Type: ChangeTypeAdded, Element: ChangeElementMessage, Name: "foo.v1.ListRequest"},
+			{Type: ChangeTypeAdded, Element: ChangeElementMessage, Name: "foo.v1.ListResponse"},
+			{Type: ChangeTypeAdded, Element: ChangeElementEnum, Name: "foo.v1.One.NestedEnumTwo"},
+			{Type: ChangeTypeAdded, Element: ChangeElementMessage, Name: "foo.v1.One.NestedTwo"},
+			{Type: ChangeTypeAdded, Element: ChangeElementMessageField, Name: "foo.v1.One.counts"},
+			{Type: ChangeTypeAdded, Element: ChangeElementMessageField, Name: "foo.v1.One.three"},
+			{Type: ChangeTypeAdded, Element: ChangeElementServiceMethod, Name: "foo.v1.OneAPI.List"},
+			{Type: ChangeTypeAdded, Element: ChangeElementService, Name: "foo.v1.TwoAPI"},
+			{Type: ChangeTypeAdded, Element: ChangeElementExtension, Name: "foo.v1.opt_three"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementEnum, Name: "foo.v1.EnumOne"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementEnumValue, Name: "foo.v1.EnumOne.ENUM_ONE_ONE"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementMessage, Name: "foo.v1.One.NestedOne"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementMessageField, Name: "foo.v1.One.one"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementService, Name: "foo.v1.OneAPI"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementServiceMethod, Name: "foo.v1.OneAPI.Get"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementExtension, Name: "foo.v1.opt_two"},
+			{Type: ChangeTypeDeprecated, Element: ChangeElementPackage, Name: "qux.v1"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementPackage, Name: "baz.v1"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementEnumValue, Name: "foo.v1.EnumOne.ENUM_ONE_TWO"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementEnum, Name: "foo.v1.One.NestedEnumOne"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementMessageField, Name: "foo.v1.One.labels"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementMessageField, Name: "foo.v1.One.two"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementServiceMethod, Name: "foo.v1.OneAPI.Put"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementMessage, Name: "foo.v1.PutRequest"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementMessage, Name: "foo.v1.PutResponse"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementMessage, Name: "foo.v1.Two"},
+			{Type: ChangeTypeRemoved, Element: ChangeElementExtension, Name: "foo.v1.opt_one"},
+		},
+		changes,
+	)
+}
+
 func testRun(t *testing.T, subDirPath string, includeBeta bool, allowBetaDeps bool, expectedFailures ...*text.Failure) {
+	testRunConfig(
+		t,
+		subDirPath,
+		settings.BreakConfig{
+			IncludeBeta:   includeBeta,
+			AllowBetaDeps: allowBetaDeps,
+		},
+		expectedFailures...,
+	)
+}
+
+func testRunMode(t *testing.T, subDirPath string, mode settings.BreakMode, expectedFailures ...*text.Failure) {
+	testRunConfig(
+		t,
+		subDirPath,
+		settings.BreakConfig{
+			Mode: mode,
+		},
+		expectedFailures...,
+	)
+}
+
+func testRunConfig(t *testing.T, subDirPath string, config settings.BreakConfig, expectedFailures ...*text.Failure) {
 	fromPackageSet, toPackageSet, err := getPackageSets(subDirPath)
 	require.NoError(t, err)
 	runner := NewRunner()
-	config := settings.BreakConfig{
-		IncludeBeta:   includeBeta,
-		AllowBetaDeps: allowBetaDeps,
-	}
 	failures, err := runner.Run(config, fromPackageSet, toPackageSet)
 	require.NoError(t, err)
 	for _, failure := range failures {
@@ -359,7 +842,14 @@ func testRun(t *testing.T, subDirPath string, includeBeta bool, allowBetaDeps bo
 }
 
 func getPackageSets(subDirPath string) (*extract.PackageSet, *extract.PackageSet, error) {
-	fromFileDescriptorSets, err := ptesting.GetFileDescriptorSets(".", "testdata/"+subDirPath+"/from")
+	return getPackageSetsForFunc(subDirPath, ptesting.GetFileDescriptorSets)
+}
+
+func getPackageSetsForFunc(
+	subDirPath string,
+	getFileDescriptorSets func(string, string) (protoc.FileDescriptorSets, error),
+) (*extract.PackageSet, *extract.PackageSet, error) {
+	fromFileDescriptorSets, err := getFileDescriptorSets(".", "testdata/"+subDirPath+"/from")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -367,11 +857,11 @@ func getPackageSets(subDirPath string) (*extract.PackageSet, *extract.PackageSet
 	if err != nil {
 		return nil, nil, err
 	}
-	fromPackageSet, err := extract.NewPackageSet(fromReflectPackageSet)
+	fromPackageSet, err := extract.NewPackageSetWithFileNames(fromReflectPackageSet, fromFileDescriptorSets.Unwrap()...)
 	if err != nil {
 		return nil, nil, err
 	}
-	toFileDescriptorSets, err := ptesting.GetFileDescriptorSets(".", "testdata/"+subDirPath+"/to")
+	toFileDescriptorSets, err := getFileDescriptorSets(".", "testdata/"+subDirPath+"/to")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -379,7 +869,7 @@ func getPackageSets(subDirPath string) (*extract.PackageSet, *extract.PackageSet
 	if err != nil {
 		return nil, nil, err
 	}
-	toPackageSet, err := extract.NewPackageSet(toReflectPackageSet)
+	toPackageSet, err := extract.NewPackageSetWithFileNames(toReflectPackageSet, toFileDescriptorSets.Unwrap()...)
 	if err != nil {
 		return nil, nil, err
 	}
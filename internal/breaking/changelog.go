@@ -0,0 +1,345 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package breaking
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/text"
+)
+
+const (
+	// ChangeTypeAdded says that an element was added.
+	ChangeTypeAdded ChangeType = iota
+	// ChangeTypeDeprecated says that an element was deprecated.
+	ChangeTypeDeprecated
+	// ChangeTypeRemoved says that an element was removed.
+	ChangeTypeRemoved
+)
+
+const (
+	// ChangeElementPackage is a package.
+	ChangeElementPackage ChangeElement = "package"
+	// ChangeElementEnum is an enum.
+	ChangeElementEnum ChangeElement = "enum"
+	// ChangeElementEnumValue is an enum value.
+	ChangeElementEnumValue ChangeElement = "enum value"
+	// ChangeElementMessage is a message.
+	ChangeElementMessage ChangeElement = "message"
+	// ChangeElementMessageField is a message field.
+	ChangeElementMessageField ChangeElement = "message field"
+	// ChangeElementService is a service.
+	ChangeElementService ChangeElement = "service"
+	// ChangeElementServiceMethod is a service method.
+	ChangeElementServiceMethod ChangeElement = "service method"
+	// ChangeElementExtension is an extension.
+	ChangeElementExtension ChangeElement = "extension"
+)
+
+var (
+	// AllChangeTypes are all ChangeTypes in the order they are sorted.
+	AllChangeTypes = []ChangeType{
+		ChangeTypeAdded,
+		ChangeTypeDeprecated,
+		ChangeTypeRemoved,
+	}
+
+	_changeTypeToString = map[ChangeType]string{
+		ChangeTypeAdded:      "added",
+		ChangeTypeDeprecated: "deprecated",
+		ChangeTypeRemoved:    "removed",
+	}
+)
+
+// ChangeType is the type of a Change.
+type ChangeType int
+
+// String implements fmt.Stringer.
+func (c ChangeType) String() string {
+	if s, ok := _changeTypeToString[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("%d", c)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ChangeType) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// ChangeElement is the kind of element a Change applies to.
+type ChangeElement string
+
+// Change is an addition, deprecation or removal of an element between
+// two versions of Protobuf definitions.
+type Change struct {
+	Type    ChangeType    `json:"type"`
+	Element ChangeElement `json:"element"`
+	// The fully-qualified name of the element.
+	//
+	// Message fields, enum values and service methods are qualified by
+	// the name of their parent message, enum or service.
+	Name string `json:"name"`
+}
+
+// GetChanges returns the additions, deprecations and removals of packages,
+// enums, enum values, messages, message fields, services, service methods
+// and extensions from the PackageSet from to the PackageSet to.
+//
+// Elements within an added or removed element are not listed separately,
+// similar to the Checkers. Message fields and enum values are matched by
+// number, and all other elements are matched by name.
+//
+// The Changes are sorted by type, name and element.
+func GetChanges(from *extract.PackageSet, to *extract.PackageSet) ([]*Change, error) {
+	var changes []*Change
+	addChange := func(changeType ChangeType, element ChangeElement, name string) {
+		changes = append(changes, &Change{
+			Type:    changeType,
+			Element: element,
+			Name:    name,
+		})
+	}
+	getPackageChanges(addChange, from, to)
+	// the forEach functions are shared with the Checkers, no Failures are added
+	for _, f := range []func(func(ChangeType, ChangeElement, string), *extract.PackageSet, *extract.PackageSet) error{
+		getPackagePairChanges,
+		getMessagePairChanges,
+		getMessageFieldPairChanges,
+		getEnumPairChanges,
+		getEnumValuePairChanges,
+		getServicePairChanges,
+		getServiceMethodPairChanges,
+		getExtensionPairChanges,
+	} {
+		if err := f(addChange, from, to); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(changes, func(i int, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return changes[i].Type < changes[j].Type
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Element < changes[j].Element
+	})
+	return changes, nil
+}
+
+func getPackageChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) {
+	fromPackageNameToPackage := from.PackageNameToPackage()
+	toPackageNameToPackage := to.PackageNameToPackage()
+	for toPackageName := range toPackageNameToPackage {
+		if _, ok := fromPackageNameToPackage[toPackageName]; !ok {
+			addChange(ChangeTypeAdded, ChangeElementPackage, toPackageName)
+		}
+	}
+	for fromPackageName := range fromPackageNameToPackage {
+		if _, ok := toPackageNameToPackage[fromPackageName]; !ok {
+			addChange(ChangeTypeRemoved, ChangeElementPackage, fromPackageName)
+		}
+	}
+}
+
+func getPackagePairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachPackagePair(nil, from, to, func(_ func(*text.Failure), from *extract.Package, to *extract.Package) error {
+		if !isPackageDeprecated(from) && isPackageDeprecated(to) {
+			addChange(ChangeTypeDeprecated, ChangeElementPackage, to.FullyQualifiedName())
+		}
+		getEnumMapChanges(addChange, from.EnumNameToEnum(), to.EnumNameToEnum())
+		getMessageMapChanges(addChange, from.MessageNameToMessage(), to.MessageNameToMessage())
+		getExtensionMapChanges(addChange, from.ExtensionNameToExtension(), to.ExtensionNameToExtension())
+		for toServiceName, toService := range to.ServiceNameToService() {
+			if _, ok := from.ServiceNameToService()[toServiceName]; !ok {
+				addChange(ChangeTypeAdded, ChangeElementService, toService.FullyQualifiedName())
+			}
+		}
+		for fromServiceName, fromService := range from.ServiceNameToService() {
+			if _, ok := to.ServiceNameToService()[fromServiceName]; !ok {
+				addChange(ChangeTypeRemoved, ChangeElementService, fromService.FullyQualifiedName())
+			}
+		}
+		return nil
+	})
+}
+
+func getMessagePairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachMessagePair(nil, from, to, func(_ func(*text.Failure), from *extract.Message, to *extract.Message) error {
+		if !from.ProtoMessage().GetOptions().GetDeprecated() && to.ProtoMessage().GetOptions().GetDeprecated() {
+			addChange(ChangeTypeDeprecated, ChangeElementMessage, to.FullyQualifiedName())
+		}
+		getEnumMapChanges(addChange, from.NestedEnumNameToEnum(), to.NestedEnumNameToEnum())
+		getMessageMapChanges(addChange, from.NestedMessageNameToMessage(), to.NestedMessageNameToMessage())
+		getExtensionMapChanges(addChange, from.NestedExtensionNameToExtension(), to.NestedExtensionNameToExtension())
+		for toFieldNumber, toField := range to.FieldNumberToField() {
+			if _, ok := from.FieldNumberToField()[toFieldNumber]; !ok {
+				addChange(ChangeTypeAdded, ChangeElementMessageField, getMessageFieldName(toField))
+			}
+		}
+		for fromFieldNumber, fromField := range from.FieldNumberToField() {
+			if _, ok := to.FieldNumberToField()[fromFieldNumber]; !ok {
+				addChange(ChangeTypeRemoved, ChangeElementMessageField, getMessageFieldName(fromField))
+			}
+		}
+		return nil
+	})
+}
+
+func getMessageFieldPairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachMessageFieldPair(nil, from, to, func(_ func(*text.Failure), from *extract.MessageField, to *extract.MessageField) error {
+		if !from.ProtoMessage().GetOptions().GetDeprecated() && to.ProtoMessage().GetOptions().GetDeprecated() {
+			addChange(ChangeTypeDeprecated, ChangeElementMessageField, getMessageFieldName(to))
+		}
+		return nil
+	})
+}
+
+func getEnumPairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachEnumPair(nil, from, to, func(_ func(*text.Failure), from *extract.Enum, to *extract.Enum) error {
+		if !from.ProtoMessage().GetOptions().GetDeprecated() && to.ProtoMessage().GetOptions().GetDeprecated() {
+			addChange(ChangeTypeDeprecated, ChangeElementEnum, to.FullyQualifiedName())
+		}
+		for toValueNumber, toValue := range to.ValueNumberToValue() {
+			if _, ok := from.ValueNumberToValue()[toValueNumber]; !ok {
+				addChange(ChangeTypeAdded, ChangeElementEnumValue, getEnumValueName(toValue))
+			}
+		}
+		for fromValueNumber, fromValue := range from.ValueNumberToValue() {
+			if _, ok := to.ValueNumberToValue()[fromValueNumber]; !ok {
+				addChange(ChangeTypeRemoved, ChangeElementEnumValue, getEnumValueName(fromValue))
+			}
+		}
+		return nil
+	})
+}
+
+func getEnumValuePairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachEnumValuePair(nil, from, to, func(_ func(*text.Failure), from *extract.EnumValue, to *extract.EnumValue) error {
+		if !from.ProtoMessage().GetOptions().GetDeprecated() && to.ProtoMessage().GetOptions().GetDeprecated() {
+			addChange(ChangeTypeDeprecated, ChangeElementEnumValue, getEnumValueName(to))
+		}
+		return nil
+	})
+}
+
+func getServicePairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachServicePair(nil, from, to, func(_ func(*text.Failure), from *extract.Service, to *extract.Service) error {
+		if !from.ProtoMessage().GetOptions().GetDeprecated() && to.ProtoMessage().GetOptions().GetDeprecated() {
+			addChange(ChangeTypeDeprecated, ChangeElementService, to.FullyQualifiedName())
+		}
+		for toMethodName := range to.MethodNameToMethod() {
+			if _, ok := from.MethodNameToMethod()[toMethodName]; !ok {
+				addChange(ChangeTypeAdded, ChangeElementServiceMethod, to.FullyQualifiedName()+"."+toMethodName)
+			}
+		}
+		for fromMethodName := range from.MethodNameToMethod() {
+			if _, ok := to.MethodNameToMethod()[fromMethodName]; !ok {
+				addChange(ChangeTypeRemoved, ChangeElementServiceMethod, from.FullyQualifiedName()+"."+fromMethodName)
+			}
+		}
+		return nil
+	})
+}
+
+func getServiceMethodPairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachServiceMethodPair(nil, from, to, func(_ func(*text.Failure), from *extract.ServiceMethod, to *extract.ServiceMethod) error {
+		if !from.ProtoMessage().GetOptions().GetDeprecated() && to.ProtoMessage().GetOptions().GetDeprecated() {
+			addChange(ChangeTypeDeprecated, ChangeElementServiceMethod, to.Service().FullyQualifiedName()+"."+to.ProtoMessage().Name)
+		}
+		return nil
+	})
+}
+
+func getExtensionPairChanges(addChange func(ChangeType, ChangeElement, string), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachExtensionPair(nil, from, to, func(_ func(*text.Failure), from *extract.Extension, to *extract.Extension) error {
+		if !from.ProtoMessage().GetOptions().GetDeprecated() && to.ProtoMessage().GetOptions().GetDeprecated() {
+			addChange(ChangeTypeDeprecated, ChangeElementExtension, to.FullyQualifiedName())
+		}
+		return nil
+	})
+}
+
+func getEnumMapChanges(addChange func(ChangeType, ChangeElement, string), from map[string]*extract.Enum, to map[string]*extract.Enum) {
+	for toEnumName, toEnum := range to {
+		if _, ok := from[toEnumName]; !ok {
+			addChange(ChangeTypeAdded, ChangeElementEnum, toEnum.FullyQualifiedName())
+		}
+	}
+	for fromEnumName, fromEnum := range from {
+		if _, ok := to[fromEnumName]; !ok {
+			addChange(ChangeTypeRemoved, ChangeElementEnum, fromEnum.FullyQualifiedName())
+		}
+	}
+}
+
+// getMessageMapChanges does not report the entry messages generated for map
+// fields, as these are reported as changes to the map fields themselves.
+func getMessageMapChanges(addChange func(ChangeType, ChangeElement, string), from map[string]*extract.Message, to map[string]*extract.Message) {
+	for toMessageName, toMessage := range to {
+		if _, ok := from[toMessageName]; !ok && !toMessage.ProtoMessage().GetOptions().GetMapEntry() {
+			addChange(ChangeTypeAdded, ChangeElementMessage, toMessage.FullyQualifiedName())
+		}
+	}
+	for fromMessageName, fromMessage := range from {
+		if _, ok := to[fromMessageName]; !ok && !fromMessage.ProtoMessage().GetOptions().GetMapEntry() {
+			addChange(ChangeTypeRemoved, ChangeElementMessage, fromMessage.FullyQualifiedName())
+		}
+	}
+}
+
+func getExtensionMapChanges(addChange func(ChangeType, ChangeElement, string), from map[string]*extract.Extension, to map[string]*extract.Extension) {
+	for toExtensionName, toExtension := range to {
+		if _, ok := from[toExtensionName]; !ok {
+			addChange(ChangeTypeAdded, ChangeElementExtension, toExtension.FullyQualifiedName())
+		}
+	}
+	for fromExtensionName, fromExtension := range from {
+		if _, ok := to[fromExtensionName]; !ok {
+			addChange(ChangeTypeRemoved, ChangeElementExtension, fromExtension.FullyQualifiedName())
+		}
+	}
+}
+
+// isPackageDeprecated returns true if all the files of the package are deprecated.
+func isPackageDeprecated(pkg *extract.Package) bool {
+	files := pkg.ProtoMessage().Files
+	if len(files) == 0 {
+		return false
+	}
+	for _, file := range files {
+		if !file.GetOptions().GetDeprecated() {
+			return false
+		}
+	}
+	return true
+}
+
+func getMessageFieldName(messageField *extract.MessageField) string {
+	return messageField.Message().FullyQualifiedName() + "." + messageField.ProtoMessage().Name
+}
+
+func getEnumValueName(enumValue *extract.EnumValue) string {
+	return enumValue.Enum().FullyQualifiedName() + "." + enumValue.ProtoMessage().Name
+}
@@ -41,5 +41,5 @@ func checkEnumValuesNotDeletedEnum(addFailure func(*text.Failure), from *extract
 }
 
 func newEnumValuesNotDeletedFailure(enumName string, valueNumber int32) *text.Failure {
-	return newTextFailuref(`Enum value "%d" on enum %q was deleted.`, valueNumber, enumName)
+	return newTextFailuref(extract.EnumValuePath(enumName, valueNumber), `Enum value "%d" on enum %q was deleted.`, valueNumber, enumName)
 }
@@ -40,5 +40,5 @@ func checkEnumValuesSameNameEnumValue(addFailure func(*text.Failure), from *extr
 }
 
 func newEnumValuesSameNameFailure(enumName string, valueNumber int32, fromName string, toName string) *text.Failure {
-	return newTextFailuref(`Enum value "%d" on enum %q changed from %q to %q.`, valueNumber, enumName, fromName, toName)
+	return newTextFailuref(extract.EnumValuePath(enumName, valueNumber), `Enum value "%d" on enum %q changed from %q to %q.`, valueNumber, enumName, fromName, toName)
 }
@@ -52,5 +52,5 @@ func checkEnumsNotDeletedMap(addFailure func(*text.Failure), from map[string]*ex
 }
 
 func newEnumsNotDeletedFailure(enumName string) *text.Failure {
-	return newTextFailuref(`Enum %q was deleted.`, enumName)
+	return newTextFailuref(enumName, `Enum %q was deleted.`, enumName)
 }
@@ -0,0 +1,56 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package breaking
+
+import (
+	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/text"
+)
+
+func checkExtensionsNotDeleted(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error {
+	if err := forEachPackagePair(addFailure, from, to, checkExtensionsNotDeletedPackage); err != nil {
+		return err
+	}
+	// if a message is deleted, the breaking change detector will fail, so we don't need to check for this
+	// and produce two warnings, one for the message, the other for the nested extension
+	return forEachMessagePair(addFailure, from, to, checkExtensionsNotDeletedMessage)
+}
+
+func checkExtensionsNotDeletedPackage(addFailure func(*text.Failure), from *extract.Package, to *extract.Package) error {
+	return checkExtensionsNotDeletedMap(addFailure, from.ExtensionNameToExtension(), to.ExtensionNameToExtension())
+}
+
+func checkExtensionsNotDeletedMessage(addFailure func(*text.Failure), from *extract.Message, to *extract.Message) error {
+	return checkExtensionsNotDeletedMap(addFailure, from.NestedExtensionNameToExtension(), to.NestedExtensionNameToExtension())
+}
+
+func checkExtensionsNotDeletedMap(addFailure func(*text.Failure), from map[string]*extract.Extension, to map[string]*extract.Extension) error {
+	for fromExtensionName, fromExtension := range from {
+		if _, ok := to[fromExtensionName]; !ok {
+			addFailure(newExtensionsNotDeletedFailure(fromExtension.FullyQualifiedName()))
+		}
+	}
+	return nil
+}
+
+func newExtensionsNotDeletedFailure(extensionName string) *text.Failure {
+	return newTextFailuref(extensionName, `Extension %q was deleted.`, extensionName)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package breaking
+
+import (
+	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/text"
+)
+
+func checkFieldsDeletedMustBeReserved(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachMessagePair(addFailure, from, to, checkFieldsDeletedMustBeReservedMessage)
+}
+
+func checkFieldsDeletedMustBeReservedMessage(addFailure func(*text.Failure), from *extract.Message, to *extract.Message) error {
+	fromFieldNumberToField := from.FieldNumberToField()
+	toFieldNumberToField := to.FieldNumberToField()
+	toReservedRanges := to.ProtoMessage().ReservedRanges
+	for fieldNumber := range fromFieldNumberToField {
+		if _, ok := toFieldNumberToField[fieldNumber]; !ok && !isNumberReserved(toReservedRanges, fieldNumber) {
+			addFailure(newFieldsDeletedMustBeReservedFailure(from.FullyQualifiedName(), fieldNumber))
+		}
+	}
+	return nil
+}
+
+func newFieldsDeletedMustBeReservedFailure(messageName string, fieldNumber int32) *text.Failure {
+	return newTextFailuref(extract.MessageFieldPath(messageName, fieldNumber), `Message field "%d" on message %q was deleted without reserving the number "%d".`, fieldNumber, messageName, fieldNumber)
+}
@@ -41,5 +41,5 @@ func checkMessageFieldsNotDeletedMessage(addFailure func(*text.Failure), from *e
 }
 
 func newMessageFieldsNotDeletedFailure(messageName string, fieldNumber int32) *text.Failure {
-	return newTextFailuref(`Message field "%d" on message %q was deleted.`, fieldNumber, messageName)
+	return newTextFailuref(extract.MessageFieldPath(messageName, fieldNumber), `Message field "%d" on message %q was deleted.`, fieldNumber, messageName)
 }
@@ -48,5 +48,5 @@ func checkMessageFieldsSameLabelMessageField(addFailure func(*text.Failure), fro
 }
 
 func newMessageFieldsSameLabelFailure(messageName string, fieldNumber int32, fromLabelString string, toLabelString string) *text.Failure {
-	return newTextFailuref(`Message field "%d" on message %q changed from %q to %q.`, fieldNumber, messageName, fromLabelString, toLabelString)
+	return newTextFailuref(extract.MessageFieldPath(messageName, fieldNumber), `Message field "%d" on message %q changed from %q to %q.`, fieldNumber, messageName, fromLabelString, toLabelString)
 }
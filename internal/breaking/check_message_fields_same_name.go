@@ -40,5 +40,5 @@ func checkMessageFieldsSameNameMessageField(addFailure func(*text.Failure), from
 }
 
 func newMessageFieldsSameNameFailure(messageName string, fieldNumber int32, fromName string, toName string) *text.Failure {
-	return newTextFailuref(`Message field "%d" on message %q changed from %q to %q.`, fieldNumber, messageName, fromName, toName)
+	return newTextFailuref(extract.MessageFieldPath(messageName, fieldNumber), `Message field "%d" on message %q changed from %q to %q.`, fieldNumber, messageName, fromName, toName)
 }
@@ -67,5 +67,5 @@ func checkMessageFieldsSameTypeMessageField(addFailure func(*text.Failure), from
 }
 
 func newMessageFieldsSameTypeFailure(messageName string, fieldNumber int32, fromTypeString string, toTypeString string) *text.Failure {
-	return newTextFailuref(`Message field "%d" on message %q changed type from %q to %q.`, fieldNumber, messageName, fromTypeString, toTypeString)
+	return newTextFailuref(extract.MessageFieldPath(messageName, fieldNumber), `Message field "%d" on message %q changed type from %q to %q.`, fieldNumber, messageName, fromTypeString, toTypeString)
 }
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package breaking
+
+import (
+	"fmt"
+
+	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/text"
+
+	reflectv1 "github.com/uber/prototool/internal/reflect/gen/uber/proto/reflect/v1"
+)
+
+// types in the same group have the same wire type and can be changed
+// to each other without breaking the wire format, although values may be
+// truncated or reinterpreted
+var messageFieldTypeToWireCompatibleGroup = map[reflectv1.MessageField_Type]int{
+	reflectv1.MessageField_TYPE_INT32:    1,
+	reflectv1.MessageField_TYPE_UINT32:   1,
+	reflectv1.MessageField_TYPE_INT64:    1,
+	reflectv1.MessageField_TYPE_UINT64:   1,
+	reflectv1.MessageField_TYPE_BOOL:     1,
+	reflectv1.MessageField_TYPE_ENUM:     1,
+	reflectv1.MessageField_TYPE_SINT32:   2,
+	reflectv1.MessageField_TYPE_SINT64:   2,
+	reflectv1.MessageField_TYPE_STRING:   3,
+	reflectv1.MessageField_TYPE_BYTES:    3,
+	reflectv1.MessageField_TYPE_FIXED32:  4,
+	reflectv1.MessageField_TYPE_SFIXED32: 4,
+	reflectv1.MessageField_TYPE_FIXED64:  5,
+	reflectv1.MessageField_TYPE_SFIXED64: 5,
+}
+
+func checkMessageFieldsWireCompatibleType(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error {
+	return forEachMessageFieldPair(addFailure, from, to, checkMessageFieldsWireCompatibleTypeMessageField)
+}
+
+func checkMessageFieldsWireCompatibleTypeMessageField(addFailure func(*text.Failure), from *extract.MessageField, to *extract.MessageField) error {
+	fromType := from.ProtoMessage().Type
+	toType := to.ProtoMessage().Type
+	if fromType != toType {
+		if isWireCompatibleMessageFieldType(fromType, toType) {
+			return nil
+		}
+		fromTypeString, err := getMessageFieldTypeString(fromType)
+		if err != nil {
+			return err
+		}
+		toTypeString, err := getMessageFieldTypeString(toType)
+		if err != nil {
+			return err
+		}
+		addFailure(newMessageFieldsWireCompatibleTypeFailure(from.Message().FullyQualifiedName(), from.ProtoMessage().Number, fromTypeString, toTypeString))
+		return nil
+	}
+	// enums are varints on the wire so changing the enum type is compatible
+	switch fromType {
+	case reflectv1.MessageField_TYPE_GROUP, reflectv1.MessageField_TYPE_MESSAGE:
+		fromTypeName := from.ProtoMessage().TypeName
+		toTypeName := to.ProtoMessage().TypeName
+		if fromTypeName == "" {
+			return fmt.Errorf("fromTypeName empty")
+		}
+		if toTypeName == "" {
+			return fmt.Errorf("toTypeName empty")
+		}
+		if fromTypeName != toTypeName {
+			addFailure(newMessageFieldsWireCompatibleTypeFailure(from.Message().FullyQualifiedName(), from.ProtoMessage().Number, fromTypeName, toTypeName))
+		}
+	}
+	return nil
+}
+
+func isWireCompatibleMessageFieldType(fromType reflectv1.MessageField_Type, toType reflectv1.MessageField_Type) bool {
+	fromGroup, ok := messageFieldTypeToWireCompatibleGroup[fromType]
+	if !ok {
+		return false
+	}
+	return fromGroup == messageFieldTypeToWireCompatibleGroup[toType]
+}
+
+func newMessageFieldsWireCompatibleTypeFailure(messageName string, fieldNumber int32, fromTypeString string, toTypeString string) *text.Failure {
+	return newTextFailuref(extract.MessageFieldPath(messageName, fieldNumber), `Message field "%d" on message %q changed type from %q to %q, which is not wire compatible.`, fieldNumber, messageName, fromTypeString, toTypeString)
+}
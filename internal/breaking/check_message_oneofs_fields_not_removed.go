@@ -41,7 +41,7 @@ func checkMessageOneofsFieldsNotRemovedMessageOneof(addFailure func(*text.Failur
 }
 
 func newMessageOneofsFieldsNotRemovedFailure(messageName string, oneofName string, fromFieldNumber int32) *text.Failure {
-	return newTextFailuref(`Message oneof %q on message %q had field "%d" removed.`, oneofName, messageName, fromFieldNumber)
+	return newTextFailuref(getFullyQualifiedName(messageName, oneofName), `Message oneof %q on message %q had field "%d" removed.`, oneofName, messageName, fromFieldNumber)
 }
 
 func getMessageOneofFieldNumbersMap(fieldNumbers []int32) map[int32]struct{} {
@@ -46,5 +46,5 @@ func checkMessagesNotDeletedMap(addFailure func(*text.Failure), from map[string]
 }
 
 func newMessagesNotDeletedFailure(messageName string) *text.Failure {
-	return newTextFailuref(`Message %q was deleted.`, messageName)
+	return newTextFailuref(messageName, `Message %q was deleted.`, messageName)
 }
@@ -28,10 +28,14 @@ import (
 
 func checkPackagesNoBetaDeps(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error {
 	// this check is only run if WithIncludeBeta is not set
-	// so this map will only contain stable packages
+	// so this map will mostly contain stable packages, but unstable
+	// packages are kept if their stability level is not excluded
 	for _, toPackage := range to.PackageNameToPackage() {
+		if protostrs.PackageStability(toPackage.FullyQualifiedName()) != protostrs.StabilityStable {
+			continue
+		}
 		for _, depPackageName := range toPackage.ProtoMessage().DependencyNames {
-			if _, betaVersion, ok := protostrs.MajorBetaVersion(depPackageName); ok && betaVersion > 0 {
+			if protostrs.PackageStability(depPackageName) != protostrs.StabilityStable {
 				addFailure(newPackagesNoBetaDepsFailure(toPackage.FullyQualifiedName(), depPackageName))
 			}
 		}
@@ -40,5 +44,5 @@ func checkPackagesNoBetaDeps(addFailure func(*text.Failure), from *extract.Packa
 }
 
 func newPackagesNoBetaDepsFailure(packageName string, depPackageName string) *text.Failure {
-	return newTextFailuref(`Package %q depends on beta package %q which is not allowed.`, packageName, depPackageName)
+	return newTextFailuref(packageName, `Package %q depends on %s package %q which is not allowed.`, packageName, protostrs.PackageStability(depPackageName), depPackageName)
 }
@@ -0,0 +1,43 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package breaking
+
+import (
+	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/protostrs"
+	"github.com/uber/prototool/internal/text"
+)
+
+func checkPackagesNoLessStableDeps(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error {
+	for _, toPackage := range to.PackageNameToPackage() {
+		stability := protostrs.PackageStability(toPackage.FullyQualifiedName())
+		for _, depPackageName := range toPackage.ProtoMessage().DependencyNames {
+			if protostrs.PackageStability(depPackageName) > stability {
+				addFailure(newPackagesNoLessStableDepsFailure(toPackage.FullyQualifiedName(), depPackageName))
+			}
+		}
+	}
+	return nil
+}
+
+func newPackagesNoLessStableDepsFailure(packageName string, depPackageName string) *text.Failure {
+	return newTextFailuref(packageName, `Package %q is %s but depends on %s package %q which is less stable.`, packageName, protostrs.PackageStability(packageName), protostrs.PackageStability(depPackageName), depPackageName)
+}
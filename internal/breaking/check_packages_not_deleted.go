@@ -37,5 +37,5 @@ func checkPackagesNotDeleted(addFailure func(*text.Failure), from *extract.Packa
 }
 
 func newPackagesNotDeletedFailure(packageName string) *text.Failure {
-	return newTextFailuref(`Package %q was deleted.`, packageName)
+	return newTextFailuref(packageName, `Package %q was deleted.`, packageName)
 }
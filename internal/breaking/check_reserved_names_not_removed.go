@@ -0,0 +1,62 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package breaking
+
+import (
+	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/text"
+)
+
+func checkReservedNamesNotRemoved(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error {
+	if err := forEachMessagePair(addFailure, from, to, checkReservedNamesNotRemovedMessage); err != nil {
+		return err
+	}
+	return forEachEnumPair(addFailure, from, to, checkReservedNamesNotRemovedEnum)
+}
+
+func checkReservedNamesNotRemovedMessage(addFailure func(*text.Failure), from *extract.Message, to *extract.Message) error {
+	for _, reservedName := range getRemovedReservedNames(from.ProtoMessage().ReservedNames, to.ProtoMessage().ReservedNames) {
+		addFailure(newReservedNamesNotRemovedFailure("message", from.FullyQualifiedName(), reservedName))
+	}
+	return nil
+}
+
+func checkReservedNamesNotRemovedEnum(addFailure func(*text.Failure), from *extract.Enum, to *extract.Enum) error {
+	for _, reservedName := range getRemovedReservedNames(from.ProtoMessage().ReservedNames, to.ProtoMessage().ReservedNames) {
+		addFailure(newReservedNamesNotRemovedFailure("enum", from.FullyQualifiedName(), reservedName))
+	}
+	return nil
+}
+
+func getRemovedReservedNames(from []string, to []string) []string {
+	isReserved := newContainsFunc(to)
+	var removed []string
+	for _, fromReservedName := range from {
+		if !isReserved(fromReservedName) {
+			removed = append(removed, fromReservedName)
+		}
+	}
+	return removed
+}
+
+func newReservedNamesNotRemovedFailure(elementType string, elementName string, reservedName string) *text.Failure {
+	return newTextFailuref(elementName, `Reserved name %q on %s %q was removed.`, reservedName, elementType, elementName)
+}
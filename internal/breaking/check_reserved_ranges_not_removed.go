@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package breaking
+
+import (
+	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/text"
+
+	reflectv1 "github.com/uber/prototool/internal/reflect/gen/uber/proto/reflect/v1"
+)
+
+func checkReservedRangesNotRemoved(addFailure func(*text.Failure), from *extract.PackageSet, to *extract.PackageSet) error {
+	if err := forEachMessagePair(addFailure, from, to, checkReservedRangesNotRemovedMessage); err != nil {
+		return err
+	}
+	return forEachEnumPair(addFailure, from, to, checkReservedRangesNotRemovedEnum)
+}
+
+func checkReservedRangesNotRemovedMessage(addFailure func(*text.Failure), from *extract.Message, to *extract.Message) error {
+	for _, reservedRange := range getRemovedReservedRanges(from.ProtoMessage().ReservedRanges, to.ProtoMessage().ReservedRanges) {
+		addFailure(newReservedRangesNotRemovedFailure("message", from.FullyQualifiedName(), reservedRange))
+	}
+	return nil
+}
+
+func checkReservedRangesNotRemovedEnum(addFailure func(*text.Failure), from *extract.Enum, to *extract.Enum) error {
+	for _, reservedRange := range getRemovedReservedRanges(from.ProtoMessage().ReservedRanges, to.ProtoMessage().ReservedRanges) {
+		addFailure(newReservedRangesNotRemovedFailure("enum", from.FullyQualifiedName(), reservedRange))
+	}
+	return nil
+}
+
+// getRemovedReservedRanges returns the ranges in from that are not
+// entirely reserved in to.
+//
+// Ranges can be split, merged, or extended, as long as every number
+// that was reserved is still reserved.
+func getRemovedReservedRanges(from []*reflectv1.ReservedRange, to []*reflectv1.ReservedRange) []*reflectv1.ReservedRange {
+	var removed []*reflectv1.ReservedRange
+	for _, fromReservedRange := range from {
+		if !isRangeReserved(to, fromReservedRange.Start, fromReservedRange.End) {
+			removed = append(removed, fromReservedRange)
+		}
+	}
+	return removed
+}
+
+func newReservedRangesNotRemovedFailure(elementType string, elementName string, reservedRange *reflectv1.ReservedRange) *text.Failure {
+	if reservedRange.Start == reservedRange.End {
+		return newTextFailuref(elementName, `Reserved number "%d" on %s %q was removed.`, reservedRange.Start, elementType, elementName)
+	}
+	return newTextFailuref(elementName, `Reserved range "%d to %d" on %s %q was removed.`, reservedRange.Start, reservedRange.End, elementType, elementName)
+}
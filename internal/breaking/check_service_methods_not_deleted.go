@@ -41,5 +41,5 @@ func checkServiceMethodsNotDeletedService(addFailure func(*text.Failure), from *
 }
 
 func newServiceMethodsNotDeletedFailure(serviceName string, methodName string) *text.Failure {
-	return newTextFailuref(`Service method %q on service %q was deleted.`, methodName, serviceName)
+	return newTextFailuref(getFullyQualifiedName(serviceName, methodName), `Service method %q on service %q was deleted.`, methodName, serviceName)
 }
@@ -45,5 +45,5 @@ func newServiceMethodsSameClientStreamingFailure(serviceName string, methodName
 	if fromStreaming {
 		fromStreamingString, toStreamingString = toStreamingString, fromStreamingString
 	}
-	return newTextFailuref(`Service method %q on service %q changed from %s to %s.`, methodName, serviceName, fromStreamingString, toStreamingString)
+	return newTextFailuref(getFullyQualifiedName(serviceName, methodName), `Service method %q on service %q changed from %s to %s.`, methodName, serviceName, fromStreamingString, toStreamingString)
 }
@@ -40,5 +40,5 @@ func checkServiceMethodsSameRequestTypeServiceMethod(addFailure func(*text.Failu
 }
 
 func newServiceMethodsSameRequestTypeFailure(serviceName string, methodName string, fromTypeName string, toTypeName string) *text.Failure {
-	return newTextFailuref(`Service method %q on service %q changed request type from %q to %q.`, methodName, serviceName, fromTypeName, toTypeName)
+	return newTextFailuref(getFullyQualifiedName(serviceName, methodName), `Service method %q on service %q changed request type from %q to %q.`, methodName, serviceName, fromTypeName, toTypeName)
 }
@@ -40,5 +40,5 @@ func checkServiceMethodsSameResponseTypeServiceMethod(addFailure func(*text.Fail
 }
 
 func newServiceMethodsSameResponseTypeFailure(serviceName string, methodName string, fromTypeName string, toTypeName string) *text.Failure {
-	return newTextFailuref(`Service method %q on service %q changed response type from %q to %q.`, methodName, serviceName, fromTypeName, toTypeName)
+	return newTextFailuref(getFullyQualifiedName(serviceName, methodName), `Service method %q on service %q changed response type from %q to %q.`, methodName, serviceName, fromTypeName, toTypeName)
 }
@@ -43,5 +43,5 @@ func checkServicesNotDeletedMap(addFailure func(*text.Failure), fullyQualifiedNa
 }
 
 func newServicesNotDeletedFailure(serviceName string) *text.Failure {
-	return newTextFailuref(`Service %q was deleted.`, serviceName)
+	return newTextFailuref(serviceName, `Service %q was deleted.`, serviceName)
 }
@@ -22,9 +22,12 @@ package breaking
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/uber/prototool/internal/extract"
 	"github.com/uber/prototool/internal/text"
+
+	reflectv1 "github.com/uber/prototool/internal/reflect/gen/uber/proto/reflect/v1"
 )
 
 func forEachPackagePair(
@@ -229,6 +232,56 @@ func forEachEnumValuePair(
 	)
 }
 
+func forEachExtensionPair(
+	addFailure func(*text.Failure),
+	from *extract.PackageSet,
+	to *extract.PackageSet,
+	f func(
+		func(*text.Failure),
+		*extract.Extension,
+		*extract.Extension,
+	) error,
+) error {
+	if err := forEachPackagePair(
+		addFailure,
+		from,
+		to,
+		func(addFailure func(*text.Failure), fromPackage *extract.Package, toPackage *extract.Package) error {
+			return forEachExtensionPairInternal(addFailure, fromPackage.ExtensionNameToExtension(), toPackage.ExtensionNameToExtension(), f)
+		},
+	); err != nil {
+		return err
+	}
+	return forEachMessagePair(
+		addFailure,
+		from,
+		to,
+		func(addFailure func(*text.Failure), fromMessage *extract.Message, toMessage *extract.Message) error {
+			return forEachExtensionPairInternal(addFailure, fromMessage.NestedExtensionNameToExtension(), toMessage.NestedExtensionNameToExtension(), f)
+		},
+	)
+}
+
+func forEachExtensionPairInternal(
+	addFailure func(*text.Failure),
+	fromExtensionNameToExtension map[string]*extract.Extension,
+	toExtensionNameToExtension map[string]*extract.Extension,
+	f func(
+		func(*text.Failure),
+		*extract.Extension,
+		*extract.Extension,
+	) error,
+) error {
+	for fromExtensionName, fromExtension := range fromExtensionNameToExtension {
+		if toExtension, ok := toExtensionNameToExtension[fromExtensionName]; ok {
+			if err := f(addFailure, fromExtension, toExtension); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func forEachServicePair(
 	addFailure func(*text.Failure),
 	from *extract.PackageSet,
@@ -287,8 +340,45 @@ func forEachServiceMethodPair(
 	)
 }
 
-func newTextFailuref(format string, args ...interface{}) *text.Failure {
+// isNumberReserved returns true if the number is within one of the reserved ranges.
+func isNumberReserved(reservedRanges []*reflectv1.ReservedRange, number int32) bool {
+	return isRangeReserved(reservedRanges, number, number)
+}
+
+// isRangeReserved returns true if every number from start to end inclusive
+// is within the reserved ranges, which may overlap or be adjacent.
+func isRangeReserved(reservedRanges []*reflectv1.ReservedRange, start int32, end int32) bool {
+	sorted := make([]*reflectv1.ReservedRange, len(reservedRanges))
+	copy(sorted, reservedRanges)
+	sort.Slice(sorted, func(i int, j int) bool { return sorted[i].Start < sorted[j].Start })
+	// next is the first number that has not been found to be reserved yet
+	next := int64(start)
+	for _, reservedRange := range sorted {
+		if int64(reservedRange.Start) > next {
+			break
+		}
+		if int64(reservedRange.End)+1 > next {
+			next = int64(reservedRange.End) + 1
+		}
+		if next > int64(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// newTextFailuref returns a new Failure for the element with the given path,
+// see extract.PackageSet.Location.
+func newTextFailuref(path string, format string, args ...interface{}) *text.Failure {
 	return &text.Failure{
 		Message: fmt.Sprintf(format, args...),
+		Path:    path,
+	}
+}
+
+func getFullyQualifiedName(encapsulatingFullyQualifiedName string, name string) string {
+	if encapsulatingFullyQualifiedName == "" {
+		return name
 	}
+	return encapsulatingFullyQualifiedName + "." + name
 }
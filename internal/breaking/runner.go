@@ -21,12 +21,23 @@
 package breaking
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/uber/prototool/internal/extract"
+	"github.com/uber/prototool/internal/protostrs"
 	"github.com/uber/prototool/internal/settings"
 	"github.com/uber/prototool/internal/text"
 	"go.uber.org/zap"
 )
 
+var unstableStabilities = []protostrs.Stability{
+	protostrs.StabilityBeta,
+	protostrs.StabilityAlpha,
+	protostrs.StabilityTest,
+}
+
 type runner struct {
 	logger   *zap.Logger
 	checkers []Checker
@@ -44,35 +55,175 @@ func newRunner(options ...RunnerOption) *runner {
 }
 
 func (r *runner) Run(config settings.BreakConfig, from *extract.PackageSet, to *extract.PackageSet) ([]*text.Failure, error) {
-	var err error
-	if !config.IncludeBeta {
-		from, err = from.WithoutBeta()
+	checkers, err := getCheckers(r.checkers, config)
+	if err != nil {
+		return nil, err
+	}
+	if excludeStabilities := getExcludeStabilities(config); len(excludeStabilities) > 0 {
+		isExcluded := func(packageName string) bool {
+			_, ok := excludeStabilities[protostrs.PackageStability(packageName)]
+			return ok
+		}
+		from, err = from.Without(isExcluded, nil)
 		if err != nil {
 			return nil, err
 		}
-		to, err = to.WithoutBeta()
+		to, err = to.Without(isExcluded, nil)
 		if err != nil {
 			return nil, err
 		}
 	}
-	checkers := r.checkers
-	// if includeBeta, do not do the check
-	// else if not including beta, unless allow beta deps, do not do the check
-	if !config.IncludeBeta && !config.AllowBetaDeps {
-		checkers = append(checkers, PackagesNoBetaDepsChecker)
-	}
 	var failures []*text.Failure
 	for _, checker := range checkers {
+		checkerFrom, checkerTo, err := withoutIgnores(checker, config, from, to)
+		if err != nil {
+			return nil, err
+		}
 		if err := checker.Check(
 			func(failure *text.Failure) {
 				failure.LintID = checker.ID
+				setFailureLocation(failure, to)
 				failures = append(failures, failure)
 			},
-			from,
-			to,
+			checkerFrom,
+			checkerTo,
 		); err != nil {
 			return nil, err
 		}
 	}
 	return failures, nil
 }
+
+// setFailureLocation sets the location of the failure to the location of
+// its element in to, or of the closest enclosing element if the element is
+// not in to, for example if it was deleted.
+func setFailureLocation(failure *text.Failure, to *extract.PackageSet) {
+	for path := failure.Path; path != ""; path = getEnclosingPath(path) {
+		if location := to.Location(path); location != nil {
+			failure.Filename = location.FileName
+			failure.Line = location.Line
+			failure.Column = location.Column
+			return
+		}
+	}
+}
+
+// getEnclosingPath returns the path with the last component removed, or
+// the empty string if there is only one component.
+func getEnclosingPath(path string) string {
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// getExcludeStabilities returns the stability levels of the packages that
+// are excluded from breaking change detection for the config.
+func getExcludeStabilities(config settings.BreakConfig) map[protostrs.Stability]struct{} {
+	excludeStabilities := make(map[protostrs.Stability]struct{})
+	if len(config.ExcludeStabilities) > 0 {
+		for _, stability := range config.ExcludeStabilities {
+			excludeStabilities[stability] = struct{}{}
+		}
+	} else if !config.IncludeBeta {
+		for _, stability := range unstableStabilities {
+			excludeStabilities[stability] = struct{}{}
+		}
+	}
+	if config.IgnoreUnstablePackages {
+		for _, stability := range unstableStabilities {
+			excludeStabilities[stability] = struct{}{}
+		}
+	}
+	return excludeStabilities
+}
+
+// withoutIgnores returns copies of from and to without the packages and
+// files that are ignored for the checker, or from and to if there are none.
+func withoutIgnores(checker Checker, config settings.BreakConfig, from *extract.PackageSet, to *extract.PackageSet) (*extract.PackageSet, *extract.PackageSet, error) {
+	packages := config.IgnoreIDToPackages[checker.ID]
+	fileNames := config.IgnoreIDToFileNames[checker.ID]
+	if len(packages) == 0 && len(fileNames) == 0 {
+		return from, to, nil
+	}
+	ignorePackage := newContainsFunc(packages)
+	ignoreFileName := newContainsFunc(fileNames)
+	from, err := from.Without(ignorePackage, ignoreFileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err = to.Without(ignorePackage, ignoreFileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// getCheckers returns the non-optional checkers for the mode of the config,
+// with the included checkers added and the excluded checkers removed.
+//
+// The order of checkers is kept, as there are some dependencies between checkers.
+func getCheckers(checkers []Checker, config settings.BreakConfig) ([]Checker, error) {
+	checkerIDs := map[string]struct{}{
+		PackagesNoBetaDepsChecker.ID: {},
+	}
+	for _, checker := range checkers {
+		checkerIDs[checker.ID] = struct{}{}
+	}
+	for _, ids := range [][]string{config.IncludeIDs, config.ExcludeIDs, getMapKeys(config.IgnoreIDToPackages), getMapKeys(config.IgnoreIDToFileNames)} {
+		for _, id := range ids {
+			if _, ok := checkerIDs[id]; !ok {
+				return nil, fmt.Errorf("unknown breaking checker id in configuration file: %s", id)
+			}
+		}
+	}
+	isIncluded := newContainsFunc(config.IncludeIDs)
+	isExcluded := newContainsFunc(config.ExcludeIDs)
+	modeCheckers := make([]Checker, 0, len(checkers)+1)
+	for _, checker := range checkers {
+		if ((!checker.Optional && checkerHasMode(checker, config.Mode)) || isIncluded(checker.ID)) && !isExcluded(checker.ID) {
+			modeCheckers = append(modeCheckers, checker)
+		}
+	}
+	if isExcluded(PackagesNoBetaDepsChecker.ID) {
+		return modeCheckers, nil
+	}
+	// if includeBeta, do not do the check
+	// else if not including beta, unless allow beta deps, do not do the check
+	if (!config.IncludeBeta && !config.AllowBetaDeps) || isIncluded(PackagesNoBetaDepsChecker.ID) {
+		return append(modeCheckers, PackagesNoBetaDepsChecker), nil
+	}
+	return modeCheckers, nil
+}
+
+func checkerHasMode(checker Checker, mode settings.BreakMode) bool {
+	if len(checker.Modes) == 0 {
+		return true
+	}
+	for _, checkerMode := range checker.Modes {
+		if checkerMode == mode {
+			return true
+		}
+	}
+	return false
+}
+
+func newContainsFunc(values []string) func(string) bool {
+	valueMap := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		valueMap[value] = struct{}{}
+	}
+	return func(value string) bool {
+		_, ok := valueMap[value]
+		return ok
+	}
+}
+
+func getMapKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
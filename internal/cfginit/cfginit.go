@@ -60,6 +60,22 @@ protoc:
   # Setting this will ignore unused imports.
   {{.V}}allow_unused_imports: true
 
+# Compile directives.
+{{.V}}compile:
+  # The backend to compile with, either "protoc" or "go".
+  # By default, protoc is downloaded and run.
+  # The go backend compiles in-process without downloading or running protoc,
+  # and runs plugins directly. The code generators built into protoc, such as
+  # java, cannot be used with the go backend.
+  {{.V}}backend: go
+
+  # Compiled directories are cached in the cache directory, see the cache
+  # command, so that directories that have not changed are not compiled
+  # again. The cache is never pruned, so it grows as files change until
+  # it is removed with "prototool cache delete".
+  # Set this to true to never use or write to this cache.
+  {{.V}}disable_cache: true
+
 # Create directives.
 {{.V}}create:
   # List of mappings from relative directory to base package.
@@ -111,6 +127,51 @@ lint:
 {{.V}}    remove:
 {{.V}}      - ENUM_NAMES_CAMEL_CASE
 
+  # Custom lint rules.
+  # These are always run in addition to the configured linters, and can be
+  # removed with rules.remove and ignored with ignores.
+  # The id must not be the id of a built-in linter.
+  # If directories is set, the rule only applies to files within the given
+  # directories, which must be relative.
+  # Regular expressions are not anchored.
+{{.V}}  custom_rules:
+    # Messages with names matching message_name_regexp must have a field
+    # named field_name. If message_name_regexp is not set, this applies
+    # to all messages.
+{{.V}}    - id: REQUESTS_HAVE_ID
+{{.V}}      purpose: Verifies that all request messages have an id field.
+{{.V}}      type: message_field_required
+{{.V}}      message_name_regexp: Request$
+{{.V}}      field_name: id
+    # Field names must not match field_name_regexp. If message_name_regexp is
+    # set, this only applies to fields of messages with matching names.
+{{.V}}    - id: FIELD_NAMES_NO_DATA
+{{.V}}      type: field_name_forbidden
+{{.V}}      field_name_regexp: ^data$
+    # Files must set the file option option_name.
+{{.V}}    - id: API_FILES_REQUIRE_GO_PACKAGE
+{{.V}}      type: file_option_required
+{{.V}}      directories:
+{{.V}}        - path/to/api
+{{.V}}      option_name: go_package
+
+  # Lint plugins.
+  # These are executables that are given the compiled FileDescriptorSet with
+  # imports and source info on stdin and the names of the files to check as
+  # arguments, and print failures as JSON objects to stdout.
+  # These are always run in addition to the configured linters, and can be
+  # removed with rules.remove and ignored with ignores.
+  # The id must not be the id of a built-in linter or custom rule.
+{{.V}}  plugins:
+{{.V}}    - id: MY_COMPANY_CHECKS
+{{.V}}      purpose: Verifies that all files follow the My Company API guidelines.
+      # Relative paths with a directory are relative to this file,
+      # otherwise the executable is looked up on the PATH.
+{{.V}}      path: path/to/my-company-lint
+      # Arguments to pass before the file names.
+{{.V}}      args:
+{{.V}}        - --strict
+
   # The path to the file header or the file header content for all Protobuf files.
   # If either path or content is set and the FILE_HEADER linter is turned on,
   # files will be checked to begin with the given header, and format --fix
@@ -142,13 +203,44 @@ lint:
 {{.V}}break:
   # Include beta packages in breaking change detection.
   # Beta packages have the form "foo.bar.vMAJORbetaBETA" where MAJOR > 0 and BETA > 0.
-  # By default, beta packages are ignored.
+  # This also includes alpha and test packages of the forms "foo.bar.vMAJORalphaALPHA"
+  # and "foo.bar.vMAJORtestTEST".
+  # By default, beta, alpha and test packages are ignored.
   {{.V}}include_beta: true
   # Allow stable packages to depend on beta packages.
   # By default, the breaking change detector will error if a stable package
   # depends on a breaking package.
   # If include_beta is true, this is implicitly set.
   {{.V}}allow_beta_deps: true
+  # The kind of compatibility to check, one of source, wire_json or wire.
+  # With wire_json, renaming or deleting packages, enums, messages and oneofs is allowed.
+  # With wire, renaming enum values and message fields, and changing the type of
+  # message fields to a wire-compatible type, is also allowed.
+  # The default is source.
+  {{.V}}mode: wire_json
+  # Add or remove breaking change checkers by ID.
+  # Checkers are always run in the same order.
+  {{.V}}rules:
+  {{.V}}  add:
+  {{.V}}    - MESSAGES_NOT_DELETED
+  {{.V}}  remove:
+  {{.V}}    - ENUM_VALUES_SAME_NAME
+  # Ignore checkers for packages or for files relative to this file.
+  # Ignoring a file ignores the top-level enums, messages, services and extensions declared in it.
+  {{.V}}ignores:
+  {{.V}}  - id: MESSAGE_FIELDS_NOT_DELETED
+  {{.V}}    packages:
+  {{.V}}      - foo.v1
+  {{.V}}    files:
+  {{.V}}      - foo/v2/deprecated.proto
+  # Ignore alpha, beta and test packages regardless of include_beta.
+  {{.V}}ignore_unstable_packages: true
+  # The stability levels of packages to exclude from breaking change detection,
+  # out of stable, beta, alpha and test. This takes precedence over include_beta.
+  # By default, beta, alpha and test packages are excluded.
+  {{.V}}exclude_stability_levels:
+  {{.V}}  - alpha
+  {{.V}}  - test
 
 # Code generation directives.
 {{.V}}generate:
@@ -48,6 +48,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
@@ -805,6 +806,245 @@ func TestLintConfigDataOverride(t *testing.T) {
 	)
 }
 
+func TestLintCustomRules(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`5:1:REQUESTS_HAVE_ID
+		6:3:FIELD_NAMES_NO_DATA
+		12:5:FIELD_NAMES_NO_DATA
+		18:5:FIELD_NAMES_NO_DATA`,
+		"testdata/lint/customrules/custom_rules.proto",
+	)
+	assertDoLintFile(
+		t,
+		false,
+		`1:1:API_FILES_REQUIRE_GO_PACKAGE`,
+		"testdata/lint/customrules/api/api.proto",
+	)
+	assertDoLintFile(
+		t,
+		false,
+		`5:1:REQUESTS_HAVE_ID`,
+		"testdata/lint/customrules/custom_rules.proto",
+		"--config-data",
+		`{"compile":{"backend":"go"},"lint":{"group":"empty","rules":{"remove":["FIELD_NAMES_NO_DATA"]},"custom_rules":[{"id":"FIELD_NAMES_NO_DATA","type":"field_name_forbidden","field_name_regexp":"^data$"},{"id":"REQUESTS_HAVE_ID","type":"message_field_required","message_name_regexp":"Request$","field_name":"id"}]}}`,
+	)
+	assertDoLintFile(
+		t,
+		true,
+		``,
+		"testdata/lint/customrules/custom_rules.proto",
+		"--config-data",
+		`{"compile":{"backend":"go"},"lint":{"group":"empty","custom_rules":[{"id":"FIELD_NAMES_NO_DATA","type":"field_name_forbidden","message_name_regexp":"^Bar$","field_name_regexp":"^data$"}]}}`,
+	)
+	assertExact(
+		t,
+		true,
+		true,
+		1,
+		`custom lint rule SYNTAX_PROTO3 has the same id as a built-in linter`,
+		"lint",
+		"testdata/lint/customrules/custom_rules.proto",
+		"--config-data",
+		`{"compile":{"backend":"go"},"lint":{"custom_rules":[{"id":"syntax_proto3","type":"file_option_required","option_name":"go_package"}]}}`,
+	)
+	assertExact(
+		t,
+		true,
+		true,
+		1,
+		`custom lint rule FOO: field_name is required for type message_field_required`,
+		"lint",
+		"testdata/lint/customrules/custom_rules.proto",
+		"--config-data",
+		`{"lint":{"custom_rules":[{"id":"foo","type":"message_field_required"}]}}`,
+	)
+}
+
+func TestLintPlugins(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`5:1:PLUGIN_MESSAGES`,
+		"testdata/lint/plugins/foo.proto",
+	)
+	assertExact(
+		t,
+		true,
+		false,
+		255,
+		`testdata/lint/plugins/foo.proto:5:1:Checked foo.proto. This can be suppressed by adding "@suppresswarnings plugin" to the comment.`,
+		"lint",
+		"testdata/lint/plugins",
+	)
+	assertDo(
+		t,
+		true,
+		true,
+		0,
+		`lint:
+  ignores:
+  - id: PLUGIN_MESSAGES
+    files:
+    - foo.proto`,
+		"lint",
+		"--generate-ignores",
+		"testdata/lint/plugins",
+	)
+	assertExact(
+		t,
+		true,
+		true,
+		1,
+		`lint plugin FAILING failed: exit status 1`,
+		"lint",
+		"testdata/lint/plugins/foo.proto",
+		"--config-data",
+		`{"compile":{"backend":"go"},"lint":{"group":"empty","plugins":[{"id":"failing","path":"false"}]}}`,
+	)
+	// plugins are given the names of files relative to the include path
+	assertExact(
+		t,
+		true,
+		false,
+		255,
+		`testdata/lint/pluginsincludes/proto/foo/foo.proto:5:1:Checked foo/foo.proto.`,
+		"lint",
+		"testdata/lint/pluginsincludes",
+	)
+}
+
+func TestLintFix(t *testing.T) {
+	t.Parallel()
+	tmpDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	for _, filename := range []string{settings.DefaultConfigFilename, "fix.proto"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata/lint/fix", filename))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, filename), data, 0644))
+	}
+
+	assertExact(
+		t,
+		true,
+		false,
+		255,
+		`23:3:Field name "fooBAR" must be lower_snake_case.
+23:21:Inline comments are not allowed on fields, only comment above the type.`,
+		"lint",
+		"--fix",
+		"--error-format",
+		"line:column:message",
+		tmpDir,
+	)
+	golden, err := ioutil.ReadFile("testdata/lint/fix/fix.proto.golden")
+	require.NoError(t, err)
+	data, err := ioutil.ReadFile(filepath.Join(tmpDir, "fix.proto"))
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(data))
+
+	assertExact(
+		t,
+		true,
+		false,
+		255,
+		`can only set one of list-all-linters, list-linters, list-all-lint-groups, list-lint-group, diff-lint-groups, update-ignores, fix`,
+		"lint",
+		"--fix",
+		"--list-linters",
+		tmpDir,
+	)
+}
+
+func TestLintOutputFormat(t *testing.T) {
+	stdout, exitCode := testDo(
+		t,
+		true,
+		false,
+		"lint",
+		"testdata/lint/capitalized/message_name_not_capitalized.proto",
+		"--output-format",
+		"sarif",
+		"--config-data",
+		`{"compile":{"backend":"go"},"lint":{"rules":{"no_default":true,"add":["MESSAGE_NAMES_CAPITALIZED"]}}}`,
+	)
+	assert.Equal(t, 255, exitCode)
+	assert.JSONEq(
+		t,
+		fmt.Sprintf(`{
+  "$schema": "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "prototool",
+          "version": "%s",
+          "rules": [
+            {"id": "COMPILE", "shortDescription": {"text": "Checks that the files compile."}},
+            {"id": "MESSAGE_NAMES_CAPITALIZED", "shortDescription": {"text": "Verifies that all non-extended message names are Capitalized."}}
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "MESSAGE_NAMES_CAPITALIZED",
+          "ruleIndex": 1,
+          "level": "error",
+          "message": {"text": "Message name \"baz\" must be capitalized."},
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {"uri": "testdata/lint/capitalized/message_name_not_capitalized.proto"},
+                "region": {"startLine": 11, "startColumn": 1}
+              }
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}`, vars.Version),
+		stdout,
+	)
+	assertExact(
+		t,
+		true,
+		false,
+		255,
+		`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="testdata/lint/capitalized/message_name_not_capitalized.proto">
+    <error line="11" column="1" severity="error" message="Message name &#34;baz&#34; must be capitalized." source="MESSAGE_NAMES_CAPITALIZED"></error>
+  </file>
+</checkstyle>`,
+		"lint",
+		"testdata/lint/capitalized/message_name_not_capitalized.proto",
+		"--output-format",
+		"checkstyle",
+		"--config-data",
+		`{"compile":{"backend":"go"},"lint":{"rules":{"no_default":true,"add":["MESSAGE_NAMES_CAPITALIZED"]}}}`,
+	)
+	assertExact(
+		t,
+		true,
+		true,
+		255,
+		"can only set one of json, output-format sarif",
+		"lint",
+		"testdata/lint/capitalized/message_name_not_capitalized.proto",
+		"--output-format",
+		"sarif",
+		"--json",
+	)
+}
+
 func TestGoldenFormat(t *testing.T) {
 	t.Parallel()
 	assertGoldenFormat(t, false, false, "testdata/format/proto3/foo/bar/bar.proto")
@@ -1477,6 +1717,128 @@ func TestGRPC(t *testing.T) {
 		`{"value":"hello"}`,
 		`--details`,
 	)
+	// the server does not support reflection so the file is compiled
+	assertGRPC(t,
+		0,
+		`{"value":"hello!"}`,
+		"testdata/grpc/grpc.proto",
+		"grpc.ExcitedService/Exclamation",
+		`{"value":"hello"}`,
+		`--reflect`,
+	)
+}
+
+func TestGRPCReflect(t *testing.T) {
+	t.Parallel()
+	assertGRPCReflect(t,
+		nil,
+		0,
+		`{"value":"hello!"}`,
+		"grpc.ExcitedService/Exclamation",
+		`{"value":"hello"}`,
+	)
+	assertGRPCReflect(t,
+		nil,
+		0,
+		`{"value":"h"}
+		{"value":"e"}
+		{"value":"l"}
+		{"value":"l"}
+		{"value":"o"}
+		{"value":"!"}`,
+		"grpc.ExcitedService/ExclamationServerStream",
+		`{"value":"hello"}`,
+	)
+	assertGRPCReflect(t,
+		nil,
+		0,
+		`{"value":"hello!"}
+		{"value":"salutations!"}`,
+		"grpc.ExcitedService/ExclamationBidiStream",
+		`{"value":"hello"}
+		{"value":"salutations"}`,
+	)
+	st, err := status.New(codes.InvalidArgument, "test").WithDetails(&grpcpb.Foo{Bar: "baz"})
+	assert.NoError(t, err)
+	assertGRPCReflect(t,
+		status.ErrorProto(st.Proto()),
+		1,
+		`{"status":{"code":3,"message":"test","details":[{"@type":"type.googleapis.com/grpc.Foo","bar":"baz"}]}}
+		{"trailers":{"content-type":["application/grpc"]}}
+		rpc error: code = InvalidArgument desc = test`,
+		"grpc.ExcitedService/Exclamation",
+		`{"value":"hello"}`,
+		`--details`,
+	)
+	assertGRPCReflect(t,
+		nil,
+		0,
+		`{"value":"hello!"}
+		{"value":"salutations!"}`,
+		"grpc.ExcitedService/ExclamationBidiStream",
+		`{"value":"hello"}
+
+		{"value":"salutations"}`,
+		`--stream`,
+	)
+	assertGRPCReflect(t,
+		nil,
+		0,
+		`{"request":{"value":"hello"}}
+		{"headers":{"content-type":["application/grpc"]}}
+		{"response":{"value":"hello!"}}`,
+		"grpc.ExcitedService/Exclamation",
+		`{"value":"hello"}
+		{"value":"salutations"}`,
+		`--stream`,
+		`--details`,
+	)
+	assertGRPCReflect(t,
+		nil,
+		1,
+		`Symbol not found: grpc.NotExcitedService`,
+		"grpc.NotExcitedService/Exclamation",
+		`{"value":"hello"}`,
+	)
+}
+
+func TestGRPCBench(t *testing.T) {
+	t.Parallel()
+	excitedTestCase := startExcitedTestCase(t, nil)
+	defer excitedTestCase.Close()
+	assertRegexp(t, true, true, 0,
+		`(?s)^{\n  "method": "grpc.ExcitedService/Exclamation",\n  "concurrency": 2,\n  "rate": 20,\n.*"calls": \d+,.*"latency_ms": {.*"p99": .*"status_codes": {\n    "OK": \d+\n  }\n}$`,
+		"grpc", "bench", "testdata/grpc/grpc.proto", "--address", excitedTestCase.Address(), "--method", "grpc.ExcitedService/Exclamation", "--data", `{"value":"hello"}`, "--connect-timeout", "500ms", "--concurrency", "2", "--rate", "20", "--duration", "200ms", "--json",
+	)
+	assertRegexp(t, true, true, 0,
+		`(?s)^Method:\s+grpc.ExcitedService/Exclamation\nConcurrency:\s+1\nRate:\s+unlimited\n.*Status codes:\s+\n  OK:\s+\d+$`,
+		"grpc", "bench", "testdata/grpc/grpc.proto", "--address", excitedTestCase.Address(), "--method", "grpc.ExcitedService/Exclamation", "--data", `{"value":"hello"}`, "--connect-timeout", "500ms", "--concurrency", "1", "--duration", "100ms",
+	)
+	assertExact(t, true, true, 255, "must set method", "grpc", "bench", "testdata/grpc/grpc.proto", "--address", excitedTestCase.Address(), "--data", `{"value":"hello"}`)
+}
+
+func TestGRPCShell(t *testing.T) {
+	t.Parallel()
+	excitedTestCase := startExcitedTestCase(t, nil)
+	defer excitedTestCase.Close()
+	assertDoStdin(t,
+		strings.NewReader(`methods
+call grpc.ExcitedService/Exclamation {"value":"hello"}
+call grpc.ExcitedService/ExclamationClientStream {"value":"hello"} {"value":"salutations"}
+headers
+`),
+		true,
+		true,
+		0,
+		`grpc.ExcitedService/Exclamation
+		grpc.ExcitedService/ExclamationBidiStream
+		grpc.ExcitedService/ExclamationClientStream
+		grpc.ExcitedService/ExclamationServerStream
+		{"value":"hello!"}
+		{"value":"hellosalutations!"}
+		foo:bar`,
+		"grpc", "shell", "testdata/grpc/grpc.proto", "--address", excitedTestCase.Address(), "--connect-timeout", "500ms", "--header", "foo:bar",
+	)
 }
 
 func TestVersion(t *testing.T) {
@@ -1489,6 +1851,35 @@ func TestVersionJSON(t *testing.T) {
 	assertRegexp(t, false, false, 0, fmt.Sprintf(`(?s){.*"version":.*"%s",.*"default_protoc_version":.*"%s".*}`, vars.Version, vars.DefaultProtocVersion), "version", "--json")
 }
 
+func TestBreakCheckIncludes(t *testing.T) {
+	t.Parallel()
+	tmpFile, err := ioutil.TempFile("", "")
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+	exitCode := do(true, []string{"descriptor-set", "--include-imports", "--include-source-info", "testdata/break/includes/from"}, os.Stdin, tmpFile, tmpFile)
+	require.NoError(t, tmpFile.Close())
+	require.Equal(t, 0, exitCode)
+
+	// files are named relative to the include path in FileDescriptorSets
+	assertExact(
+		t,
+		true,
+		false,
+		255,
+		`testdata/break/includes/to/proto/foo/foo.proto:5:1:Message field "2" on message "foo.Foo" was deleted.`,
+		"break", "check", "testdata/break/includes/to", "--descriptor-set-path", tmpFile.Name(),
+	)
+	assertExact(
+		t,
+		true,
+		false,
+		0,
+		``,
+		"break", "check", "testdata/break/includes/to", "--descriptor-set-path", tmpFile.Name(),
+		"--config-data", `{"protoc":{"includes":["proto"]},"compile":{"backend":"go"},"break":{"ignores":[{"id":"MESSAGE_FIELDS_NOT_DELETED","files":["proto/foo/foo.proto"]}]}}`,
+	)
+}
+
 func TestDescriptorSet(t *testing.T) {
 	t.Parallel()
 	for _, includeSourceInfo := range []bool{false, true} {
@@ -1879,6 +2270,17 @@ func assertGRPCExclamationError(t *testing.T, exclamationError error, expectedEx
 	assertDoStdin(t, strings.NewReader(jsonData), true, true, expectedExitCode, expectedLinePrefixes, append([]string{"grpc", filePath, "--address", excitedTestCase.Address(), "--method", method, "--stdin", "--connect-timeout", "500ms"}, extraFlags...)...)
 }
 
+// GRPC Server reflection assert
+//
+// The server supports reflection, so the proto files do not need to exist.
+func assertGRPCReflect(t *testing.T, exclamationError error, expectedExitCode int, expectedLinePrefixes string, method string, jsonData string, extraFlags ...string) {
+	grpcServer := grpc.NewServer()
+	reflection.Register(grpcServer)
+	excitedTestCase := startExcitedTestCaseWithServer(t, exclamationError, grpcServer)
+	defer excitedTestCase.Close()
+	assertDoStdin(t, strings.NewReader(jsonData), true, true, expectedExitCode, expectedLinePrefixes, append([]string{"grpc", "testdata/grpc/does-not-exist", "--address", excitedTestCase.Address(), "--method", method, "--stdin", "--connect-timeout", "500ms", "--reflect"}, extraFlags...)...)
+}
+
 // GRPC Server TLS assert
 func assertGRPCTLS(t *testing.T, expectedExitCode int, expectedLinePrefixes string, filePath string, method string, jsonData string, serverCrt string, serverKey string, caCrt string, extraFlags ...string) {
 	assertGRPCmTLS(t, expectedExitCode, expectedLinePrefixes, filePath, method, jsonData, serverCrt, serverKey, caCrt, "", "", "", extraFlags...)
@@ -26,13 +26,17 @@ import (
 
 type flags struct {
 	address           string
+	againstRegistry   string
 	cachePath         string
 	callTimeout       string
 	cacert            string
 	cert              string
+	concurrency       int
 	configData        string
 	connectTimeout    string
+	current           string
 	data              string
+	debounce          string
 	debug             bool
 	descriptorSetPath string
 	details           bool
@@ -42,10 +46,15 @@ type flags struct {
 	disableLint       bool
 	document          bool
 	dryRun            bool
+	duration          string
 	errorFormat       string
 	fix               bool
+	fixtures          string
 	gitBranch         string
+	gitRef            string
+	gitRepo           string
 	headers           []string
+	host              string
 	insecure          bool
 	includeImports    bool
 	includeSourceInfo bool
@@ -59,17 +68,24 @@ type flags struct {
 	lintMode          bool
 	method            string
 	name              string
+	outputFormat      string
 	outputPath        string
 	overwrite         bool
+	pipeline          string
 	pkg               string
+	port              int
 	protocBinPath     string
 	protocWKTPath     string
 	protocURL         string
+	rate              int
+	reflect           bool
 	serverName        string
 	stdin             bool
+	stream            bool
 	tls               bool
 	tmp               bool
 	uncomment         bool
+	version           string
 	generateIgnores   bool
 	walkTimeout       string
 }
@@ -78,6 +94,10 @@ func (f *flags) bindAddress(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.address, "address", "", "The GRPC endpoint to connect to. This is required.")
 }
 
+func (f *flags) bindAgainstRegistry(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.againstRegistry, "against-registry", "", "Check for breaking changes against every release in the given registry directory. Each sub-directory named by a semantic version, such as v1.2.0, is a release containing serialized FileDescriptorSets.")
+}
+
 func (f *flags) bindCachePath(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.cachePath, "cache-path", "", "The path to use for the cache, otherwise uses the default behavior. The user is expected to clean and manage this cache path. See prototool help cache update for more details.")
 }
@@ -86,6 +106,14 @@ func (f *flags) bindCallTimeout(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.callTimeout, "call-timeout", "60s", "The maximum time to for all calls to be completed.")
 }
 
+func (f *flags) bindCallTimeoutStream(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.callTimeout, "call-timeout", "", "The maximum time to for all calls to be completed. The default is 60s, or no timeout if --stream is set.")
+}
+
+func (f *flags) bindConcurrency(flagSet *pflag.FlagSet) {
+	flagSet.IntVar(&f.concurrency, "concurrency", 10, "The number of calls to make at the same time.")
+}
+
 func (f *flags) bindConfigData(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.configData, "config-data", "", "The configuration data to use instead of reading prototool.yaml or prototool.json files.\nThis will act as if there is a configuration file with the given data in the current directory, and no other configuration files recursively.\nThis is an advanced feature and is not recommended to be generally used.")
 }
@@ -94,16 +122,24 @@ func (f *flags) bindConnectTimeout(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.connectTimeout, "connect-timeout", "10s", "The maximum time to wait for the connection to be established.")
 }
 
+func (f *flags) bindCurrent(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.current, "current", "", "The current semantic version, for example v1.4.2. Required.")
+}
+
 func (f *flags) bindData(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.data, "data", "", "The GRPC request data in JSON format. Either this or --stdin is required.")
 }
 
+func (f *flags) bindDebounce(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.debounce, "debounce", "100ms", "The time to wait for more changes after a change before running the pipeline.")
+}
+
 func (f *flags) bindDebug(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.debug, "debug", false, "Run in debug mode, which will print out debug logging.")
 }
 
 func (f *flags) bindDescriptorSetPath(flagSet *pflag.FlagSet) {
-	flagSet.StringVarP(&f.descriptorSetPath, "descriptor-set-path", "f", "", "The path to the file containing a serialized FileDescriptorSet to check against.\nFileDescriptorSet files can be produced using the descriptor-set sub-command.\nThe default behavior is to check against a git branch or tag. This cannot be used with the --git-branch flag.")
+	flagSet.StringVarP(&f.descriptorSetPath, "descriptor-set-path", "f", "", "The path to the file containing a serialized FileDescriptorSet to check against.\nFileDescriptorSet files can be produced using the descriptor-set sub-command.\nThe default behavior is to check against a git branch or tag. This cannot be used with the --git-branch, --git-ref or --git-repo flags.")
 }
 
 func (f *flags) bindDetails(flagSet *pflag.FlagSet) {
@@ -134,6 +170,10 @@ func (f *flags) bindDocument(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.document, "document", false, "Document all available options. Automatically set if --uncomment is set.")
 }
 
+func (f *flags) bindDuration(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.duration, "duration", "10s", "The time to make calls for.")
+}
+
 func (f *flags) bindErrorFormat(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.errorFormat, "error-format", "filename:line:column:message", `The colon-separated fields to print out on error. Valid values are "filename:line:column:id:message".`)
 }
@@ -142,14 +182,30 @@ func (f *flags) bindFix(flagSet *pflag.FlagSet) {
 	flagSet.BoolVarP(&f.fix, "fix", "f", false, "Fix the file according to the Style Guide.")
 }
 
+func (f *flags) bindFixtures(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.fixtures, "fixtures", "", "The .json or .yaml file of responses to return for each method. Methods without a matching fixture return random responses.")
+}
+
 func (f *flags) bindGitBranch(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.gitBranch, "git-branch", "", "The git branch or tag to check against. The default is the default branch.")
 }
 
+func (f *flags) bindGitRef(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.gitRef, "git-ref", "", "The git ref to check against, for example a commit SHA or HEAD~1. This cannot be used with the --git-branch flag.")
+}
+
+func (f *flags) bindGitRepo(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.gitRepo, "git-repo", "", "The path or URL of the git repository to check against. The default is the git repository at the current directory.")
+}
+
 func (f *flags) bindHeaders(flagSet *pflag.FlagSet) {
 	flagSet.StringSliceVarP(&f.headers, "header", "H", []string{}, "Additional request headers in 'name:value' format.")
 }
 
+func (f *flags) bindHost(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.host, "host", "127.0.0.1", "The host to serve on. Use 0.0.0.0 to serve on all interfaces.")
+}
+
 func (f *flags) bindIncludeImports(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.includeImports, "include-imports", false, "Include all dependencies of the input files in the set, so that the set is self-contained.")
 }
@@ -170,6 +226,10 @@ func (f *flags) bindLintMode(flagSet *pflag.FlagSet) {
 	flagSet.BoolVarP(&f.lintMode, "lint", "l", false, "Write a lint error saying that the file is not formatted instead of writing the formatted file to stdout.")
 }
 
+func (f *flags) bindLintFix(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&f.fix, "fix", false, "Fix the lint failures that can be fixed automatically and overwrite the files, then print the remaining failures.")
+}
+
 func (f *flags) bindListAllLinters(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.listAllLinters, "list-all-linters", false, "List all available linters instead of running lint.")
 }
@@ -194,6 +254,10 @@ func (f *flags) bindName(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.name, "name", "", "The package name. This is required.")
 }
 
+func (f *flags) bindOutputFormat(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.outputFormat, "output-format", "text", "The format to print failures in. Valid values are \"text\", \"sarif\", \"junit\" and \"checkstyle\".\nThe text format uses the error-format and json flags. The other formats print all failures as a single SARIF 2.1.0 log, JUnit XML or Checkstyle XML report.")
+}
+
 func (f *flags) bindOutputPath(flagSet *pflag.FlagSet) {
 	flagSet.StringVarP(&f.outputPath, "output-path", "o", "", "Write the FileDescriptorSet to the given file path instead of outputting to stdout.")
 }
@@ -210,10 +274,18 @@ func (f *flags) bindPackage(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.pkg, "package", "", "The Protobuf package to use in the created file.")
 }
 
+func (f *flags) bindPort(flagSet *pflag.FlagSet) {
+	flagSet.IntVar(&f.port, "port", 0, "The port to serve on. If 0, a free port is chosen.")
+}
+
 func (f *flags) bindProtocURL(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.protocURL, "protoc-url", "", "The url to use to download the protoc zip file, otherwise uses GitHub Releases. Setting this option will ignore the config protoc.version setting.")
 }
 
+func (f *flags) bindPipeline(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.pipeline, "pipeline", "compile,lint,format", "The comma-separated steps to run when files change, out of compile, lint, format and generate.\nThe steps are always run in this order. The format step checks formatting like format --lint and does not overwrite files.")
+}
+
 func (f *flags) bindProtocBinPath(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.protocBinPath, "protoc-bin-path", "", "The path to the protoc binary. Setting this option will ignore the config protoc.version setting.\nThis flag must be used with protoc-wkt-path and must not be used with the protoc-url flag.\nThis setting can also be controlled using the $PROTOTOOL_PROTOC_BIN_PATH environment variable, however this flag takes precedence.")
 }
@@ -230,6 +302,10 @@ func (f *flags) bindUncomment(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.uncomment, "uncomment", false, "Uncomment the example config settings. Automatically sets --document.")
 }
 
+func (f *flags) bindVersion(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.version, "version", "", "The semantic version range of the releases to check against, such as \">=1.0.0 <2.0.0\" or \"^1.2\". Only valid with --against-registry. Defaults to all releases.")
+}
+
 func (f *flags) bindGenerateIgnores(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.generateIgnores, "generate-ignores", false, "Generate a lint.ignores configuration to stdout that reflects current lint failures.\nThis can be copied to your configuration file.")
 }
@@ -238,6 +314,10 @@ func (f *flags) bindTmp(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.tmp, "tmp", false, "Write the FileDescriptorSet to a temporary file and print the file path instead of outputting to stdout.")
 }
 
+func (f *flags) bindStream(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&f.stream, "stream", false, "Read the GRPC request data from stdin line by line with one JSON message per line, sending each message as soon as its line is read and printing responses as they are received. There is no call timeout unless --call-timeout is set. If set, --stdin is required.")
+}
+
 func (f *flags) bindTLS(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.tls, "tls", false, "Enable SSL/TLS connection to remote host.")
 }
@@ -258,6 +338,14 @@ func (f *flags) bindKey(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.key, "key", "", "File containing client key (private key) in pem encoded format to use for mutual TLS authentication. If set, --tls and --cert is required.")
 }
 
+func (f *flags) bindRate(flagSet *pflag.FlagSet) {
+	flagSet.IntVar(&f.rate, "rate", 0, "The maximum number of calls to start per second, or 0 for no maximum.")
+}
+
+func (f *flags) bindReflect(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&f.reflect, "reflect", false, "Resolve the method and types with the gRPC server reflection API instead of compiling the proto files. The proto files are compiled if the server does not support reflection.")
+}
+
 func (f *flags) bindServerName(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.serverName, "server-name", "", "Override expected server \"Common Name\" when validating TLS certificate. Should usually be set if using a HTTP proxy or an IP for the --address. If set, --tls is required.")
 }
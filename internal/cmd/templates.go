@@ -52,6 +52,7 @@ var (
 			flags.bindDisableLint(flagSet)
 			flags.bindErrorFormat(flagSet)
 			flags.bindJSON(flagSet)
+			flags.bindOutputFormat(flagSet)
 			flags.bindFix(flagSet)
 			flags.bindProtocURL(flagSet)
 			flags.bindProtocBinPath(flagSet)
@@ -60,23 +61,60 @@ var (
 		},
 	}
 
+	breakChangelogCmdTemplate = &cmdTemplate{
+		Use:   "changelog [dir]",
+		Short: "Print the additions, deprecations and removals since a previous version.",
+		Long: `This compares the input directory against a previous version in the same way as break check, and prints the packages, enums, enum values, messages, message fields, services, service methods and extensions that were added, deprecated or removed, as Markdown or with --json as JSON.
+
+This command must be run from the root of a git repository, and the input directory must be relative, unless --descriptor-set-path is specified.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.BreakChangelog(args, flags.gitBranch, flags.gitRef, flags.gitRepo, flags.descriptorSetPath)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindCachePath(flagSet)
+			flags.bindConfigData(flagSet)
+			flags.bindDescriptorSetPath(flagSet)
+			flags.bindGitBranch(flagSet)
+			flags.bindGitRef(flagSet)
+			flags.bindGitRepo(flagSet)
+			flags.bindJSON(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+			flags.bindWalkTimeout(flagSet)
+		},
+	}
+
 	breakCheckCmdTemplate = &cmdTemplate{
 		Use:   "check [dir]",
 		Short: "Check for breaking changes.",
-		Long:  `This command must be run from the root of a git repository, and the input directory must be relative, unless --descriptor-set-path is specified.`,
-		Args:  cobra.MaximumNArgs(1),
+		Long: `This command must be run from the root of a git repository, and the input directory must be relative, unless --descriptor-set-path or --against-registry is specified.
+
+With --git-repo, the input directory is checked against the same relative directory in the given git repository, and this command can be run from any directory.
+
+With --against-registry, the input directory is checked against every release in the given registry directory that matches --version, and each failure lists the releases it breaks.
+
+Failures are located at the element in the input directory, or at the closest enclosing element if the element was deleted. Failures for deleted packages have no location. With --json, the fully-qualified path of the element is printed as well.`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(runner exec.Runner, args []string, flags *flags) error {
-			return runner.BreakCheck(args, flags.gitBranch, flags.descriptorSetPath)
+			return runner.BreakCheck(args, flags.gitBranch, flags.gitRef, flags.gitRepo, flags.descriptorSetPath, flags.againstRegistry, flags.version)
 		},
 		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindAgainstRegistry(flagSet)
 			flags.bindCachePath(flagSet)
 			flags.bindConfigData(flagSet)
 			flags.bindDescriptorSetPath(flagSet)
+			flags.bindErrorFormat(flagSet)
 			flags.bindGitBranch(flagSet)
+			flags.bindGitRef(flagSet)
+			flags.bindGitRepo(flagSet)
 			flags.bindJSON(flagSet)
+			flags.bindOutputFormat(flagSet)
 			flags.bindProtocURL(flagSet)
 			flags.bindProtocBinPath(flagSet)
 			flags.bindProtocWKTPath(flagSet)
+			flags.bindVersion(flagSet)
 			flags.bindWalkTimeout(flagSet)
 		},
 	}
@@ -101,6 +139,34 @@ var (
 		},
 	}
 
+	breakSemverCmdTemplate = &cmdTemplate{
+		Use:   "semver [dir]",
+		Short: "Print the next semantic version based on the changes since a previous version. The --current flag is required.",
+		Long: `This compares the input directory against a previous version in the same way as break check, and prints the next semantic version after --current.
+
+The major version is incremented if there are breaking changes, the minor version is incremented if there are any other additions, deprecations or removals as printed by break changelog, and the patch version is incremented otherwise, for example if only comments or options changed. If the major version is zero, breaking changes increment the minor version.
+
+This command must be run from the root of a git repository, and the input directory must be relative, unless --descriptor-set-path is specified.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.BreakSemver(args, flags.gitBranch, flags.gitRef, flags.gitRepo, flags.descriptorSetPath, flags.current)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindCachePath(flagSet)
+			flags.bindConfigData(flagSet)
+			flags.bindCurrent(flagSet)
+			flags.bindDescriptorSetPath(flagSet)
+			flags.bindGitBranch(flagSet)
+			flags.bindGitRef(flagSet)
+			flags.bindGitRepo(flagSet)
+			flags.bindJSON(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+			flags.bindWalkTimeout(flagSet)
+		},
+	}
+
 	cacheUpdateCmdTemplate = &cmdTemplate{
 		Use:   "update [dirOrFile]",
 		Short: "Update the cache by downloading all artifacts.",
@@ -159,6 +225,7 @@ Artifacts are downloaded to the following directories based on flags and environ
 			flags.bindDryRun(flagSet)
 			flags.bindErrorFormat(flagSet)
 			flags.bindJSON(flagSet)
+			flags.bindOutputFormat(flagSet)
 			flags.bindProtocURL(flagSet)
 			flags.bindProtocBinPath(flagSet)
 			flags.bindProtocWKTPath(flagSet)
@@ -286,6 +353,7 @@ If Vim integration is set up, files will be generated when you open a new Protob
 			flags.bindDiffMode(flagSet)
 			flags.bindErrorFormat(flagSet)
 			flags.bindJSON(flagSet)
+			flags.bindOutputFormat(flagSet)
 			flags.bindLintMode(flagSet)
 			flags.bindOverwrite(flagSet)
 			flags.bindFix(flagSet)
@@ -309,6 +377,7 @@ If Vim integration is set up, files will be generated when you open a new Protob
 			flags.bindDryRun(flagSet)
 			flags.bindErrorFormat(flagSet)
 			flags.bindJSON(flagSet)
+			flags.bindOutputFormat(flagSet)
 			flags.bindProtocURL(flagSet)
 			flags.bindProtocBinPath(flagSet)
 			flags.bindProtocWKTPath(flagSet)
@@ -380,16 +449,37 @@ $ prototool grpc example \
 {"response":{"value":"l"}}
 {"response":{"value":"l"}}
 {"response":{"value":"o"}}
-{"response":{"value":"!"}}`,
+{"response":{"value":"!"}}
+
+Use "--stream" with "--stdin" to send each line of stdin as a request as soon as it is entered, and print responses as they are received. This is useful to debug client streaming and bidirectional streaming methods by hand. With "--details", requests are also printed when they are sent. There is no call timeout unless "--call-timeout" is set.
+
+$ prototool grpc example \
+  --address 0.0.0.0:8080 \
+  --method uber.foo.v1.ExcitedAPI/ExclamationBidiStream \
+  --stdin \
+  --stream
+{"value":"hello"}
+{"value": "hello!"}
+{"value":"salutations"}
+{"value": "salutations!"}
+
+Use "--reflect" to resolve the method, the request and response types, and the types of Any messages with the gRPC server reflection API instead of compiling your proto files. This lets you call servers whose proto files you do not have. If the server does not support reflection, your proto files are compiled as usual.
+
+$ prototool grpc \
+  --address 0.0.0.0:8080 \
+  --method uber.foo.v1.ExcitedAPI/Exclamation \
+  --data '{"value":"hello"}' \
+  --reflect
+{"value": "hello!"}`,
 		Args: cobra.MaximumNArgs(1),
 		Run: func(runner exec.Runner, args []string, flags *flags) error {
-			return runner.GRPC(args, flags.headers, flags.address, flags.method, flags.data, flags.callTimeout, flags.connectTimeout, flags.keepaliveTime, flags.stdin, flags.details, flags.tls, flags.insecure, flags.cacert, flags.cert, flags.key, flags.serverName)
+			return runner.GRPC(args, flags.headers, flags.address, flags.method, flags.data, flags.callTimeout, flags.connectTimeout, flags.keepaliveTime, flags.stdin, flags.details, flags.tls, flags.insecure, flags.cacert, flags.cert, flags.key, flags.serverName, flags.reflect, flags.stream)
 		},
 		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
 			flags.bindCachePath(flagSet)
 			flags.bindConfigData(flagSet)
 			flags.bindAddress(flagSet)
-			flags.bindCallTimeout(flagSet)
+			flags.bindCallTimeoutStream(flagSet)
 			flags.bindConnectTimeout(flagSet)
 			flags.bindData(flagSet)
 			flags.bindDetails(flagSet)
@@ -398,6 +488,169 @@ $ prototool grpc example \
 			flags.bindKeepaliveTime(flagSet)
 			flags.bindMethod(flagSet)
 			flags.bindStdin(flagSet)
+			flags.bindStream(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+			flags.bindReflect(flagSet)
+			flags.bindTLS(flagSet)
+			flags.bindInsecure(flagSet)
+			flags.bindCacert(flagSet)
+			flags.bindCert(flagSet)
+			flags.bindKey(flagSet)
+			flags.bindServerName(flagSet)
+			flags.bindWalkTimeout(flagSet)
+		},
+	}
+
+	grpcBenchCmdTemplate = &cmdTemplate{
+		Use:   "bench [dirOrFile]",
+		Short: "Benchmark a gRPC endpoint. Be sure to set the required flags address, method, and either data or stdin.",
+		Long: `This command compiles your proto files once, and then calls a unary or server streaming method on the address as many times as it can for the given duration, with the given number of calls at the same time and at most the given number of calls per second. The latency percentiles, the throughput and the number of calls for each status code are printed at the end.
+
+Either use "--data 'requestData'" as the JSON request, or "--stdin" to read one or more JSON requests from stdin, which are used in turn.
+
+$ prototool grpc bench example \
+  --address 0.0.0.0:8080 \
+  --method uber.foo.v1.ExcitedAPI/Exclamation \
+  --data '{"value":"hello"}' \
+  --concurrency 20 \
+  --rate 1000 \
+  --duration 30s
+Method:        uber.foo.v1.ExcitedAPI/Exclamation
+Concurrency:   20
+Rate:          1000/s
+Duration:      30.001s
+Calls:         30000
+Throughput:    999.97/s
+Latency:
+  min:         152µs
+  mean:        412µs
+  p50:         388µs
+  p90:         587µs
+  p95:         701µs
+  p99:         1.204ms
+  max:         9.871ms
+Status codes:
+  OK:          30000
+
+Use "--json" to print the results as JSON, with the latencies in milliseconds. The call timeout applies to each call.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.GRPCBench(args, flags.headers, flags.address, flags.method, flags.data, flags.callTimeout, flags.connectTimeout, flags.keepaliveTime, flags.stdin, flags.tls, flags.insecure, flags.cacert, flags.cert, flags.key, flags.serverName, flags.concurrency, flags.rate, flags.duration)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindCachePath(flagSet)
+			flags.bindConfigData(flagSet)
+			flags.bindAddress(flagSet)
+			flags.bindCallTimeout(flagSet)
+			flags.bindConcurrency(flagSet)
+			flags.bindConnectTimeout(flagSet)
+			flags.bindData(flagSet)
+			flags.bindDuration(flagSet)
+			flags.bindErrorFormat(flagSet)
+			flags.bindHeaders(flagSet)
+			flags.bindJSON(flagSet)
+			flags.bindKeepaliveTime(flagSet)
+			flags.bindMethod(flagSet)
+			flags.bindRate(flagSet)
+			flags.bindStdin(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+			flags.bindTLS(flagSet)
+			flags.bindInsecure(flagSet)
+			flags.bindCacert(flagSet)
+			flags.bindCert(flagSet)
+			flags.bindKey(flagSet)
+			flags.bindServerName(flagSet)
+			flags.bindWalkTimeout(flagSet)
+		},
+	}
+
+	grpcMockCmdTemplate = &cmdTemplate{
+		Use:   "mock [dirOrFile]",
+		Short: "Serve every service with a mock gRPC server that returns fixtures or random responses.",
+		Long: `This command compiles your proto files once, and then serves every method of every service on the port until interrupted, so that clients can be developed and tested without the real servers. The server only accepts connections from the local machine unless --host is set, such as to 0.0.0.0 to serve on all interfaces.
+
+Responses are taken from the fixtures file given with --fixtures, which maps methods to lists of fixtures. The first fixture whose request matches the request of a call is used. A fixture without a request matches every request, and a fixture with a request matches if every field set in its request has the same value in the request of the call. Fixtures can set a response, a list of responses for server streaming methods, or an error with a gRPC status code.
+
+$ cat fixtures.yaml
+uber.foo.v1.ExcitedAPI/Exclamation:
+  - request:
+      value: hello
+    response:
+      value: hello!
+  - request:
+      value: goodbye
+    error:
+      code: NOT_FOUND
+      message: goodbye not found
+
+If no fixture matches, a random response with every field set is returned.
+
+$ prototool grpc mock example --port 8080 --fixtures fixtures.yaml
+serving on 127.0.0.1:8080
+
+$ prototool grpc example \
+  --address 127.0.0.1:8080 \
+  --method uber.foo.v1.ExcitedAPI/Exclamation \
+  --data '{"value":"hello"}'
+{"value": "hello!"}`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.GRPCMock(args, flags.host, flags.port, flags.fixtures)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindCachePath(flagSet)
+			flags.bindConfigData(flagSet)
+			flags.bindErrorFormat(flagSet)
+			flags.bindFixtures(flagSet)
+			flags.bindHost(flagSet)
+			flags.bindPort(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+			flags.bindWalkTimeout(flagSet)
+		},
+	}
+
+	grpcShellCmdTemplate = &cmdTemplate{
+		Use:   "shell [dirOrFile]",
+		Short: "Start an interactive shell to call gRPC endpoints. Be sure to set the required flag address.",
+		Long: `This command compiles your proto files once, and then reads commands to call gRPC endpoints on the address until "exit" is entered or Ctrl-D is pressed.
+
+The headers given with --header are sent with every call, and can be changed in the shell with the header and unheader commands. In a terminal, tab completes commands, services, methods and the field names of requests, and the up and down keys go through the history. Enter "help" for all commands.
+
+$ prototool grpc shell example --address 0.0.0.0:8080
+> methods
+uber.foo.v1.ExcitedAPI/Exclamation
+uber.foo.v1.ExcitedAPI/ExclamationBidiStream
+uber.foo.v1.ExcitedAPI/ExclamationClientStream
+uber.foo.v1.ExcitedAPI/ExclamationServerStream
+> template uber.foo.v1.ExcitedAPI/Exclamation
+{
+  "value": ""
+}
+> header authorization:Bearer token
+> call uber.foo.v1.ExcitedAPI/Exclamation {"value":"hello"}
+{"value":"hello!"}
+
+If "call" is entered with only a method, the next line is filled with the method and a request with every field set to its default value, so that it can be edited before calling.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.GRPCShell(args, flags.headers, flags.address, flags.callTimeout, flags.connectTimeout, flags.keepaliveTime, flags.details, flags.tls, flags.insecure, flags.cacert, flags.cert, flags.key, flags.serverName)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindCachePath(flagSet)
+			flags.bindConfigData(flagSet)
+			flags.bindAddress(flagSet)
+			flags.bindCallTimeout(flagSet)
+			flags.bindConnectTimeout(flagSet)
+			flags.bindDetails(flagSet)
+			flags.bindErrorFormat(flagSet)
+			flags.bindHeaders(flagSet)
+			flags.bindKeepaliveTime(flagSet)
 			flags.bindProtocURL(flagSet)
 			flags.bindProtocBinPath(flagSet)
 			flags.bindProtocWKTPath(flagSet)
@@ -486,18 +739,20 @@ $ prototool grpc example \
 		Short: "Lint proto files and compile with protoc to check for failures.",
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(runner exec.Runner, args []string, flags *flags) error {
-			return runner.Lint(args, flags.listAllLinters, flags.listLinters, flags.listAllLintGroups, flags.listLintGroup, flags.diffLintGroups, flags.generateIgnores)
+			return runner.Lint(args, flags.listAllLinters, flags.listLinters, flags.listAllLintGroups, flags.listLintGroup, flags.diffLintGroups, flags.generateIgnores, flags.fix)
 		},
 		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
 			flags.bindCachePath(flagSet)
 			flags.bindConfigData(flagSet)
 			flags.bindErrorFormat(flagSet)
 			flags.bindJSON(flagSet)
+			flags.bindOutputFormat(flagSet)
 			flags.bindListAllLinters(flagSet)
 			flags.bindListLinters(flagSet)
 			flags.bindListAllLintGroups(flagSet)
 			flags.bindListLintGroup(flagSet)
 			flags.bindDiffLintGroups(flagSet)
+			flags.bindLintFix(flagSet)
 			flags.bindProtocURL(flagSet)
 			flags.bindProtocBinPath(flagSet)
 			flags.bindProtocWKTPath(flagSet)
@@ -506,6 +761,50 @@ $ prototool grpc example \
 		},
 	}
 
+	lspCmdTemplate = &cmdTemplate{
+		Use:   "lsp",
+		Short: "Run a Language Server Protocol server over stdin and stdout.",
+		Long: `The server provides diagnostics from compile and lint, formatting, go to definition, hover and find references.
+Diagnostics are updated when files are opened and saved, and are computed from the files on disk.
+The working directory is the root URI given by the client, or the current directory if there is none.`,
+		Args: cobra.NoArgs,
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.LSP()
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindCachePath(flagSet)
+			flags.bindConfigData(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+			flags.bindWalkTimeout(flagSet)
+		},
+	}
+
+	watchCmdTemplate = &cmdTemplate{
+		Use:   "watch [dirOrFile]",
+		Short: "Watch proto files and compile, lint, format and generate when they change.",
+		Long: `The pipeline is run for all files on start, and then for the directories of the changed files every time files change.
+All files are compiled on every change, however directories that have not changed are taken from the compile cache.
+Failures are printed in the same format as the other commands, and watching continues until interrupted.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.Watch(args, flags.pipeline, flags.debounce)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindCachePath(flagSet)
+			flags.bindConfigData(flagSet)
+			flags.bindDebounce(flagSet)
+			flags.bindErrorFormat(flagSet)
+			flags.bindJSON(flagSet)
+			flags.bindPipeline(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+			flags.bindWalkTimeout(flagSet)
+		},
+	}
+
 	versionCmdTemplate = &cmdTemplate{
 		Use:   "version",
 		Short: "Print the version.",
@@ -643,6 +942,12 @@ func getRunner(develMode bool, stdin io.Reader, stdout io.Writer, stderr io.Writ
 			exec.RunnerWithErrorFormat(flags.errorFormat),
 		)
 	}
+	if flags.outputFormat != "" {
+		runnerOptions = append(
+			runnerOptions,
+			exec.RunnerWithOutputFormat(flags.outputFormat),
+		)
+	}
 	if flags.protocURL != "" {
 		runnerOptions = append(
 			runnerOptions,
@@ -0,0 +1,15 @@
+#!/bin/sh
+
+# Fails if no FileDescriptorSet was given on stdin, and prints
+# a failure on line 5 of each file to check. The first argument
+# is the prefix of the failure messages.
+
+if [ "$(wc -c)" -eq 0 ]; then
+  echo "no FileDescriptorSet given" >&2
+  exit 1
+fi
+prefix="${1}"
+shift
+for name in "$@"; do
+  printf '{"filename":"%s","line":5,"column":1,"message":"%s %s."}\n' "${name}" "${prefix}" "${name}"
+done
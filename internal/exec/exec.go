@@ -54,15 +54,22 @@ type Runner interface {
 	Files(args []string) error
 	Compile(args []string, dryRun bool) error
 	Gen(args []string, dryRun bool) error
-	Lint(args []string, listAllLinters bool, listLinters bool, listAllLintGroups bool, listLintGroup string, diffLintGroups string, generateIgnores bool) error
+	Lint(args []string, listAllLinters bool, listLinters bool, listAllLintGroups bool, listLintGroup string, diffLintGroups string, generateIgnores bool, fix bool) error
 	Format(args []string, overwrite, diffMode, lintMode, fix bool) error
 	All(args []string, disableFormat, disableLint, fix bool) error
-	GRPC(args, headers []string, address, method, data, callTimeout, connectTimeout, keepaliveTime string, stdin bool, details bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string) error
+	Watch(args []string, pipeline string, debounce string) error
+	LSP() error
+	GRPC(args, headers []string, address, method, data, callTimeout, connectTimeout, keepaliveTime string, stdin bool, details bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string, reflect bool, stream bool) error
+	GRPCBench(args, headers []string, address, method, data, callTimeout, connectTimeout, keepaliveTime string, stdin bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string, concurrency int, rate int, duration string) error
+	GRPCMock(args []string, host string, port int, fixtures string) error
+	GRPCShell(args, headers []string, address, callTimeout, connectTimeout, keepaliveTime string, details bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string) error
 	InspectPackages(args []string) error
 	InspectPackageDeps(args []string, name string) error
 	InspectPackageImporters(args []string, name string) error
-	BreakCheck(args []string, gitBranch string, descriptorSetPath string) error
+	BreakCheck(args []string, gitBranch string, gitRef string, gitRepo string, descriptorSetPath string, againstRegistry string, versionRange string) error
+	BreakChangelog(args []string, gitBranch string, gitRef string, gitRepo string, descriptorSetPath string) error
 	BreakDescriptorSet(args []string, outputPath string) error
+	BreakSemver(args []string, gitBranch string, gitRef string, gitRepo string, descriptorSetPath string, current string) error
 	DescriptorSet(args []string, includeImports bool, includeSourceInfo bool, outputPath string, tmp bool) error
 }
 
@@ -114,6 +121,19 @@ func RunnerWithErrorFormat(errorFormat string) RunnerOption {
 	}
 }
 
+// RunnerWithOutputFormat returns a RunnerOption that prints failures in the
+// given output format for the commands that support it.
+//
+// The default is text, which prints failures with the error format, or as
+// JSON if RunnerWithJSON is used. The sarif, junit and checkstyle output formats
+// print all failures of the command as a single SARIF 2.1.0 log, JUnit XML
+// or Checkstyle XML report once the command completes.
+func RunnerWithOutputFormat(outputFormat string) RunnerOption {
+	return func(runner *runner) {
+		runner.outputFormat = outputFormat
+	}
+}
+
 // RunnerWithProtocBinPath returns a RunnerOption that uses the given protoc binary path.
 func RunnerWithProtocBinPath(protocBinPath string) RunnerOption {
 	return func(runner *runner) {
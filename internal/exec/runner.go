@@ -28,11 +28,15 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"text/scanner"
 	"text/tabwriter"
 	"time"
@@ -51,17 +55,52 @@ import (
 	"github.com/uber/prototool/internal/git"
 	"github.com/uber/prototool/internal/grpc"
 	"github.com/uber/prototool/internal/lint"
+	"github.com/uber/prototool/internal/lsp"
+	"github.com/uber/prototool/internal/mock"
 	"github.com/uber/prototool/internal/protoc"
 	"github.com/uber/prototool/internal/reflect"
+	"github.com/uber/prototool/internal/semver"
 	"github.com/uber/prototool/internal/settings"
+	"github.com/uber/prototool/internal/shell"
 	"github.com/uber/prototool/internal/text"
 	"github.com/uber/prototool/internal/vars"
+	"github.com/uber/prototool/internal/watch"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
 	"gopkg.in/yaml.v2"
 )
 
-var jsonpbMarshaler = &jsonpb.Marshaler{}
+const (
+	outputFormatText       = "text"
+	outputFormatSARIF      = "sarif"
+	outputFormatJUnit      = "junit"
+	outputFormatCheckstyle = "checkstyle"
+
+	// the IDs of the failures that are not from linters or breaking checkers
+	compileID    = "COMPILE"
+	formatDiffID = "FORMAT_DIFF"
+
+	watchStepCompile  = "compile"
+	watchStepLint     = "lint"
+	watchStepFormat   = "format"
+	watchStepGenerate = "generate"
+
+	// the maximum number of times fixes are applied by lint --fix
+	maxLintFixPasses = 5
+)
+
+var (
+	jsonpbMarshaler = &jsonpb.Marshaler{}
+
+	// the order that watch steps are run in regardless of the order given
+	watchSteps = []string{
+		watchStepCompile,
+		watchStepLint,
+		watchStepFormat,
+		watchStepGenerate,
+	}
+)
 
 type runner struct {
 	protoSetProvider file.ProtoSetProvider
@@ -79,7 +118,11 @@ type runner struct {
 	protocURL     string
 	errorFormat   string
 	json          bool
+	outputFormat  string
 	walkTimeout   time.Duration
+
+	// set if the failures of the command are printed once it completes
+	report *failureReport
 }
 
 func newRunner(workDirPath string, input io.Reader, output io.Writer, options ...RunnerOption) *runner {
@@ -125,6 +168,8 @@ func (r *runner) cloneForWorkDirPath(workDirPath string) *runner {
 		protocURL:        r.protocURL,
 		errorFormat:      r.errorFormat,
 		json:             r.json,
+		outputFormat:     r.outputFormat,
+		report:           r.report,
 	}
 }
 
@@ -242,7 +287,16 @@ func (r *runner) Files(args []string) error {
 	return nil
 }
 
-func (r *runner) Compile(args []string, dryRun bool) error {
+func (r *runner) Compile(args []string, dryRun bool) (retErr error) {
+	if dryRun {
+		if err := r.checkNoReport("dry-run"); err != nil {
+			return err
+		}
+	}
+	if err := r.startReport(); err != nil {
+		return err
+	}
+	defer func() { retErr = r.finishReport(retErr) }()
 	meta, err := r.getMeta(args)
 	if err != nil {
 		return err
@@ -252,7 +306,16 @@ func (r *runner) Compile(args []string, dryRun bool) error {
 	return err
 }
 
-func (r *runner) Gen(args []string, dryRun bool) error {
+func (r *runner) Gen(args []string, dryRun bool) (retErr error) {
+	if dryRun {
+		if err := r.checkNoReport("dry-run"); err != nil {
+			return err
+		}
+	}
+	if err := r.startReport(); err != nil {
+		return err
+	}
+	defer func() { retErr = r.finishReport(retErr) }()
 	meta, err := r.getMeta(args)
 	if err != nil {
 		return err
@@ -266,7 +329,8 @@ func (r *runner) compile(doGen bool, doFileDescriptorSet bool, dryRun bool, meta
 	if dryRun {
 		doFileDescriptorSet = false
 	}
-	compiler, err := r.newCompiler(doGen, doFileDescriptorSet, false, false, false)
+	// the compile cache is not used when generating
+	compiler, err := r.newCompiler(doGen, doFileDescriptorSet, false, false, false, true)
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +341,7 @@ func (r *runner) compile(doGen bool, doFileDescriptorSet bool, dryRun bool, meta
 }
 
 func (r *runner) compileFullControl(includeImports bool, includeSourceInfo bool, meta *meta) (protoc.FileDescriptorSets, error) {
-	compiler, err := r.newCompiler(false, false, true, includeImports, includeSourceInfo)
+	compiler, err := r.newCompiler(false, false, true, includeImports, includeSourceInfo, false)
 	if err != nil {
 		return nil, err
 	}
@@ -311,9 +375,19 @@ func (r *runner) doProtocCommands(compiler protoc.Compiler, meta *meta) error {
 	return nil
 }
 
-func (r *runner) Lint(args []string, listAllLinters bool, listLinters bool, listAllLintGroups bool, listLintGroup string, diffLintGroups string, generateIgnores bool) error {
-	if moreThanOneSet(listAllLinters, listLinters, listAllLintGroups, listLintGroup != "", diffLintGroups != "", generateIgnores) {
-		return newExitErrorf(255, "can only set one of list-all-linters, list-linters, list-all-lint-groups, list-lint-group, diff-lint-groups, update-ignores")
+func (r *runner) Lint(args []string, listAllLinters bool, listLinters bool, listAllLintGroups bool, listLintGroup string, diffLintGroups string, generateIgnores bool, fix bool) (retErr error) {
+	if moreThanOneSet(listAllLinters, listLinters, listAllLintGroups, listLintGroup != "", diffLintGroups != "", generateIgnores, fix) {
+		return newExitErrorf(255, "can only set one of list-all-linters, list-linters, list-all-lint-groups, list-lint-group, diff-lint-groups, update-ignores, fix")
+	}
+	if listAllLinters || listLinters || listAllLintGroups || listLintGroup != "" || diffLintGroups != "" || generateIgnores {
+		if err := r.checkNoReport("list-all-linters, list-linters, list-all-lint-groups, list-lint-group, diff-lint-groups, update-ignores"); err != nil {
+			return err
+		}
+	} else {
+		if err := r.startReport(); err != nil {
+			return err
+		}
+		defer func() { retErr = r.finishReport(retErr) }()
 	}
 	if listAllLintGroups {
 		return r.listAllLintGroups()
@@ -341,11 +415,90 @@ func (r *runner) Lint(args []string, listAllLinters bool, listLinters bool, list
 	if generateIgnores {
 		return r.generateIgnores(meta)
 	}
+	if fix {
+		if err := r.lintFix(meta); err != nil {
+			return err
+		}
+		// the fixes may have broken compilation, for example if a renamed enum value is referenced
+		if _, err := r.compile(false, false, false, meta); err != nil {
+			return err
+		}
+	}
 	return r.lint(meta)
 }
 
+// lintFix applies the fixes of the lint failures to the files and formats
+// the fixed files. Fixing may result in new fixable failures, so this is
+// done until there are no more fixes or maxLintFixPasses is reached.
+func (r *runner) lintFix(meta *meta) error {
+	absSingleFilename, err := file.AbsClean(meta.SingleFilename)
+	if err != nil {
+		return err
+	}
+	lintRunner, err := r.newLintRunner()
+	if err != nil {
+		return err
+	}
+	transformer := r.newTransformer(format.FixNone, "", "")
+	for i := 0; i < maxLintFixPasses; i++ {
+		filePathToTextEdits, err := lintRunner.Fix(meta.ProtoSet)
+		if err != nil {
+			return err
+		}
+		filePaths := make([]string, 0, len(filePathToTextEdits))
+		for filePath := range filePathToTextEdits {
+			// we are not concerned with the other files
+			if meta.SingleFilename != "" && filePath != absSingleFilename {
+				continue
+			}
+			filePaths = append(filePaths, filePath)
+		}
+		sort.Strings(filePaths)
+		changed := false
+		for _, filePath := range filePaths {
+			input, err := ioutil.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+			data, failures, err := transformer.Transform(filePath, lint.ApplyTextEdits(input, filePathToTextEdits[filePath]))
+			if err != nil {
+				return err
+			}
+			if len(failures) > 0 {
+				if err := r.printFailures(filePath, meta, failures...); err != nil {
+					return err
+				}
+				return newExitErrorf(255, "")
+			}
+			if !bytes.Equal(input, data) {
+				if err := ioutil.WriteFile(filePath, data, os.ModePerm); err != nil {
+					return err
+				}
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+	return nil
+}
+
 func (r *runner) lint(meta *meta) error {
-	failures, err := r.newLintRunner().Run(meta.ProtoSet, false)
+	if r.report != nil {
+		linters, err := lint.GetLinters(meta.ProtoSet.Config.Lint)
+		if err != nil {
+			return err
+		}
+		for _, linter := range linters {
+			r.report.idToPurpose[linter.ID()] = linter.Purpose(meta.ProtoSet.Config.Lint)
+		}
+	}
+	lintRunner, err := r.newLintRunner()
+	if err != nil {
+		return err
+	}
+	failures, err := lintRunner.Run(meta.ProtoSet, false)
 	if err != nil {
 		return err
 	}
@@ -361,7 +514,11 @@ func (r *runner) lint(meta *meta) error {
 func (r *runner) generateIgnores(meta *meta) error {
 	meta.ProtoSet.Config.Lint.IgnoreIDToFilePaths = make(map[string][]string)
 
-	failures, err := r.newLintRunner().Run(meta.ProtoSet, true)
+	lintRunner, err := r.newLintRunner()
+	if err != nil {
+		return err
+	}
+	failures, err := lintRunner.Run(meta.ProtoSet, true)
 	if err != nil {
 		return err
 	}
@@ -426,7 +583,11 @@ func (r *runner) listLinters(meta *meta) error {
 }
 
 func (r *runner) listAllLinters(meta *meta) error {
-	return r.printLinters(meta.ProtoSet.Config.Lint, lint.AllLinters)
+	customLinters, err := lint.GetCustomLinters(meta.ProtoSet.Config.Lint)
+	if err != nil {
+		return err
+	}
+	return r.printLinters(meta.ProtoSet.Config.Lint, append(append(make([]lint.Linter, 0, len(lint.AllLinters)+len(customLinters)), lint.AllLinters...), customLinters...))
 }
 
 func (r *runner) listLintGroup(meta *meta, group string) error {
@@ -472,10 +633,21 @@ func (r *runner) diffLintGroups(groups string) error {
 	return nil
 }
 
-func (r *runner) Format(args []string, overwrite, diffMode, lintMode, fixFlag bool) error {
+func (r *runner) Format(args []string, overwrite, diffMode, lintMode, fixFlag bool) (retErr error) {
 	if moreThanOneSet(overwrite, diffMode, lintMode) {
 		return newExitErrorf(255, "can only set one of overwrite, diff, lint")
 	}
+	if lintMode {
+		if err := r.startReport(); err != nil {
+			return err
+		}
+		defer func() { retErr = r.finishReport(retErr) }()
+		if r.report != nil {
+			r.report.idToPurpose[formatDiffID] = "Checks that the files are formatted."
+		}
+	} else if err := r.checkNoReport("overwrite, diff or without lint"); err != nil {
+		return err
+	}
 	meta, err := r.getMeta(args)
 	if err != nil {
 		return err
@@ -541,7 +713,7 @@ func (r *runner) formatFile(overwrite bool, diffMode bool, lintMode bool, fix in
 		if lintMode {
 			return false, r.printFailures("", meta, text.NewFailuref(scanner.Position{
 				Filename: protoFile.DisplayPath,
-			}, "FORMAT_DIFF", "Format returned a diff."))
+			}, formatDiffID, "Format returned a diff."))
 		}
 		if diffMode {
 			d, err := diff.Do(input, data, protoFile.DisplayPath)
@@ -569,7 +741,11 @@ func (r *runner) formatFile(overwrite bool, diffMode bool, lintMode bool, fix in
 	return true, nil
 }
 
-func (r *runner) All(args []string, disableFormat, disableLint, fixFlag bool) error {
+func (r *runner) All(args []string, disableFormat, disableLint, fixFlag bool) (retErr error) {
+	if err := r.startReport(); err != nil {
+		return err
+	}
+	defer func() { retErr = r.finishReport(retErr) }()
 	meta, err := r.getMeta(args)
 	if err != nil {
 		return err
@@ -592,7 +768,185 @@ func (r *runner) All(args []string, disableFormat, disableLint, fixFlag bool) er
 	return nil
 }
 
-func (r *runner) GRPC(args, headers []string, address, method, data, callTimeout, connectTimeout, keepaliveTime string, stdin bool, details bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string) error {
+func (r *runner) Watch(args []string, pipeline string, debounce string) error {
+	steps, err := parseWatchSteps(pipeline)
+	if err != nil {
+		return err
+	}
+	parsedDebounce, err := time.ParseDuration(debounce)
+	if err != nil {
+		return err
+	}
+	meta, err := r.getMeta(args)
+	if err != nil {
+		return err
+	}
+	watcher, err := watch.NewWatcher(
+		watch.WatcherWithLogger(r.logger),
+		watch.WatcherWithDebounce(parsedDebounce),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = watcher.Close() }()
+	// we stop watching on an interrupt instead of exiting immediately
+	// so that a step that is running, such as protoc, is stopped as well
+	stopC := make(chan struct{})
+	doneC := make(chan struct{})
+	defer close(doneC)
+	signalC := make(chan os.Signal, 1)
+	signal.Notify(signalC, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalC)
+	go func() {
+		select {
+		case <-signalC:
+			close(stopC)
+			_ = watcher.Close()
+		case <-doneC:
+		}
+	}()
+	// all files of the ProtoSet are found starting from the config directory
+	// so this is where files can be added
+	configDirPath := meta.ProtoSet.Config.DirPath
+	if configDirPath == "" {
+		configDirPath = meta.ProtoSet.WorkDirPath
+	}
+	if err := watcher.Add(configDirPath); err != nil {
+		return err
+	}
+	r.printAffectedFiles(meta)
+	if err := r.runWatchSteps(steps, meta, meta); err != nil {
+		return err
+	}
+	for {
+		filePaths, err := watcher.Next()
+		if err != nil {
+			select {
+			case <-stopC:
+				return nil
+			default:
+				return err
+			}
+		}
+		dirPaths, all := getWatchDirPaths(filePaths)
+		if len(dirPaths) == 0 && !all {
+			continue
+		}
+		// files could have been added or removed, or the configuration
+		// could have changed, so we get everything again
+		meta, err := r.getMeta(args)
+		if err != nil {
+			// we want to keep watching until the error is fixed
+			if err := r.println(err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		affectedMeta := meta
+		if !all {
+			affectedMeta = getMetaForDirPaths(meta, dirPaths)
+		}
+		r.printAffectedFiles(affectedMeta)
+		if err := r.runWatchSteps(steps, meta, affectedMeta); err != nil {
+			return err
+		}
+	}
+}
+
+// runWatchSteps runs the steps for the affected directories.
+//
+// Compilation is always done for all files of the ProtoSet so that failures in
+// files that import the changed files are found. Directories that have not changed
+// are taken from the compile cache, so in practice only the affected directories
+// and the directories that import them are compiled. If compilation fails, no other
+// steps are run.
+//
+// Failures are printed, and only system errors writing to the output are returned.
+func (r *runner) runWatchSteps(steps []string, meta *meta, affectedMeta *meta) error {
+	for _, step := range steps {
+		var err error
+		switch step {
+		case watchStepCompile:
+			_, err = r.compile(false, false, false, meta)
+		case watchStepLint:
+			err = r.lint(affectedMeta)
+		case watchStepFormat:
+			err = r.format(false, false, true, format.FixNone, "", "", affectedMeta)
+		case watchStepGenerate:
+			_, err = r.compile(true, false, false, affectedMeta)
+		}
+		if err == nil {
+			continue
+		}
+		// the failures have already been printed
+		if exitError, ok := err.(*ExitError); !ok || exitError.Message != "" {
+			if err := r.println(err.Error()); err != nil {
+				return err
+			}
+		}
+		if step == watchStepCompile {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *runner) LSP() error {
+	// the ProtoSet of each file is found when the file is opened
+	compiler, err := r.newCompiler(false, false, true, true, true, true)
+	if err != nil {
+		return err
+	}
+	lintRunner, err := r.newLintRunner()
+	if err != nil {
+		return err
+	}
+	return lsp.NewServer(
+		r.workDirPath,
+		r.protoSetProvider,
+		compiler,
+		lintRunner,
+		r.newTransformer(format.FixNone, "", ""),
+		lsp.ServerWithLogger(r.logger),
+	).Serve(r.input, r.output)
+}
+
+func (r *runner) GRPC(args, headers []string, address, method, data, callTimeout, connectTimeout, keepaliveTime string, stdin bool, details bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string, reflect bool, stream bool) error {
+	if address == "" {
+		return newExitErrorf(255, "must set address")
+	}
+	if method == "" {
+		return newExitErrorf(255, "must set method")
+	}
+	if data == "" && !stdin {
+		return newExitErrorf(255, "must set one of data or stdin")
+	}
+	if data != "" && stdin {
+		return newExitErrorf(255, "must set only one of data or stdin")
+	}
+	if stream && !stdin {
+		return newExitErrorf(255, "must set stdin if stream is set")
+	}
+	parsedHeaders, handlerProvider, err := r.getGRPCHandlerProvider(headers, callTimeout, connectTimeout, keepaliveTime, details, stream, tls, insecure, cacert, cert, key, serverName)
+	if err != nil {
+		return err
+	}
+	reader := r.getInputReader(data, stdin)
+	handler := handlerProvider(parsedHeaders)
+	getFileDescriptorSets := func() ([]*descriptor.FileDescriptorSet, error) {
+		return r.getGRPCFileDescriptorSets(args)
+	}
+	if reflect {
+		return handler.InvokeWithReflection(getFileDescriptorSets, address, method, reader, r.output)
+	}
+	fileDescriptorSets, err := getFileDescriptorSets()
+	if err != nil {
+		return err
+	}
+	return handler.Invoke(fileDescriptorSets, address, method, reader, r.output)
+}
+
+func (r *runner) GRPCBench(args, headers []string, address, method, data, callTimeout, connectTimeout, keepaliveTime string, stdin bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string, concurrency int, rate int, duration string) error {
 	if address == "" {
 		return newExitErrorf(255, "must set address")
 	}
@@ -605,22 +959,215 @@ func (r *runner) GRPC(args, headers []string, address, method, data, callTimeout
 	if data != "" && stdin {
 		return newExitErrorf(255, "must set only one of data or stdin")
 	}
+	if concurrency < 1 {
+		return newExitErrorf(255, "concurrency must be at least 1")
+	}
+	if rate < 0 {
+		return newExitErrorf(255, "rate must not be negative")
+	}
+	parsedDuration, err := time.ParseDuration(duration)
+	if err != nil {
+		return err
+	}
+	if parsedDuration <= 0 {
+		return newExitErrorf(255, "duration must be positive")
+	}
+	parsedHeaders, handlerProvider, err := r.getGRPCHandlerProvider(headers, callTimeout, connectTimeout, keepaliveTime, false, false, tls, insecure, cacert, cert, key, serverName)
+	if err != nil {
+		return err
+	}
+	fileDescriptorSets, err := r.getGRPCFileDescriptorSets(args)
+	if err != nil {
+		return err
+	}
+	benchResult, err := handlerProvider(parsedHeaders).Bench(
+		fileDescriptorSets,
+		address,
+		method,
+		r.getInputReader(data, stdin),
+		grpc.BenchConfig{
+			Concurrency: concurrency,
+			Rate:        rate,
+			Duration:    parsedDuration,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return r.printBenchResult(benchResult)
+}
+
+func (r *runner) GRPCShell(args, headers []string, address, callTimeout, connectTimeout, keepaliveTime string, details bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string) error {
+	if address == "" {
+		return newExitErrorf(255, "must set address")
+	}
+	parsedHeaders, handlerProvider, err := r.getGRPCHandlerProvider(headers, callTimeout, connectTimeout, keepaliveTime, details, false, tls, insecure, cacert, cert, key, serverName)
+	if err != nil {
+		return err
+	}
+	// compile once for all calls in the shell
+	fileDescriptorSets, err := r.getGRPCFileDescriptorSets(args)
+	if err != nil {
+		return err
+	}
+	shellOptions := []shell.ShellOption{
+		shell.ShellWithLogger(r.logger),
+	}
+	for key, value := range parsedHeaders {
+		shellOptions = append(shellOptions, shell.ShellWithHeader(key, value))
+	}
+	grpcShell, err := shell.NewShell(fileDescriptorSets, address, handlerProvider, shellOptions...)
+	if err != nil {
+		return err
+	}
+	return grpcShell.Run(r.input, r.output)
+}
+
+func (r *runner) GRPCMock(args []string, host string, port int, fixtures string) error {
+	if port < 0 || port > 65535 {
+		return newExitErrorf(255, "invalid port: %d", port)
+	}
+	fileDescriptorSets, err := r.getGRPCFileDescriptorSets(args)
+	if err != nil {
+		return err
+	}
+	serverOptions := []mock.ServerOption{
+		mock.ServerWithLogger(r.logger),
+	}
+	if fixtures != "" {
+		serverOptions = append(serverOptions, mock.ServerWithFixturesFilePath(fixtures))
+	}
+	server, err := mock.NewServer(fileDescriptorSets, serverOptions...)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	// we stop serving on an interrupt so that Serve returns
+	doneC := make(chan struct{})
+	defer close(doneC)
+	signalC := make(chan os.Signal, 1)
+	signal.Notify(signalC, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalC)
+	go func() {
+		select {
+		case <-signalC:
+			server.Stop()
+		case <-doneC:
+		}
+	}()
+	if err := r.println("serving on " + listener.Addr().String()); err != nil {
+		return err
+	}
+	return server.Serve(listener)
+}
+
+func (r *runner) printBenchResult(benchResult *grpc.BenchResult) error {
+	statusCodes := make([]codes.Code, 0, len(benchResult.StatusCodeToCount))
+	for code := range benchResult.StatusCodeToCount {
+		statusCodes = append(statusCodes, code)
+	}
+	sort.Slice(statusCodes, func(i int, j int) bool { return statusCodes[i] < statusCodes[j] })
+	latencies := []struct {
+		Name    string
+		Latency time.Duration
+	}{
+		{"min", benchResult.Latency.Min},
+		{"mean", benchResult.Latency.Mean},
+		{"p50", benchResult.Latency.P50},
+		{"p90", benchResult.Latency.P90},
+		{"p95", benchResult.Latency.P95},
+		{"p99", benchResult.Latency.P99},
+		{"max", benchResult.Latency.Max},
+	}
+
+	if r.json {
+		// latencies are in milliseconds
+		out := struct {
+			Method          string             `json:"method,omitempty"`
+			Concurrency     int                `json:"concurrency,omitempty"`
+			Rate            int                `json:"rate,omitempty"`
+			DurationSeconds float64            `json:"duration_seconds"`
+			Calls           int                `json:"calls"`
+			Throughput      float64            `json:"throughput"`
+			Latency         map[string]float64 `json:"latency_ms,omitempty"`
+			StatusCodes     map[string]int     `json:"status_codes,omitempty"`
+		}{
+			Method:          benchResult.Method,
+			Concurrency:     benchResult.Concurrency,
+			Rate:            benchResult.Rate,
+			DurationSeconds: benchResult.Duration.Seconds(),
+			Calls:           benchResult.Calls,
+			Throughput:      benchResult.Throughput(),
+			StatusCodes:     make(map[string]int, len(statusCodes)),
+		}
+		if benchResult.Calls > 0 {
+			out.Latency = make(map[string]float64, len(latencies))
+			for _, latency := range latencies {
+				out.Latency[latency.Name] = float64(latency.Latency) / float64(time.Millisecond)
+			}
+		}
+		for _, code := range statusCodes {
+			out.StatusCodes[code.String()] = benchResult.StatusCodeToCount[code]
+		}
+		enc := json.NewEncoder(r.output)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	rate := "unlimited"
+	if benchResult.Rate > 0 {
+		rate = fmt.Sprintf("%d/s", benchResult.Rate)
+	}
+	tabWriter := newTabWriter(r.output)
+	lines := []string{
+		fmt.Sprintf("Method:\t%s", benchResult.Method),
+		fmt.Sprintf("Concurrency:\t%d", benchResult.Concurrency),
+		fmt.Sprintf("Rate:\t%s", rate),
+		fmt.Sprintf("Duration:\t%v", benchResult.Duration.Round(time.Millisecond)),
+		fmt.Sprintf("Calls:\t%d", benchResult.Calls),
+		fmt.Sprintf("Throughput:\t%.2f/s", benchResult.Throughput()),
+	}
+	if benchResult.Calls > 0 {
+		lines = append(lines, "Latency:\t")
+		for _, latency := range latencies {
+			lines = append(lines, fmt.Sprintf("  %s:\t%v", latency.Name, latency.Latency.Round(time.Microsecond)))
+		}
+		lines = append(lines, "Status codes:\t")
+		for _, code := range statusCodes {
+			lines = append(lines, fmt.Sprintf("  %s:\t%d", code.String(), benchResult.StatusCodeToCount[code]))
+		}
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(tabWriter, line); err != nil {
+			return err
+		}
+	}
+	return tabWriter.Flush()
+}
+
+// getGRPCHandlerProvider validates and parses the flags shared by the grpc commands.
+//
+// The returned headers are the parsed headers, which are not included in the
+// grpc.Handlers returned by the shell.HandlerProvider.
+func (r *runner) getGRPCHandlerProvider(headers []string, callTimeout, connectTimeout, keepaliveTime string, details bool, stream bool, tls bool, insecure bool, cacert string, cert string, key string, serverName string) (map[string]string, shell.HandlerProvider, error) {
 	if tls {
 		if insecure && (cacert != "" || cert != "" || key != "" || serverName != "") {
-			return newExitErrorf(255, "if insecure then cacert, cert, key, and server-name must not be specified")
+			return nil, nil, newExitErrorf(255, "if insecure then cacert, cert, key, and server-name must not be specified")
 		} else if (cert != "") != (key != "") {
-			return newExitErrorf(255, "if cert is specified, key must be specified")
+			return nil, nil, newExitErrorf(255, "if cert is specified, key must be specified")
 		}
 	} else if insecure || cacert != "" || cert != "" || key != "" || serverName != "" {
-		return newExitErrorf(255, "tls must be specified if insecure, cacert, cert, key or server-name are specified")
+		return nil, nil, newExitErrorf(255, "tls must be specified if insecure, cacert, cert, key or server-name are specified")
 	}
-	reader := r.getInputReader(data, stdin)
 
 	parsedHeaders := make(map[string]string)
 	for _, header := range headers {
 		split := strings.SplitN(header, ":", 2)
 		if len(split) != 2 {
-			return fmt.Errorf("headers must be key:value but got %s", header)
+			return nil, nil, fmt.Errorf("headers must be key:value but got %s", header)
 		}
 		parsedHeaders[split[0]] = split[1]
 	}
@@ -631,47 +1178,53 @@ func (r *runner) GRPC(args, headers []string, address, method, data, callTimeout
 	if callTimeout != "" {
 		parsedCallTimeout, err = time.ParseDuration(callTimeout)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 	if connectTimeout != "" {
 		parsedConnectTimeout, err = time.ParseDuration(connectTimeout)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 	if keepaliveTime != "" {
 		parsedKeepaliveTime, err = time.ParseDuration(keepaliveTime)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
+	return parsedHeaders, func(headers map[string]string) grpc.Handler {
+		return r.newGRPCHandler(
+			headers,
+			parsedCallTimeout,
+			parsedConnectTimeout,
+			parsedKeepaliveTime,
+			details,
+			stream,
+			tls,
+			insecure,
+			cacert,
+			cert,
+			key,
+			serverName,
+		)
+	}, nil
+}
 
+func (r *runner) getGRPCFileDescriptorSets(args []string) ([]*descriptor.FileDescriptorSet, error) {
 	meta, err := r.getMeta(args)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	r.printAffectedFiles(meta)
 	fileDescriptorSets, err := r.compile(false, true, false, meta)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(fileDescriptorSets) == 0 {
-		return fmt.Errorf("no FileDescriptorSets returned")
-	}
-	return r.newGRPCHandler(
-		parsedHeaders,
-		parsedCallTimeout,
-		parsedConnectTimeout,
-		parsedKeepaliveTime,
-		details,
-		tls,
-		insecure,
-		cacert,
-		cert,
-		key,
-		serverName,
-	).Invoke(fileDescriptorSets.Unwrap(), address, method, reader, r.output)
+		return nil, fmt.Errorf("no FileDescriptorSets returned")
+	}
+	return fileDescriptorSets.Unwrap(), nil
 }
 
 func (r *runner) BreakDescriptorSet(args []string, outputPath string) error {
@@ -763,66 +1316,360 @@ func (r *runner) InspectPackageImporters(args []string, name string) error {
 	return r.printPackageNames(pkg.ImporterNameToImporter())
 }
 
-func (r *runner) BreakCheck(args []string, gitBranch string, descriptorSetPath string) error {
-	if gitBranch != "" && descriptorSetPath != "" {
-		return newExitErrorf(255, "can only set one of git-branch, descriptor-set-path")
+func (r *runner) BreakCheck(args []string, gitBranch string, gitRef string, gitRepo string, descriptorSetPath string, againstRegistry string, versionRange string) (retErr error) {
+	if gitBranch != "" && gitRef != "" {
+		return newExitErrorf(255, "can only set one of git-branch, git-ref")
 	}
+	if (gitBranch != "" || gitRef != "" || gitRepo != "") && (descriptorSetPath != "" || againstRegistry != "") {
+		return newExitErrorf(255, "can only set one of git-branch, git-ref, git-repo, descriptor-set-path, against-registry")
+	}
+	if descriptorSetPath != "" && againstRegistry != "" {
+		return newExitErrorf(255, "can only set one of descriptor-set-path, against-registry")
+	}
+	if versionRange != "" && againstRegistry == "" {
+		return newExitErrorf(255, "version can only be set with against-registry")
+	}
+	if err := r.startReport(); err != nil {
+		return err
+	}
+	defer func() { retErr = r.finishReport(retErr) }()
 
 	toPackageSet, config, err := r.getPackageSetAndConfig(args)
 	if err != nil {
 		return err
 	}
 
-	var fromPackageSet *extract.PackageSet
-	if descriptorSetPath != "" {
-		fromPackageSet, err = r.getPackageSetForDescriptorSetPath(descriptorSetPath)
+	if againstRegistry != "" {
+		return r.breakCheckAgainstRegistry(config, toPackageSet, againstRegistry, versionRange)
+	}
+
+	fromPackageSet, err := r.getBreakFromPackageSet(args, gitBranch, gitRef, gitRepo, descriptorSetPath)
+	if err != nil {
+		return err
+	}
+
+	if err := r.addBreakingCheckersToReport(config); err != nil {
+		return err
+	}
+	failures, err := r.newBreakingRunner().Run(config.Break, fromPackageSet, toPackageSet)
+	if err != nil {
+		return err
+	}
+	return r.printBreakingFailures(config, failures)
+}
+
+func (r *runner) BreakChangelog(args []string, gitBranch string, gitRef string, gitRepo string, descriptorSetPath string) error {
+	if err := checkBreakFromFlags(gitBranch, gitRef, gitRepo, descriptorSetPath); err != nil {
+		return err
+	}
+	toPackageSet, _, err := r.getPackageSetAndConfig(args)
+	if err != nil {
+		return err
+	}
+	fromPackageSet, err := r.getBreakFromPackageSet(args, gitBranch, gitRef, gitRepo, descriptorSetPath)
+	if err != nil {
+		return err
+	}
+	changes, err := breaking.GetChanges(fromPackageSet, toPackageSet)
+	if err != nil {
+		return err
+	}
+	if r.json {
+		return r.printChangesJSON(changes)
+	}
+	return r.printChangesMarkdown(changes)
+}
+
+func (r *runner) BreakSemver(args []string, gitBranch string, gitRef string, gitRepo string, descriptorSetPath string, current string) error {
+	if current == "" {
+		return newExitErrorf(255, "must set current")
+	}
+	currentVersion, err := semver.ParseVersion(current)
+	if err != nil {
+		return newExitErrorf(255, "%v", err)
+	}
+	if err := checkBreakFromFlags(gitBranch, gitRef, gitRepo, descriptorSetPath); err != nil {
+		return err
+	}
+	toPackageSet, config, err := r.getPackageSetAndConfig(args)
+	if err != nil {
+		return err
+	}
+	fromPackageSet, err := r.getBreakFromPackageSet(args, gitBranch, gitRef, gitRepo, descriptorSetPath)
+	if err != nil {
+		return err
+	}
+	level, err := r.getSemverLevel(config, fromPackageSet, toPackageSet)
+	if err != nil {
+		return err
+	}
+	// major version zero is for initial development, so breaking changes
+	// only increment the minor version, similar to the caret range "^0.x"
+	if level == semver.LevelMajor && currentVersion.Major == 0 {
+		level = semver.LevelMinor
+	}
+	next := currentVersion.Increment(level).String()
+	if strings.HasPrefix(current, "v") {
+		next = "v" + next
+	}
+	if r.json {
+		data, err := json.Marshal(&breakSemverOutput{
+			Level:   level,
+			Current: current,
+			Next:    next,
+		})
 		if err != nil {
 			return err
 		}
+		return r.println(string(data))
+	}
+	return r.println(next)
+}
+
+type breakSemverOutput struct {
+	Level   semver.Level `json:"level"`
+	Current string       `json:"current"`
+	Next    string       `json:"next"`
+}
+
+// getSemverLevel returns LevelMajor if there are breaking changes from
+// fromPackageSet to toPackageSet, LevelMinor if there are any other additions,
+// deprecations or removals, and LevelPatch otherwise, for example if only
+// comments or options changed.
+func (r *runner) getSemverLevel(config settings.Config, fromPackageSet *extract.PackageSet, toPackageSet *extract.PackageSet) (semver.Level, error) {
+	failures, err := r.newBreakingRunner().Run(config.Break, fromPackageSet, toPackageSet)
+	if err != nil {
+		return semver.LevelPatch, err
+	}
+	if len(failures) > 0 {
+		return semver.LevelMajor, nil
+	}
+	changes, err := breaking.GetChanges(fromPackageSet, toPackageSet)
+	if err != nil {
+		return semver.LevelPatch, err
+	}
+	if len(changes) > 0 {
+		return semver.LevelMinor, nil
+	}
+	return semver.LevelPatch, nil
+}
+
+// checkBreakFromFlags checks that at most one way to get the PackageSet
+// to compare against is set, see getBreakFromPackageSet.
+func checkBreakFromFlags(gitBranch string, gitRef string, gitRepo string, descriptorSetPath string) error {
+	if gitBranch != "" && gitRef != "" {
+		return newExitErrorf(255, "can only set one of git-branch, git-ref")
+	}
+	if (gitBranch != "" || gitRef != "" || gitRepo != "") && descriptorSetPath != "" {
+		return newExitErrorf(255, "can only set one of git-branch, git-ref, git-repo, descriptor-set-path")
+	}
+	return nil
+}
+
+// getBreakFromPackageSet returns the PackageSet to compare the current
+// definitions against, either from the FileDescriptorSet at descriptorSetPath,
+// or from the same relative directory within a temporary git clone.
+func (r *runner) getBreakFromPackageSet(args []string, gitBranch string, gitRef string, gitRepo string, descriptorSetPath string) (*extract.PackageSet, error) {
+	if descriptorSetPath != "" {
+		return r.getPackageSetForDescriptorSetPath(descriptorSetPath)
+	}
+
+	relDirPath := "."
+	// we check length 0 or 1 in cmd, similar to other commands
+	if len(args) == 1 {
+		relDirPath = args[0]
+	}
+	if filepath.IsAbs(relDirPath) {
+		return nil, fmt.Errorf("input argument must be relative directory path: %s", relDirPath)
+	}
+
+	absDirPath, err := file.AbsClean(relDirPath)
+	if err != nil {
+		return nil, err
+	}
+	absWorkDirPath, err := file.AbsClean(r.workDirPath)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(absDirPath, absWorkDirPath) {
+		return nil, fmt.Errorf("input directory must be within working directory: %s", relDirPath)
+	}
+
+	repo := gitRepo
+	if repo == "" {
+		repo = r.workDirPath
+	}
+	// this will purposefully fail if we are not at a git repository
+	var cloneDirPath string
+	if gitRef != "" {
+		cloneDirPath, err = git.TemporaryCloneAtRef(r.logger, repo, gitRef)
 	} else {
-		relDirPath := "."
-		// we check length 0 or 1 in cmd, similar to other commands
-		if len(args) == 1 {
-			relDirPath = args[0]
+		cloneDirPath, err = git.TemporaryClone(r.logger, repo, gitBranch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		r.logger.Sugar().Debugf("removing %s", cloneDirPath)
+		_ = os.RemoveAll(cloneDirPath)
+	}()
+
+	fromPackageSet, _, err := r.cloneForWorkDirPath(cloneDirPath).getPackageSetAndConfigForRelDirPath(relDirPath)
+	return fromPackageSet, err
+}
+
+// breakCheckAgainstRegistry checks toPackageSet against every release in the
+// registry at registryDirPath that matches versionRange.
+//
+// Failures that break multiple releases are only printed once, with the
+// releases they break appended to the message.
+func (r *runner) breakCheckAgainstRegistry(config settings.Config, toPackageSet *extract.PackageSet, registryDirPath string, versionRange string) error {
+	releases, err := r.getRegistryReleases(registryDirPath, versionRange)
+	if err != nil {
+		return err
+	}
+	if err := r.addBreakingCheckersToReport(config); err != nil {
+		return err
+	}
+	breakingRunner := r.newBreakingRunner()
+	var failures []*text.Failure
+	failureToReleaseNames := make(map[text.Failure][]string)
+	for _, release := range releases {
+		releaseFailures, err := breakingRunner.Run(config.Break, release.packageSet, toPackageSet)
+		if err != nil {
+			return err
 		}
-		if filepath.IsAbs(relDirPath) {
-			return fmt.Errorf("input argument must be relative directory path: %s", relDirPath)
+		for _, failure := range releaseFailures {
+			releaseNames, ok := failureToReleaseNames[*failure]
+			if !ok {
+				failures = append(failures, failure)
+			}
+			failureToReleaseNames[*failure] = append(releaseNames, release.name)
 		}
+	}
+	for _, failure := range failures {
+		failure.Message = fmt.Sprintf("%s Breaks %s.", failure.Message, strings.Join(failureToReleaseNames[*failure], ", "))
+	}
+	return r.printBreakingFailures(config, failures)
+}
 
-		absDirPath, err := file.AbsClean(relDirPath)
+// registryRelease is a release within a registry directory.
+type registryRelease struct {
+	// the name of the directory of the release
+	name       string
+	version    semver.Version
+	packageSet *extract.PackageSet
+}
+
+// getRegistryReleases returns the releases within the registry at registryDirPath
+// that match versionRange, sorted by version.
+//
+// Each directory within registryDirPath whose name is a semantic version is a release,
+// and all files within this directory are serialized FileDescriptorSets that are merged
+// together. Other directories and files are ignored.
+func (r *runner) getRegistryReleases(registryDirPath string, versionRange string) ([]*registryRelease, error) {
+	semverRange, err := semver.ParseRange(versionRange)
+	if err != nil {
+		return nil, newExitErrorf(255, "%v", err)
+	}
+	fileInfos, err := ioutil.ReadDir(registryDirPath)
+	if err != nil {
+		return nil, err
+	}
+	var releases []*registryRelease
+	for _, fileInfo := range fileInfos {
+		if !fileInfo.IsDir() {
+			continue
+		}
+		version, err := semver.ParseVersion(fileInfo.Name())
 		if err != nil {
-			return err
+			r.logger.Sugar().Debugf("ignoring registry directory %s: %v", fileInfo.Name(), err)
+			continue
 		}
-		absWorkDirPath, err := file.AbsClean(r.workDirPath)
+		if !semverRange.Contains(version) {
+			continue
+		}
+		fileDescriptorSet, err := getRegistryReleaseFileDescriptorSet(filepath.Join(registryDirPath, fileInfo.Name()))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if !strings.HasPrefix(absDirPath, absWorkDirPath) {
-			return fmt.Errorf("input directory must be within working directory: %s", relDirPath)
+		packageSet, err := r.getPackageSetForFileDescriptorSets(fileDescriptorSet)
+		if err != nil {
+			return nil, err
 		}
+		releases = append(releases, &registryRelease{
+			name:       fileInfo.Name(),
+			version:    version,
+			packageSet: packageSet,
+		})
+	}
+	if len(releases) == 0 {
+		if versionRange == "" {
+			return nil, newExitErrorf(255, "no releases found in %s", registryDirPath)
+		}
+		return nil, newExitErrorf(255, "no releases found in %s for version %s", registryDirPath, versionRange)
+	}
+	sort.Slice(releases, func(i int, j int) bool { return releases[i].version.Compare(releases[j].version) < 0 })
+	return releases, nil
+}
 
-		// this will purposefully fail if we are not at a git repository
-		cloneDirPath, err := git.TemporaryClone(r.logger, r.workDirPath, gitBranch)
+// getRegistryReleaseFileDescriptorSet merges all the FileDescriptorSets within releaseDirPath.
+func getRegistryReleaseFileDescriptorSet(releaseDirPath string) (*descriptor.FileDescriptorSet, error) {
+	var fileDescriptorSets []*descriptor.FileDescriptorSet
+	if err := filepath.Walk(releaseDirPath, func(filePath string, fileInfo os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		defer func() {
-			r.logger.Sugar().Debugf("removing %s", cloneDirPath)
-			_ = os.RemoveAll(cloneDirPath)
-		}()
-
-		fromPackageSet, _, err = r.cloneForWorkDirPath(cloneDirPath).getPackageSetAndConfigForRelDirPath(relDirPath)
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+		data, err := ioutil.ReadFile(filePath)
 		if err != nil {
 			return err
 		}
+		fileDescriptorSet := &descriptor.FileDescriptorSet{}
+		if err := proto.Unmarshal(data, fileDescriptorSet); err != nil {
+			return fmt.Errorf("could not read FileDescriptorSet from %s: %v", filePath, err)
+		}
+		fileDescriptorSets = append(fileDescriptorSets, fileDescriptorSet)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(fileDescriptorSets) == 0 {
+		return nil, fmt.Errorf("no FileDescriptorSets found in %s", releaseDirPath)
 	}
+	fileDescriptorSet, err := desc.MergeFileDescriptorSets(fileDescriptorSets)
+	if err != nil {
+		return nil, fmt.Errorf("could not merge FileDescriptorSets in %s: %v", releaseDirPath, err)
+	}
+	return fileDescriptorSet, nil
+}
 
-	failures, err := r.newBreakingRunner().Run(config.Break, fromPackageSet, toPackageSet)
+func (r *runner) addBreakingCheckersToReport(config settings.Config) error {
+	if r.report == nil {
+		return nil
+	}
+	checkers, err := breaking.GetCheckers(config.Break)
 	if err != nil {
 		return err
 	}
+	for _, checker := range checkers {
+		r.report.idToPurpose[checker.ID] = checker.Purpose
+	}
+	return nil
+}
+
+// printBreakingFailures prints the failures, with the names of their files
+// made relative to the working directory.
+func (r *runner) printBreakingFailures(config settings.Config, failures []*text.Failure) error {
 	if len(failures) > 0 {
-		if err := r.printFailuresForErrorFormat("message", "", nil, failures...); err != nil {
+		for _, failure := range failures {
+			if failure.Filename != "" {
+				failure.Filename = r.getDisplayFilePath(r.getFileDescriptorProtoPath(config, failure.Filename))
+			}
+		}
+		if err := r.printFailures("", nil, failures...); err != nil {
 			return err
 		}
 		return newExitErrorf(255, "")
@@ -830,13 +1677,49 @@ func (r *runner) BreakCheck(args []string, gitBranch string, descriptorSetPath s
 	return nil
 }
 
+// getFileDescriptorProtoPath returns the path of the file with the given name
+// in a FileDescriptorSet compiled with the config.
+//
+// Like protoc, this looks for the file in the include paths in order, and then
+// in the directory of the config file, which the compiler adds as an include path
+// if needed. If the file is not found, the path in the directory of the config
+// file is returned.
+func (r *runner) getFileDescriptorProtoPath(config settings.Config, name string) string {
+	configDirPath := config.DirPath
+	if configDirPath == "" {
+		configDirPath = r.workDirPath
+	}
+	for _, includePath := range config.Compile.IncludePaths {
+		filePath := filepath.Join(includePath, filepath.FromSlash(name))
+		if _, err := os.Stat(filePath); err == nil {
+			return filePath
+		}
+	}
+	return filepath.Join(configDirPath, filepath.FromSlash(name))
+}
+
+// getDisplayFilePath returns the path relative to the working directory,
+// or the path if it is outside of the working directory.
+func (r *runner) getDisplayFilePath(filePath string) string {
+	relFilePath, err := filepath.Rel(r.workDirPath, filePath)
+	if err != nil || relFilePath == ".." || strings.HasPrefix(relFilePath, ".."+string(filepath.Separator)) {
+		return filePath
+	}
+	return relFilePath
+}
+
 func (r *runner) getPackageSetAndConfig(args []string) (*extract.PackageSet, settings.Config, error) {
 	meta, err := r.getMeta(args)
 	if err != nil {
 		return nil, settings.Config{}, err
 	}
 	r.printAffectedFiles(meta)
-	fileDescriptorSets, err := r.compile(false, true, false, meta)
+	// source code info is included so that breaking failures have locations
+	compiler, err := r.newCompiler(false, false, true, true, true, true)
+	if err != nil {
+		return nil, settings.Config{}, err
+	}
+	fileDescriptorSets, err := r.doCompile(compiler, meta)
 	if err != nil {
 		return nil, settings.Config{}, err
 	}
@@ -868,7 +1751,7 @@ func (r *runner) getPackageSetForFileDescriptorSets(fileDescriptorSets ...*descr
 	if err != nil {
 		return nil, err
 	}
-	return extract.NewPackageSet(reflectPackageSet)
+	return extract.NewPackageSetWithFileNames(reflectPackageSet, fileDescriptorSets...)
 }
 
 func (r *runner) getPackage(args []string, name string) (*extract.Package, error) {
@@ -889,6 +1772,48 @@ func (r *runner) getPackage(args []string, name string) (*extract.Package, error
 	return pkg, nil
 }
 
+func (r *runner) printChangesJSON(changes []*breaking.Change) error {
+	for _, change := range changes {
+		data, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		if err := r.println(string(data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printChangesMarkdown prints a section for each ChangeType that has changes,
+// with a list item for each change, for example:
+//
+//	## Added
+//
+//	- Message field `foo.v1.Foo.bar`
+func (r *runner) printChangesMarkdown(changes []*breaking.Change) error {
+	buffer := bytes.NewBuffer(nil)
+	for _, changeType := range breaking.AllChangeTypes {
+		var lines []string
+		for _, change := range changes {
+			if change.Type == changeType {
+				element := string(change.Element)
+				lines = append(lines, fmt.Sprintf("- %s%s `%s`", strings.ToUpper(element[:1]), element[1:], change.Name))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if buffer.Len() > 0 {
+			_, _ = buffer.WriteString("\n")
+		}
+		changeTypeString := changeType.String()
+		_, _ = fmt.Fprintf(buffer, "## %s%s\n\n%s\n", strings.ToUpper(changeTypeString[:1]), changeTypeString[1:], strings.Join(lines, "\n"))
+	}
+	_, err := r.output.Write(buffer.Bytes())
+	return err
+}
+
 func (r *runner) printPackageNames(m map[string]*extract.Package) error {
 	for _, packageName := range extractSortPackageNames(m) {
 		if err := r.println(packageName); err != nil {
@@ -952,6 +1877,7 @@ func (r *runner) newCompiler(
 	doFileDescriptorSetFullControl bool,
 	includeImports bool,
 	includeSourceInfo bool,
+	useCompileCache bool,
 ) (protoc.Compiler, error) {
 	if doFileDescriptorSet && doFileDescriptorSetFullControl {
 		return nil, fmt.Errorf("cannot set doFileDescriptorSet and doFileDescriptorSetFullControl")
@@ -1006,13 +1932,25 @@ func (r *runner) newCompiler(
 			protoc.CompilerWithFileDescriptorSetFullControl(includeImports, includeSourceInfo),
 		)
 	}
+	if useCompileCache {
+		compilerOptions = append(
+			compilerOptions,
+			protoc.CompilerWithCompileCache(),
+		)
+	}
 	return protoc.NewCompiler(compilerOptions...), nil
 }
 
-func (r *runner) newLintRunner() lint.Runner {
+func (r *runner) newLintRunner() (lint.Runner, error) {
+	// lint plugins are given the FileDescriptorSets with imports and source info
+	compiler, err := r.newCompiler(false, false, true, true, true, true)
+	if err != nil {
+		return nil, err
+	}
 	return lint.NewRunner(
 		lint.RunnerWithLogger(r.logger),
-	)
+		lint.RunnerWithCompiler(compiler),
+	), nil
 }
 
 func (r *runner) newTransformer(fix int, fileHeader string, javaPackagePrefix string) format.Transformer {
@@ -1052,6 +1990,7 @@ func (r *runner) newGRPCHandler(
 	connectTimeout time.Duration,
 	keepaliveTime time.Duration,
 	details bool,
+	stream bool,
 	tls bool,
 	insecure bool,
 	cacert string,
@@ -1077,12 +2016,21 @@ func (r *runner) newGRPCHandler(
 	if details {
 		handlerOptions = append(handlerOptions, grpc.HandlerWithDetails())
 	}
+	if stream {
+		handlerOptions = append(handlerOptions, grpc.HandlerWithStreaming())
+	}
 	if tls {
 		handlerOptions = append(handlerOptions, grpc.HandlerWithTLS(insecure, cacert, cert, key, serverName))
 	}
 	return grpc.NewHandler(handlerOptions...)
 }
 
+// failureReport collects the failures of a command to print once it completes.
+type failureReport struct {
+	idToPurpose map[string]string
+	failures    []*text.Failure
+}
+
 type meta struct {
 	ProtoSet *file.ProtoSet
 	// this will be empty if not in dir mode
@@ -1145,7 +2093,7 @@ func (r *runner) printFailuresForErrorFormat(errorFormat string, filename string
 		return err
 	}
 	text.SortFailures(failures)
-	bufWriter := bufio.NewWriter(r.output)
+	printFailures := make([]*text.Failure, 0, len(failures))
 	for _, failure := range failures {
 		shouldPrint := false
 		if meta != nil {
@@ -1169,20 +2117,91 @@ func (r *runner) printFailuresForErrorFormat(errorFormat string, filename string
 			shouldPrint = true
 		}
 		if shouldPrint {
-			if r.json {
-				data, err := json.Marshal(failure)
-				if err != nil {
-					return err
-				}
-				if _, err := fmt.Fprintln(bufWriter, string(data)); err != nil {
-					return err
-				}
-			} else if err := failure.Fprintln(bufWriter, failureFields...); err != nil {
-				return err
-			}
+			printFailures = append(printFailures, failure)
 		}
 	}
-	return bufWriter.Flush()
+	if r.report != nil {
+		r.report.failures = append(r.report.failures, printFailures...)
+		return nil
+	}
+	if r.json {
+		return text.NewJSONFailureReporter().ReportFailures(r.output, printFailures...)
+	}
+	return text.NewTextFailureReporter(failureFields...).ReportFailures(r.output, printFailures...)
+}
+
+// startReport starts collecting the failures of the command if the
+// output format prints them once the command completes.
+func (r *runner) startReport() error {
+	switch r.outputFormat {
+	case "", outputFormatText:
+		return nil
+	case outputFormatSARIF, outputFormatJUnit, outputFormatCheckstyle:
+		if r.json {
+			return newExitErrorf(255, "can only set one of json, output-format %s", r.outputFormat)
+		}
+		r.report = &failureReport{
+			idToPurpose: map[string]string{
+				// compile failures have no ID
+				compileID: "Checks that the files compile.",
+			},
+		}
+		return nil
+	default:
+		return newExitErrorf(255, "unknown output-format: %s", r.outputFormat)
+	}
+}
+
+// checkNoReport returns an error if the output format prints a report, for
+// the flags of commands that print other output.
+func (r *runner) checkNoReport(flags string) error {
+	if r.outputFormat != "" && r.outputFormat != outputFormatText {
+		return newExitErrorf(255, "cannot use output-format %s with %s", r.outputFormat, flags)
+	}
+	return nil
+}
+
+// finishReport prints the collected failures, unless err is a system error.
+//
+// This returns err if the failures were printed.
+func (r *runner) finishReport(err error) error {
+	if r.report == nil {
+		return err
+	}
+	if err != nil {
+		if _, ok := err.(*ExitError); !ok {
+			return err
+		}
+	}
+	failures := make([]*text.Failure, 0, len(r.report.failures))
+	for _, failure := range r.report.failures {
+		if failure.LintID == "" {
+			failureCopy := *failure
+			failureCopy.LintID = compileID
+			failure = &failureCopy
+		}
+		failures = append(failures, failure)
+	}
+	text.SortFailures(failures)
+	bufWriter := bufio.NewWriter(r.output)
+	if reportErr := r.newFailureReporter().ReportFailures(bufWriter, failures...); reportErr != nil {
+		return reportErr
+	}
+	if flushErr := bufWriter.Flush(); flushErr != nil {
+		return flushErr
+	}
+	return err
+}
+
+func (r *runner) newFailureReporter() text.FailureReporter {
+	switch r.outputFormat {
+	case outputFormatJUnit:
+		return text.NewJUnitFailureReporter("prototool")
+	case outputFormatCheckstyle:
+		return text.NewCheckstyleFailureReporter()
+	default:
+		return text.NewSARIFFailureReporter("prototool", vars.Version, r.report.idToPurpose)
+	}
 }
 
 func (r *runner) printLinters(config settings.LintConfig, linters []lint.Linter) error {
@@ -1268,6 +2287,73 @@ func getFormatJavaPackagePrefixValue(fixFlag bool, meta *meta) string {
 	return meta.ProtoSet.Config.Lint.JavaPackagePrefix
 }
 
+// parseWatchSteps parses the comma-separated steps and returns them in the order they should be run.
+func parseWatchSteps(pipeline string) ([]string, error) {
+	stepMap := make(map[string]struct{})
+	for _, step := range strings.Split(pipeline, ",") {
+		step = strings.ToLower(strings.TrimSpace(step))
+		if step == "" {
+			continue
+		}
+		if !isWatchStep(step) {
+			return nil, newExitErrorf(255, "unknown watch step %q, must be one of %s", step, strings.Join(watchSteps, ","))
+		}
+		stepMap[step] = struct{}{}
+	}
+	if len(stepMap) == 0 {
+		return nil, newExitErrorf(255, "no watch steps given, must be some of %s", strings.Join(watchSteps, ","))
+	}
+	steps := make([]string, 0, len(stepMap))
+	for _, step := range watchSteps {
+		if _, ok := stepMap[step]; ok {
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
+
+func isWatchStep(step string) bool {
+	for _, watchStep := range watchSteps {
+		if step == watchStep {
+			return true
+		}
+	}
+	return false
+}
+
+// getWatchDirPaths returns the directories of the changed .proto files.
+//
+// If a configuration file changed, all is true as everything could be affected.
+func getWatchDirPaths(filePaths []string) (map[string]struct{}, bool) {
+	dirPaths := make(map[string]struct{})
+	for _, filePath := range filePaths {
+		for _, configFilename := range settings.ConfigFilenames {
+			if filepath.Base(filePath) == configFilename {
+				return nil, true
+			}
+		}
+		if filepath.Ext(filePath) == ".proto" {
+			dirPaths[filepath.Dir(filePath)] = struct{}{}
+		}
+	}
+	return dirPaths, false
+}
+
+// getMetaForDirPaths returns a copy of the meta with only the files in the given directories.
+func getMetaForDirPaths(m *meta, dirPaths map[string]struct{}) *meta {
+	protoSet := *m.ProtoSet
+	protoSet.DirPathToFiles = make(map[string][]*file.ProtoFile)
+	for dirPath, protoFiles := range m.ProtoSet.DirPathToFiles {
+		if _, ok := dirPaths[dirPath]; ok {
+			protoSet.DirPathToFiles[dirPath] = protoFiles
+		}
+	}
+	return &meta{
+		ProtoSet:       &protoSet,
+		SingleFilename: m.SingleFilename,
+	}
+}
+
 func extractSortPackageNames(m map[string]*extract.Package) []string {
 	s := make([]string, 0, len(m))
 	for key := range m {
@@ -24,6 +24,7 @@ package extract
 import (
 	"fmt"
 
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/uber/prototool/internal/protostrs"
 	reflectv1 "github.com/uber/prototool/internal/reflect/gen/uber/proto/reflect/v1"
 )
@@ -33,6 +34,14 @@ type PackageSet struct {
 	protoMessage *reflectv1.PackageSet
 
 	packageNameToPackage map[string]*Package
+	// the fully-qualified names of top-level enums, messages, services and
+	// extensions to the names of the files they are declared in, may be nil
+	topLevelNameToFileName map[string]string
+	// the paths of elements to their locations, may be nil
+	pathToLocation map[string]*Location
+	// the functions this PackageSet was filtered with, never nil
+	ignorePackage  func(string) bool
+	ignoreFileName func(string) bool
 }
 
 // ProtoMessage returns the underlying Protobuf message.
@@ -45,11 +54,44 @@ func (p *PackageSet) PackageNameToPackage() map[string]*Package {
 	return p.packageNameToPackage
 }
 
+// Location returns the location of the element with the given path, or
+// nil if the location is not known.
+//
+// Paths are the fully-qualified names of packages, enums, messages, oneofs,
+// extensions, services and service methods, and MessageFieldPath and
+// EnumValuePath for message fields and enum values.
+//
+// Locations are only known if the PackageSet was created with
+// NewPackageSetWithFileNames from FileDescriptorSets that include
+// source code info.
+func (p *PackageSet) Location(path string) *Location {
+	return p.pathToLocation[path]
+}
+
 // WithoutBeta makes a copy of the PackageSet without any beta packages.
 //
 // Note that field type names may still refer to beta packages.
 func (p *PackageSet) WithoutBeta() (*PackageSet, error) {
-	return newPackageSet(p.protoMessage, true)
+	return p.Without(isBetaPackage, nil)
+}
+
+// Without makes a copy of the PackageSet without the packages for which
+// ignorePackage returns true, and without the top-level enums, messages,
+// services and extensions declared in files for which ignoreFileName returns true.
+// Either function can be nil.
+//
+// Files are only known if the PackageSet was created with
+// NewPackageSetWithFileNames, otherwise ignoreFileName is never called.
+//
+// Note that field type names may still refer to the removed packages.
+func (p *PackageSet) Without(ignorePackage func(string) bool, ignoreFileName func(string) bool) (*PackageSet, error) {
+	return newPackageSet(
+		p.protoMessage,
+		p.topLevelNameToFileName,
+		p.pathToLocation,
+		orIgnoreFuncs(p.ignorePackage, ignorePackage),
+		orIgnoreFuncs(p.ignoreFileName, ignoreFileName),
+	)
 }
 
 // Package is the Golang wrapper for the Protobuf Package object.
@@ -62,6 +104,7 @@ type Package struct {
 	enumNameToEnum             map[string]*Enum
 	messageNameToMessage       map[string]*Message
 	serviceNameToService       map[string]*Service
+	extensionNameToExtension   map[string]*Extension
 }
 
 // ProtoMessage returns the underlying Protobuf message.
@@ -104,6 +147,11 @@ func (p *Package) ServiceNameToService() map[string]*Service {
 	return p.serviceNameToService
 }
 
+// ExtensionNameToExtension returns the top-level extensions of the given Package.
+func (p *Package) ExtensionNameToExtension() map[string]*Extension {
+	return p.extensionNameToExtension
+}
+
 // Enum is the Golang wrapper for the Protobuf Enum object.
 type Enum struct {
 	protoMessage *reflectv1.Enum
@@ -154,12 +202,13 @@ func (m *EnumValue) Enum() *Enum {
 type Message struct {
 	protoMessage *reflectv1.Message
 
-	fullyQualifiedName         string
-	nestedEnumNameToEnum       map[string]*Enum
-	nestedMessageNameToMessage map[string]*Message
-	fieldNameToField           map[string]*MessageField
-	fieldNumberToField         map[int32]*MessageField
-	oneofNameToOneof           map[string]*MessageOneof
+	fullyQualifiedName             string
+	nestedEnumNameToEnum           map[string]*Enum
+	nestedMessageNameToMessage     map[string]*Message
+	nestedExtensionNameToExtension map[string]*Extension
+	fieldNameToField               map[string]*MessageField
+	fieldNumberToField             map[int32]*MessageField
+	oneofNameToOneof               map[string]*MessageOneof
 }
 
 // ProtoMessage returns the underlying Protobuf message.
@@ -182,6 +231,11 @@ func (m *Message) NestedMessageNameToMessage() map[string]*Message {
 	return m.nestedMessageNameToMessage
 }
 
+// NestedExtensionNameToExtension returns the extensions declared within the given Message.
+func (m *Message) NestedExtensionNameToExtension() map[string]*Extension {
+	return m.nestedExtensionNameToExtension
+}
+
 // FieldNameToField returns the fields of the given Message.
 func (m *Message) FieldNameToField() map[string]*MessageField {
 	return m.fieldNameToField
@@ -197,6 +251,23 @@ func (m *Message) OneofNameToOneof() map[string]*MessageOneof {
 	return m.oneofNameToOneof
 }
 
+// Extension is the Golang wrapper for the Protobuf Extension object.
+type Extension struct {
+	protoMessage *reflectv1.Extension
+
+	fullyQualifiedName string
+}
+
+// ProtoMessage returns the underlying Protobuf message.
+func (e *Extension) ProtoMessage() *reflectv1.Extension {
+	return e.protoMessage
+}
+
+// FullyQualifiedName returns the fully-qualified name.
+func (e *Extension) FullyQualifiedName() string {
+	return e.fullyQualifiedName
+}
+
 // MessageField is the Golang wrapper for the Protobuf MessageField object.
 type MessageField struct {
 	protoMessage *reflectv1.MessageField
@@ -293,16 +364,66 @@ func (m *ServiceMethod) Service() *Service {
 
 // NewPackageSet returns a new PackageSet for the given reflect PackageSet.
 func NewPackageSet(protoMessage *reflectv1.PackageSet) (*PackageSet, error) {
-	return newPackageSet(protoMessage, false)
+	return newPackageSet(protoMessage, nil, nil, nil, nil)
 }
 
-func newPackageSet(protoMessage *reflectv1.PackageSet, withoutBeta bool) (*PackageSet, error) {
+// NewPackageSetWithFileNames returns a new PackageSet that also knows the
+// names of the files that top-level enums, messages, services and extensions
+// are declared in, so that these can be removed with Without.
+//
+// If the FileDescriptorSets include source code info, the PackageSet also
+// knows the locations of elements, see Location.
+//
+// The FileDescriptorSets are expected to be the ones the reflect
+// PackageSet was created from.
+func NewPackageSetWithFileNames(protoMessage *reflectv1.PackageSet, fileDescriptorSets ...*descriptor.FileDescriptorSet) (*PackageSet, error) {
+	topLevelNameToFileName := make(map[string]string)
+	pathToLocation := make(map[string]*Location)
+	for _, fileDescriptorSet := range fileDescriptorSets {
+		for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+			addFileLocations(pathToLocation, fileDescriptorProto)
+			packageName := fileDescriptorProto.GetPackage()
+			for _, enum := range fileDescriptorProto.GetEnumType() {
+				topLevelNameToFileName[getFullyQualifiedName(packageName, enum.GetName())] = fileDescriptorProto.GetName()
+			}
+			for _, message := range fileDescriptorProto.GetMessageType() {
+				topLevelNameToFileName[getFullyQualifiedName(packageName, message.GetName())] = fileDescriptorProto.GetName()
+			}
+			for _, service := range fileDescriptorProto.GetService() {
+				topLevelNameToFileName[getFullyQualifiedName(packageName, service.GetName())] = fileDescriptorProto.GetName()
+			}
+			for _, extension := range fileDescriptorProto.GetExtension() {
+				topLevelNameToFileName[getFullyQualifiedName(packageName, extension.GetName())] = fileDescriptorProto.GetName()
+			}
+		}
+	}
+	return newPackageSet(protoMessage, topLevelNameToFileName, pathToLocation, nil, nil)
+}
+
+func newPackageSet(
+	protoMessage *reflectv1.PackageSet,
+	topLevelNameToFileName map[string]string,
+	pathToLocation map[string]*Location,
+	ignorePackage func(string) bool,
+	ignoreFileName func(string) bool,
+) (*PackageSet, error) {
+	ignorePackage = orIgnoreFuncs(ignorePackage)
+	ignoreFileName = orIgnoreFuncs(ignoreFileName)
+	// returns true if the top-level element with the given name should be ignored
+	ignoreTopLevelName := func(encapsulatingFullyQualifiedName string, name string) bool {
+		fileName, ok := topLevelNameToFileName[getFullyQualifiedName(encapsulatingFullyQualifiedName, name)]
+		return ok && ignoreFileName(fileName)
+	}
 	packageSet := &PackageSet{
-		protoMessage:         protoMessage,
-		packageNameToPackage: make(map[string]*Package),
+		protoMessage:           protoMessage,
+		packageNameToPackage:   make(map[string]*Package),
+		topLevelNameToFileName: topLevelNameToFileName,
+		pathToLocation:         pathToLocation,
+		ignorePackage:          ignorePackage,
+		ignoreFileName:         ignoreFileName,
 	}
 	for _, pkg := range packageSet.protoMessage.Packages {
-		if !ignorePackage(withoutBeta, pkg.Name) {
+		if !ignorePackage(pkg.Name) {
 			packageSet.packageNameToPackage[pkg.Name] = &Package{
 				protoMessage:               pkg,
 				packageSet:                 packageSet,
@@ -311,6 +432,7 @@ func newPackageSet(protoMessage *reflectv1.PackageSet, withoutBeta bool) (*Packa
 				enumNameToEnum:             make(map[string]*Enum),
 				messageNameToMessage:       make(map[string]*Message),
 				serviceNameToService:       make(map[string]*Service),
+				extensionNameToExtension:   make(map[string]*Extension),
 			}
 		}
 	}
@@ -318,7 +440,7 @@ func newPackageSet(protoMessage *reflectv1.PackageSet, withoutBeta bool) (*Packa
 		for _, dependencyName := range pkg.protoMessage.DependencyNames {
 			dependency, ok := packageSet.packageNameToPackage[dependencyName]
 			if !ok {
-				if ignorePackage(withoutBeta, dependencyName) {
+				if ignorePackage(dependencyName) {
 					continue
 				}
 				return nil, fmt.Errorf("no package for name %s", dependencyName)
@@ -329,9 +451,15 @@ func newPackageSet(protoMessage *reflectv1.PackageSet, withoutBeta bool) (*Packa
 	}
 	for packageName, pkg := range packageSet.packageNameToPackage {
 		for _, enum := range pkg.protoMessage.Enums {
+			if ignoreTopLevelName(packageName, enum.Name) {
+				continue
+			}
 			pkg.enumNameToEnum[enum.Name] = newEnum(enum, packageName)
 		}
 		for _, message := range pkg.protoMessage.Messages {
+			if ignoreTopLevelName(packageName, message.Name) {
+				continue
+			}
 			extractMessage, err := newMessage(message, packageName)
 			if err != nil {
 				return nil, err
@@ -339,8 +467,17 @@ func newPackageSet(protoMessage *reflectv1.PackageSet, withoutBeta bool) (*Packa
 			pkg.messageNameToMessage[message.Name] = extractMessage
 		}
 		for _, service := range pkg.protoMessage.Services {
+			if ignoreTopLevelName(packageName, service.Name) {
+				continue
+			}
 			pkg.serviceNameToService[service.Name] = newService(service, packageName)
 		}
+		for _, extension := range pkg.protoMessage.Extensions {
+			if ignoreTopLevelName(packageName, extension.Name) {
+				continue
+			}
+			pkg.extensionNameToExtension[extension.Name] = newExtension(extension, packageName)
+		}
 	}
 	return packageSet, nil
 }
@@ -369,13 +506,14 @@ func newEnumValue(protoMessage *reflectv1.EnumValue, enum *Enum) *EnumValue {
 
 func newMessage(protoMessage *reflectv1.Message, encapsulatingFullyQualifiedName string) (*Message, error) {
 	message := &Message{
-		protoMessage:               protoMessage,
-		fullyQualifiedName:         getFullyQualifiedName(encapsulatingFullyQualifiedName, protoMessage.Name),
-		nestedEnumNameToEnum:       make(map[string]*Enum),
-		nestedMessageNameToMessage: make(map[string]*Message),
-		fieldNameToField:           make(map[string]*MessageField),
-		fieldNumberToField:         make(map[int32]*MessageField),
-		oneofNameToOneof:           make(map[string]*MessageOneof),
+		protoMessage:                   protoMessage,
+		fullyQualifiedName:             getFullyQualifiedName(encapsulatingFullyQualifiedName, protoMessage.Name),
+		nestedEnumNameToEnum:           make(map[string]*Enum),
+		nestedMessageNameToMessage:     make(map[string]*Message),
+		nestedExtensionNameToExtension: make(map[string]*Extension),
+		fieldNameToField:               make(map[string]*MessageField),
+		fieldNumberToField:             make(map[int32]*MessageField),
+		oneofNameToOneof:               make(map[string]*MessageOneof),
 	}
 	for _, nestedEnum := range protoMessage.NestedEnums {
 		message.nestedEnumNameToEnum[nestedEnum.Name] = newEnum(nestedEnum, message.fullyQualifiedName)
@@ -387,6 +525,9 @@ func newMessage(protoMessage *reflectv1.Message, encapsulatingFullyQualifiedName
 		}
 		message.nestedMessageNameToMessage[nestedMessage.Name] = extractMessage
 	}
+	for _, nestedExtension := range protoMessage.NestedExtensions {
+		message.nestedExtensionNameToExtension[nestedExtension.Name] = newExtension(nestedExtension, message.fullyQualifiedName)
+	}
 	for _, field := range protoMessage.MessageFields {
 		messageField := newMessageField(field, message)
 		message.fieldNameToField[field.Name] = messageField
@@ -402,6 +543,13 @@ func newMessage(protoMessage *reflectv1.Message, encapsulatingFullyQualifiedName
 	return message, nil
 }
 
+func newExtension(protoMessage *reflectv1.Extension, encapsulatingFullyQualifiedName string) *Extension {
+	return &Extension{
+		protoMessage:       protoMessage,
+		fullyQualifiedName: getFullyQualifiedName(encapsulatingFullyQualifiedName, protoMessage.Name),
+	}
+}
+
 func newMessageField(protoMessage *reflectv1.MessageField, message *Message) *MessageField {
 	return &MessageField{
 		protoMessage: protoMessage,
@@ -460,11 +608,20 @@ func getFullyQualifiedName(encapsulatingFullyQualifiedName string, name string)
 	return encapsulatingFullyQualifiedName + "." + name
 }
 
-func ignorePackage(withoutBeta bool, packageName string) bool {
-	// if we are not ignoring beta packages, do not ignore
-	if !withoutBeta {
+// orIgnoreFuncs returns a function that returns true if any of the non-nil
+// functions return true.
+func orIgnoreFuncs(ignoreFuncs ...func(string) bool) func(string) bool {
+	return func(s string) bool {
+		for _, ignoreFunc := range ignoreFuncs {
+			if ignoreFunc != nil && ignoreFunc(s) {
+				return true
+			}
+		}
 		return false
 	}
+}
+
+func isBetaPackage(packageName string) bool {
 	// betaVersion is 0 if we can't parse this into a beta package
 	_, betaVersion, _ := protostrs.MajorBetaVersion(packageName)
 	return betaVersion > 0
@@ -37,6 +37,30 @@ func TestOne(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestLocation(t *testing.T) {
+	fileDescriptorSets, err := ptesting.GetFileDescriptorSetsWithSourceInfo(".", "testdata/one")
+	require.NoError(t, err)
+	reflectPackageSet, err := reflect.NewPackageSet(fileDescriptorSets.Unwrap()...)
+	require.NoError(t, err)
+	packageSet, err := NewPackageSetWithFileNames(reflectPackageSet, fileDescriptorSets.Unwrap()...)
+	require.NoError(t, err)
+	for path, expectedLocation := range map[string]*Location{
+		"uber.proto.foo.v1":                                     {FileName: "uber/proto/foo/v1/one.proto", Line: 3, Column: 1},
+		"uber.proto.foo.v1.TwoBar":                              {FileName: "uber/proto/foo/v1/two.proto", Line: 19, Column: 1},
+		"uber.proto.foo.v1.Simple.Nested":                       {FileName: "uber/proto/foo/v1/one.proto", Line: 49, Column: 3},
+		"uber.proto.foo.v1.Simple.test_oneof":                   {FileName: "uber/proto/foo/v1/one.proto", Line: 63, Column: 3},
+		MessageFieldPath("uber.proto.foo.v1.Simple", 6):         {FileName: "uber/proto/foo/v1/one.proto", Line: 65, Column: 5},
+		EnumValuePath("uber.proto.foo.v1.Simple.NestedEnum", 2): {FileName: "uber/proto/foo/v1/one.proto", Line: 56, Column: 5},
+		"uber.proto.foo.v1.SomeAPI.Bar":                         {FileName: "uber/proto/foo/v1/one.proto", Line: 87, Column: 3},
+	} {
+		require.Equal(t, expectedLocation, packageSet.Location(path), path)
+	}
+	require.Nil(t, packageSet.Location("uber.proto.foo.v1.Simple.Foo"))
+	withoutPackageSet, err := packageSet.Without(func(packageName string) bool { return packageName == "uber.proto.bar.v1" }, nil)
+	require.NoError(t, err)
+	require.NotNil(t, withoutPackageSet.Location("uber.proto.foo.v1.TwoBar"))
+}
+
 func requireGetPackageSet(t *testing.T, subDirPath string) *PackageSet {
 	packageSet, err := getPackageSet(subDirPath)
 	require.NoError(t, err)
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package extract
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The field numbers in descriptor.proto that make up the paths of
+// source code info locations.
+const (
+	fileDescriptorProtoPackageTag     = 2
+	fileDescriptorProtoMessageTypeTag = 4
+	fileDescriptorProtoEnumTypeTag    = 5
+	fileDescriptorProtoServiceTag     = 6
+	fileDescriptorProtoExtensionTag   = 7
+	descriptorProtoFieldTag           = 2
+	descriptorProtoNestedTypeTag      = 3
+	descriptorProtoEnumTypeTag        = 4
+	descriptorProtoExtensionTag       = 6
+	descriptorProtoOneofDeclTag       = 8
+	enumDescriptorProtoValueTag       = 2
+	serviceDescriptorProtoMethodTag   = 2
+)
+
+// Location is the location of an element in a file.
+type Location struct {
+	// FileName is the name of the file as in the FileDescriptorSet.
+	FileName string
+	// Line is the 1-indexed line of the start of the element.
+	Line int
+	// Column is the 1-indexed column of the start of the element.
+	Column int
+}
+
+// MessageFieldPath returns the path of the message field with the given
+// number, see PackageSet.Location.
+func MessageFieldPath(messageName string, fieldNumber int32) string {
+	return getFullyQualifiedName(messageName, strconv.Itoa(int(fieldNumber)))
+}
+
+// EnumValuePath returns the path of the enum value with the given
+// number, see PackageSet.Location.
+func EnumValuePath(enumName string, valueNumber int32) string {
+	return getFullyQualifiedName(enumName, strconv.Itoa(int(valueNumber)))
+}
+
+type fileLocations struct {
+	fileName         string
+	sourcePathToSpan map[string][]int32
+	pathToLocation   map[string]*Location
+}
+
+// addFileLocations adds the locations of the elements declared in the file
+// to pathToLocation. Nothing is added if the file has no source code info.
+func addFileLocations(pathToLocation map[string]*Location, fileDescriptorProto *descriptor.FileDescriptorProto) {
+	sourceCodeInfo := fileDescriptorProto.GetSourceCodeInfo()
+	if sourceCodeInfo == nil {
+		return
+	}
+	fileLocations := &fileLocations{
+		fileName:         fileDescriptorProto.GetName(),
+		sourcePathToSpan: make(map[string][]int32),
+		pathToLocation:   pathToLocation,
+	}
+	for _, location := range sourceCodeInfo.GetLocation() {
+		sourcePathKey := getSourcePathKey(location.GetPath())
+		if _, ok := fileLocations.sourcePathToSpan[sourcePathKey]; !ok && len(location.GetSpan()) >= 2 {
+			fileLocations.sourcePathToSpan[sourcePathKey] = location.GetSpan()
+		}
+	}
+	packageName := fileDescriptorProto.GetPackage()
+	if packageName != "" {
+		fileLocations.add(packageName, []int32{fileDescriptorProtoPackageTag})
+	}
+	for i, enum := range fileDescriptorProto.GetEnumType() {
+		fileLocations.addEnum(packageName, enum, []int32{fileDescriptorProtoEnumTypeTag, int32(i)})
+	}
+	for i, message := range fileDescriptorProto.GetMessageType() {
+		fileLocations.addMessage(packageName, message, []int32{fileDescriptorProtoMessageTypeTag, int32(i)})
+	}
+	for i, service := range fileDescriptorProto.GetService() {
+		serviceName := getFullyQualifiedName(packageName, service.GetName())
+		serviceSourcePath := []int32{fileDescriptorProtoServiceTag, int32(i)}
+		fileLocations.add(serviceName, serviceSourcePath)
+		for j, method := range service.GetMethod() {
+			fileLocations.add(getFullyQualifiedName(serviceName, method.GetName()), appendSourcePath(serviceSourcePath, serviceDescriptorProtoMethodTag, int32(j)))
+		}
+	}
+	for i, extension := range fileDescriptorProto.GetExtension() {
+		fileLocations.add(getFullyQualifiedName(packageName, extension.GetName()), []int32{fileDescriptorProtoExtensionTag, int32(i)})
+	}
+}
+
+func (f *fileLocations) addEnum(encapsulatingFullyQualifiedName string, enum *descriptor.EnumDescriptorProto, sourcePath []int32) {
+	enumName := getFullyQualifiedName(encapsulatingFullyQualifiedName, enum.GetName())
+	f.add(enumName, sourcePath)
+	for i, value := range enum.GetValue() {
+		f.add(EnumValuePath(enumName, value.GetNumber()), appendSourcePath(sourcePath, enumDescriptorProtoValueTag, int32(i)))
+	}
+}
+
+func (f *fileLocations) addMessage(encapsulatingFullyQualifiedName string, message *descriptor.DescriptorProto, sourcePath []int32) {
+	messageName := getFullyQualifiedName(encapsulatingFullyQualifiedName, message.GetName())
+	f.add(messageName, sourcePath)
+	for i, field := range message.GetField() {
+		f.add(MessageFieldPath(messageName, field.GetNumber()), appendSourcePath(sourcePath, descriptorProtoFieldTag, int32(i)))
+	}
+	for i, oneof := range message.GetOneofDecl() {
+		f.add(getFullyQualifiedName(messageName, oneof.GetName()), appendSourcePath(sourcePath, descriptorProtoOneofDeclTag, int32(i)))
+	}
+	for i, nestedEnum := range message.GetEnumType() {
+		f.addEnum(messageName, nestedEnum, appendSourcePath(sourcePath, descriptorProtoEnumTypeTag, int32(i)))
+	}
+	for i, nestedMessage := range message.GetNestedType() {
+		f.addMessage(messageName, nestedMessage, appendSourcePath(sourcePath, descriptorProtoNestedTypeTag, int32(i)))
+	}
+	for i, nestedExtension := range message.GetExtension() {
+		f.add(getFullyQualifiedName(messageName, nestedExtension.GetName()), appendSourcePath(sourcePath, descriptorProtoExtensionTag, int32(i)))
+	}
+}
+
+// add adds the location of the element with the given path if there is
+// source code info for it.
+//
+// Packages are declared in many files, so the file with the smallest name
+// is used for these to be deterministic.
+func (f *fileLocations) add(path string, sourcePath []int32) {
+	span, ok := f.sourcePathToSpan[getSourcePathKey(sourcePath)]
+	if !ok {
+		return
+	}
+	if location, ok := f.pathToLocation[path]; ok && location.FileName <= f.fileName {
+		return
+	}
+	// spans are zero-indexed
+	f.pathToLocation[path] = &Location{
+		FileName: f.fileName,
+		Line:     int(span[0]) + 1,
+		Column:   int(span[1]) + 1,
+	}
+}
+
+// appendSourcePath returns a copy of sourcePath with the elements appended,
+// so that sourcePath can be reused by the caller.
+func appendSourcePath(sourcePath []int32, elements ...int32) []int32 {
+	result := make([]int32, 0, len(sourcePath)+len(elements))
+	result = append(result, sourcePath...)
+	return append(result, elements...)
+}
+
+func getSourcePathKey(sourcePath []int32) string {
+	elements := make([]string, len(sourcePath))
+	for i, element := range sourcePath {
+		elements[i] = strconv.Itoa(int(element))
+	}
+	return strings.Join(elements, ".")
+}
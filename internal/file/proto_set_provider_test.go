@@ -91,6 +91,12 @@ func TestProtoSetProviderGetMultipleForDirCwdAsValidRel(t *testing.T) {
 						ExcludeIDs:          []string{},
 						IgnoreIDToFilePaths: map[string][]string{},
 					},
+					Break: settings.BreakConfig{
+						IncludeIDs:          []string{},
+						ExcludeIDs:          []string{},
+						IgnoreIDToPackages:  map[string][]string{},
+						IgnoreIDToFileNames: map[string][]string{},
+					},
 					Gen: settings.GenConfig{
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
@@ -127,6 +133,12 @@ func TestProtoSetProviderGetMultipleForDirCwdAsValidRel(t *testing.T) {
 						ExcludeIDs:          []string{},
 						IgnoreIDToFilePaths: map[string][]string{},
 					},
+					Break: settings.BreakConfig{
+						IncludeIDs:          []string{},
+						ExcludeIDs:          []string{},
+						IgnoreIDToPackages:  map[string][]string{},
+						IgnoreIDToFileNames: map[string][]string{},
+					},
 					Gen: settings.GenConfig{
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
@@ -159,6 +171,12 @@ func TestProtoSetProviderGetMultipleForDirCwdAsValidRel(t *testing.T) {
 						ExcludeIDs:          []string{},
 						IgnoreIDToFilePaths: map[string][]string{},
 					},
+					Break: settings.BreakConfig{
+						IncludeIDs:          []string{},
+						ExcludeIDs:          []string{},
+						IgnoreIDToPackages:  map[string][]string{},
+						IgnoreIDToFileNames: map[string][]string{},
+					},
 					Gen: settings.GenConfig{
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
@@ -229,6 +247,12 @@ func TestProtoSetProviderGetMultipleForDirCwdAbs(t *testing.T) {
 						ExcludeIDs:          []string{},
 						IgnoreIDToFilePaths: map[string][]string{},
 					},
+					Break: settings.BreakConfig{
+						IncludeIDs:          []string{},
+						ExcludeIDs:          []string{},
+						IgnoreIDToPackages:  map[string][]string{},
+						IgnoreIDToFileNames: map[string][]string{},
+					},
 					Gen: settings.GenConfig{
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
@@ -265,6 +289,12 @@ func TestProtoSetProviderGetMultipleForDirCwdAbs(t *testing.T) {
 						ExcludeIDs:          []string{},
 						IgnoreIDToFilePaths: map[string][]string{},
 					},
+					Break: settings.BreakConfig{
+						IncludeIDs:          []string{},
+						ExcludeIDs:          []string{},
+						IgnoreIDToPackages:  map[string][]string{},
+						IgnoreIDToFileNames: map[string][]string{},
+					},
 					Gen: settings.GenConfig{
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
@@ -297,6 +327,12 @@ func TestProtoSetProviderGetMultipleForDirCwdAbs(t *testing.T) {
 						ExcludeIDs:          []string{},
 						IgnoreIDToFilePaths: map[string][]string{},
 					},
+					Break: settings.BreakConfig{
+						IncludeIDs:          []string{},
+						ExcludeIDs:          []string{},
+						IgnoreIDToPackages:  map[string][]string{},
+						IgnoreIDToFileNames: map[string][]string{},
+					},
 					Gen: settings.GenConfig{
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
@@ -347,6 +383,12 @@ func TestProtoSetProviderGetMultipleForDirCwdSubRel(t *testing.T) {
 						ExcludeIDs:          []string{},
 						IgnoreIDToFilePaths: map[string][]string{},
 					},
+					Break: settings.BreakConfig{
+						IncludeIDs:          []string{},
+						ExcludeIDs:          []string{},
+						IgnoreIDToPackages:  map[string][]string{},
+						IgnoreIDToFileNames: map[string][]string{},
+					},
 					Gen: settings.GenConfig{
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
@@ -394,6 +436,12 @@ func TestProtoSetProviderGetForDirEmpty(t *testing.T) {
 					ExcludeIDs:          []string{},
 					IgnoreIDToFilePaths: map[string][]string{},
 				},
+				Break: settings.BreakConfig{
+					IncludeIDs:          []string{},
+					ExcludeIDs:          []string{},
+					IgnoreIDToPackages:  map[string][]string{},
+					IgnoreIDToFileNames: map[string][]string{},
+				},
 				Gen: settings.GenConfig{
 					GoPluginOptions: settings.GenGoPluginOptions{},
 					Plugins:         []settings.GenPlugin{},
@@ -432,6 +480,12 @@ func TestProtoSetProviderGetForDirConfigData(t *testing.T) {
 					ExcludeIDs:          []string{},
 					IgnoreIDToFilePaths: map[string][]string{},
 				},
+				Break: settings.BreakConfig{
+					IncludeIDs:          []string{},
+					ExcludeIDs:          []string{},
+					IgnoreIDToPackages:  map[string][]string{},
+					IgnoreIDToFileNames: map[string][]string{},
+				},
 				Gen: settings.GenConfig{
 					GoPluginOptions: settings.GenGoPluginOptions{},
 					Plugins:         []settings.GenPlugin{},
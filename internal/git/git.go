@@ -38,31 +38,138 @@ import (
 // It is safe to os.RemoveAll this directory.
 //
 // If branchOrTag is not empty, this specific branch or tag will be cloned.
+//
+// The dirPath can also be the URL of a remote repository.
 func TemporaryClone(logger *zap.Logger, dirPath string, branchOrTag string) (string, error) {
-	absDirPath, err := file.AbsClean(dirPath)
+	cloneURL, _, err := getCloneURL(dirPath)
 	if err != nil {
 		return "", err
 	}
-	fileInfo, err := os.Stat(filepath.Join(absDirPath, ".git"))
+	cloneDirPath, err := ioutil.TempDir("", "prototool")
 	if err != nil {
 		return "", err
 	}
-	if !fileInfo.IsDir() {
-		return "", fmt.Errorf("%q does not contain a .git directory", absDirPath)
+	args := []string{"clone", "--depth", "1"}
+	if branchOrTag != "" {
+		args = append(args, "--branch", branchOrTag)
+	}
+	args = append(args, cloneURL, cloneDirPath)
+	if _, err := run(logger, "", args...); err != nil {
+		_ = os.RemoveAll(cloneDirPath)
+		return "", err
+	}
+	return cloneDirPath, nil
+}
+
+// TemporaryCloneAtRef clones the git directory at the given dirPath into a temporary
+// directory and checks out the given ref.
+//
+// It is safe to os.RemoveAll this directory.
+//
+// The ref can be anything that git rev-parse resolves to a commit, for example a commit
+// SHA, a branch, a tag, or HEAD~1. If dirPath is a local directory, the ref is resolved
+// against this directory, so HEAD refers to the current HEAD of this directory. Otherwise
+// the ref is resolved against the clone, and branches are resolved as remote branches.
+//
+// The dirPath can also be the URL of a remote repository.
+func TemporaryCloneAtRef(logger *zap.Logger, dirPath string, ref string) (_ string, retErr error) {
+	if ref == "" {
+		return "", fmt.Errorf("ref empty")
+	}
+	cloneURL, localDirPath, err := getCloneURL(dirPath)
+	if err != nil {
+		return "", err
+	}
+	var commit string
+	if localDirPath != "" {
+		commit, err = resolveCommit(logger, localDirPath, ref)
+		if err != nil {
+			return "", err
+		}
 	}
 	cloneDirPath, err := ioutil.TempDir("", "prototool")
 	if err != nil {
 		return "", err
 	}
-	args := []string{"clone", "--depth", "1"}
-	if branchOrTag != "" {
-		args = append(args, "--branch", branchOrTag)
+	defer func() {
+		if retErr != nil {
+			_ = os.RemoveAll(cloneDirPath)
+		}
+	}()
+	// a full clone is needed as the commit can be anywhere in the history
+	if _, err := run(logger, "", "clone", "--no-checkout", "--quiet", cloneURL, cloneDirPath); err != nil {
+		return "", err
 	}
-	args = append(args, fmt.Sprintf("file://%s", absDirPath), cloneDirPath)
+	if commit == "" {
+		commit, err = resolveCommit(logger, cloneDirPath, ref)
+		if err != nil {
+			// branches other than the default branch only exist as remote branches
+			commit, err = resolveCommit(logger, cloneDirPath, "origin/"+ref)
+			if err != nil {
+				return "", fmt.Errorf("could not resolve git ref %q in %s", ref, dirPath)
+			}
+		}
+	} else if _, err := run(logger, cloneDirPath, "cat-file", "-e", commit+"^{commit}"); err != nil {
+		// the commit is not reachable from any branch or tag, for example on a detached HEAD
+		if _, err := run(logger, cloneDirPath, "fetch", "--quiet", "origin", commit); err != nil {
+			return "", err
+		}
+	}
+	if _, err := run(logger, cloneDirPath, "checkout", "--quiet", "--detach", commit); err != nil {
+		return "", err
+	}
+	return cloneDirPath, nil
+}
+
+// getCloneURL returns the URL to clone for the dirPath, and the absolute path of
+// dirPath if dirPath is a local directory.
+func getCloneURL(dirPath string) (string, string, error) {
+	if _, err := os.Stat(dirPath); err != nil {
+		if os.IsNotExist(err) && isRemoteURL(dirPath) {
+			return dirPath, "", nil
+		}
+		return "", "", err
+	}
+	absDirPath, err := file.AbsClean(dirPath)
+	if err != nil {
+		return "", "", err
+	}
+	fileInfo, err := os.Stat(filepath.Join(absDirPath, ".git"))
+	if err != nil {
+		return "", "", err
+	}
+	if !fileInfo.IsDir() {
+		return "", "", fmt.Errorf("%q does not contain a .git directory", absDirPath)
+	}
+	return fmt.Sprintf("file://%s", absDirPath), absDirPath, nil
+}
+
+// isRemoteURL returns true if s looks like a URL or a scp-like address such
+// as git@github.com:uber/prototool.git.
+func isRemoteURL(s string) bool {
+	if strings.Contains(s, "://") {
+		return true
+	}
+	colonIndex := strings.Index(s, ":")
+	return colonIndex > 0 && !strings.Contains(s[:colonIndex], "/")
+}
+
+func resolveCommit(logger *zap.Logger, dirPath string, ref string) (string, error) {
+	output, err := run(logger, dirPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("could not resolve git ref %q in %s", ref, dirPath)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// run runs git with the given args in dirPath, or the current directory if dirPath is empty.
+func run(logger *zap.Logger, dirPath string, args ...string) (string, error) {
 	logger.Sugar().Debugf("git %s", strings.Join(args, " "))
-	output, err := exec.Command("git", args...).CombinedOutput()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dirPath
+	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return "", fmt.Errorf("git %s had error: %s", strings.Join(args, " "), string(output))
 	}
-	return cloneDirPath, nil
+	return string(output), nil
 }
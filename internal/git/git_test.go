@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTemporaryCloneAtRef(t *testing.T) {
+	dirPath, nameToCommit := newTestRepository(t)
+	remoteURL := "file://" + dirPath
+
+	testTemporaryCloneAtRef(t, dirPath, nameToCommit["one"], "one")
+	testTemporaryCloneAtRef(t, dirPath, "v1", "one")
+	testTemporaryCloneAtRef(t, dirPath, "HEAD", "two")
+	testTemporaryCloneAtRef(t, dirPath, "feature", "three")
+	// the commit is not reachable from any branch or tag, so it has to be fetched
+	testTemporaryCloneAtRef(t, dirPath, nameToCommit["detached"], "detached")
+
+	testTemporaryCloneAtRef(t, remoteURL, nameToCommit["one"], "one")
+	testTemporaryCloneAtRef(t, remoteURL, "v1", "one")
+	// branches other than the default branch are resolved as remote branches
+	testTemporaryCloneAtRef(t, remoteURL, "feature", "three")
+
+	for _, cloneDirPath := range []string{dirPath, remoteURL} {
+		_, err := TemporaryCloneAtRef(zap.NewNop(), cloneDirPath, "unknown")
+		assert.Error(t, err)
+		_, err = TemporaryCloneAtRef(zap.NewNop(), cloneDirPath, "")
+		assert.Error(t, err)
+	}
+}
+
+func TestIsRemoteURL(t *testing.T) {
+	assert.True(t, isRemoteURL("https://github.com/uber/prototool.git"))
+	assert.True(t, isRemoteURL("file:///tmp/prototool"))
+	assert.True(t, isRemoteURL("git@github.com:uber/prototool.git"))
+	assert.False(t, isRemoteURL("foo/bar"))
+	assert.False(t, isRemoteURL("/tmp/foo:bar"))
+	assert.False(t, isRemoteURL(":foo"))
+}
+
+func testTemporaryCloneAtRef(t *testing.T, dirPath string, ref string, expectedContent string) {
+	cloneDirPath, err := TemporaryCloneAtRef(zap.NewNop(), dirPath, ref)
+	require.NoError(t, err, "%s %s", dirPath, ref)
+	defer func() { _ = os.RemoveAll(cloneDirPath) }()
+	data, err := ioutil.ReadFile(filepath.Join(cloneDirPath, "file.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, expectedContent, string(data), "%s %s", dirPath, ref)
+}
+
+// newTestRepository creates a repository with the commits one and two on
+// the default branch, the tag v1 at one, the commit three on the branch
+// feature, and the commit detached that is not reachable from any branch or
+// tag, and returns its path and the SHAs of the commits.
+func newTestRepository(t *testing.T) (string, map[string]string) {
+	dirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dirPath) })
+	nameToCommit := make(map[string]string)
+	commit := func(name string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dirPath, "file.txt"), []byte(name), 0644))
+		runTestGit(t, dirPath, "add", "file.txt")
+		runTestGit(t, dirPath, "commit", "--quiet", "--message", name)
+		nameToCommit[name] = runTestGit(t, dirPath, "rev-parse", "HEAD")
+	}
+	runTestGit(t, dirPath, "init", "--quiet")
+	commit("one")
+	runTestGit(t, dirPath, "tag", "v1")
+	commit("two")
+	defaultBranch := runTestGit(t, dirPath, "rev-parse", "--abbrev-ref", "HEAD")
+	runTestGit(t, dirPath, "checkout", "--quiet", "-b", "feature")
+	commit("three")
+	runTestGit(t, dirPath, "checkout", "--quiet", "--detach")
+	commit("detached")
+	runTestGit(t, dirPath, "checkout", "--quiet", defaultBranch)
+	return dirPath, nameToCommit
+}
+
+func runTestGit(t *testing.T, dirPath string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dirPath
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_AUTHOR_NAME=prototool",
+		"GIT_AUTHOR_EMAIL=prototool@example.com",
+		"GIT_COMMITTER_NAME=prototool",
+		"GIT_COMMITTER_EMAIL=prototool@example.com",
+	)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), string(output))
+	return strings.TrimSpace(string(output))
+}
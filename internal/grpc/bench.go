@@ -0,0 +1,261 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fullstorydev/grpcurl"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (h *handler) Bench(fileDescriptorSets []*descriptor.FileDescriptorSet, address string, method string, inputReader io.Reader, benchConfig BenchConfig) (*BenchResult, error) {
+	if benchConfig.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1 but was %d", benchConfig.Concurrency)
+	}
+	if benchConfig.Rate < 0 {
+		return nil, fmt.Errorf("rate must not be negative but was %d", benchConfig.Rate)
+	}
+	if benchConfig.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive but was %v", benchConfig.Duration)
+	}
+	descriptorSource, err := getDescriptorSourceForMethod(fileDescriptorSets, method)
+	if err != nil {
+		return nil, err
+	}
+	methodDescriptor, err := getMethodDescriptor(descriptorSource, method)
+	if err != nil {
+		return nil, err
+	}
+	if methodDescriptor.IsClientStreaming() {
+		return nil, fmt.Errorf("only unary and server streaming methods can be benchmarked but %s is client streaming", method)
+	}
+	requests, err := getRequests(methodDescriptor, inputReader)
+	if err != nil {
+		return nil, err
+	}
+	clientConn, err := h.dial(address)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = clientConn.Close() }()
+	benchmark := &benchmark{
+		handler:          h,
+		stub:             grpcdynamic.NewStub(clientConn),
+		methodDescriptor: methodDescriptor,
+		requests:         requests,
+		metadata:         grpcurl.MetadataFromHeaders(h.headers),
+		benchConfig:      benchConfig,
+	}
+	return benchmark.run(method), nil
+}
+
+type benchmark struct {
+	handler          *handler
+	stub             grpcdynamic.Stub
+	methodDescriptor *desc.MethodDescriptor
+	requests         []proto.Message
+	metadata         metadata.MD
+	benchConfig      BenchConfig
+}
+
+type benchmarkCall struct {
+	latency time.Duration
+	code    codes.Code
+}
+
+func (b *benchmark) run(method string) *BenchResult {
+	ctx, cancel := context.WithTimeout(context.Background(), b.benchConfig.Duration)
+	defer cancel()
+	// nil if there is no rate limit
+	var tokens <-chan time.Time
+	if b.benchConfig.Rate > 0 {
+		interval := time.Second / time.Duration(b.benchConfig.Rate)
+		if interval <= 0 {
+			interval = 1
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+	var lock sync.Mutex
+	var calls []*benchmarkCall
+	var waitGroup sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < b.benchConfig.Concurrency; i++ {
+		waitGroup.Add(1)
+		// each worker starts at a different request so that
+		// all requests are used with a small duration
+		go func(requestIndex int) {
+			defer waitGroup.Done()
+			var workerCalls []*benchmarkCall
+			for {
+				if tokens != nil {
+					select {
+					case <-ctx.Done():
+					case <-tokens:
+					}
+				}
+				if ctx.Err() != nil {
+					break
+				}
+				workerCalls = append(workerCalls, b.call(b.requests[requestIndex%len(b.requests)]))
+				requestIndex++
+			}
+			lock.Lock()
+			calls = append(calls, workerCalls...)
+			lock.Unlock()
+		}(i)
+	}
+	waitGroup.Wait()
+	return newBenchResult(method, b.benchConfig, time.Since(start), calls)
+}
+
+// call calls the method and returns the latency and status code.
+//
+// Calls are not canceled when the benchmark ends so that the calls
+// in progress are completed and counted.
+func (b *benchmark) call(request proto.Message) *benchmarkCall {
+	ctx, cancel := b.handler.newCallContext()
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, b.metadata)
+	start := time.Now()
+	err := b.invoke(ctx, request)
+	return &benchmarkCall{
+		latency: time.Since(start),
+		code:    status.Code(err),
+	}
+}
+
+func (b *benchmark) invoke(ctx context.Context, request proto.Message) error {
+	if !b.methodDescriptor.IsServerStreaming() {
+		_, err := b.stub.InvokeRpc(ctx, b.methodDescriptor, request)
+		return err
+	}
+	serverStream, err := b.stub.InvokeRpcServerStream(ctx, b.methodDescriptor, request)
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := serverStream.RecvMsg(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func newBenchResult(method string, benchConfig BenchConfig, duration time.Duration, calls []*benchmarkCall) *BenchResult {
+	benchResult := &BenchResult{
+		Method:            method,
+		Concurrency:       benchConfig.Concurrency,
+		Rate:              benchConfig.Rate,
+		Duration:          duration,
+		Calls:             len(calls),
+		StatusCodeToCount: make(map[codes.Code]int),
+	}
+	if len(calls) == 0 {
+		return benchResult
+	}
+	latencies := make([]time.Duration, len(calls))
+	var totalLatency time.Duration
+	for i, call := range calls {
+		latencies[i] = call.latency
+		totalLatency += call.latency
+		benchResult.StatusCodeToCount[call.code]++
+	}
+	sort.Slice(latencies, func(i int, j int) bool { return latencies[i] < latencies[j] })
+	benchResult.Latency = BenchLatency{
+		Min:  latencies[0],
+		Mean: totalLatency / time.Duration(len(latencies)),
+		P50:  getPercentile(latencies, 50),
+		P90:  getPercentile(latencies, 90),
+		P95:  getPercentile(latencies, 95),
+		P99:  getPercentile(latencies, 99),
+		Max:  latencies[len(latencies)-1],
+	}
+	return benchResult
+}
+
+// getPercentile returns the percentile of the sorted latencies
+// with the nearest-rank method.
+func getPercentile(sortedLatencies []time.Duration, percentile float64) time.Duration {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sortedLatencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sortedLatencies[rank-1]
+}
+
+func getMethodDescriptor(descriptorSource grpcurl.DescriptorSource, method string) (*desc.MethodDescriptor, error) {
+	servicePath, err := getServiceForMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := descriptorSource.FindSymbol(servicePath)
+	if err != nil {
+		return nil, err
+	}
+	serviceDescriptor, ok := symbol.(*desc.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", servicePath)
+	}
+	methodDescriptor := serviceDescriptor.FindMethodByName(method[len(servicePath)+1:])
+	if methodDescriptor == nil {
+		return nil, fmt.Errorf("no method for %s", method)
+	}
+	return methodDescriptor, nil
+}
+
+// getRequests reads all requests from the reader.
+func getRequests(methodDescriptor *desc.MethodDescriptor, reader io.Reader) ([]proto.Message, error) {
+	decode := decodeFunc(reader)
+	messageFactory := dynamic.NewMessageFactoryWithDefaults()
+	var requests []proto.Message
+	for {
+		request := messageFactory.NewMessage(methodDescriptor.GetInputType())
+		if err := decode(request); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("error getting request data: %v", err)
+		}
+		requests = append(requests, request)
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no request data")
+	}
+	return requests, nil
+}
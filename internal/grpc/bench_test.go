@@ -0,0 +1,106 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptesting "github.com/uber/prototool/internal/testing"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestBench(t *testing.T) {
+	address := ptesting.RequireStartHealthServer(t, false)
+	benchResult := testBench(t, address, "grpc.health.v1.Health/Check", `{"service":"foo"}`+"\n"+`{"service":"bar"}`, BenchConfig{
+		Concurrency: 2,
+		Duration:    100 * time.Millisecond,
+	})
+	assert.Equal(t, "grpc.health.v1.Health/Check", benchResult.Method)
+	assert.Equal(t, 2, benchResult.Concurrency)
+	assert.True(t, benchResult.Duration >= 100*time.Millisecond)
+	assert.True(t, benchResult.StatusCodeToCount[codes.OK] > 0)
+	assert.True(t, benchResult.StatusCodeToCount[codes.NotFound] > 0)
+	assert.Equal(t, benchResult.Calls, benchResult.StatusCodeToCount[codes.OK]+benchResult.StatusCodeToCount[codes.NotFound])
+	assert.True(t, benchResult.Latency.Min <= benchResult.Latency.P50)
+	assert.True(t, benchResult.Latency.P50 <= benchResult.Latency.Max)
+
+	benchResult = testBench(t, address, "grpc.health.v1.Health/Check", `{"service":"foo"}`, BenchConfig{
+		Concurrency: 1,
+		Rate:        20,
+		Duration:    200 * time.Millisecond,
+	})
+	assert.True(t, benchResult.Calls >= 1 && benchResult.Calls <= 5, "calls: %d", benchResult.Calls)
+	assert.Equal(t, benchResult.Calls, benchResult.StatusCodeToCount[codes.OK])
+
+	_, err := NewHandler().Bench(ptesting.RequireGetMessageFileDescriptorSets(t, &healthpb.HealthCheckRequest{}), address, "grpc.health.v1.Health/Check", strings.NewReader(""), BenchConfig{Concurrency: 1, Duration: time.Second})
+	assert.EqualError(t, err, "no request data")
+	_, err = NewHandler().Bench(ptesting.RequireGetMessageFileDescriptorSets(t, &healthpb.HealthCheckRequest{}), address, "grpc.health.v1.Health/Check", strings.NewReader("{}"), BenchConfig{Duration: time.Second})
+	assert.EqualError(t, err, "concurrency must be at least 1 but was 0")
+}
+
+func TestNewBenchResult(t *testing.T) {
+	var calls []*benchmarkCall
+	for i := 10; i > 0; i-- {
+		code := codes.OK
+		if i%5 == 0 {
+			code = codes.Unavailable
+		}
+		calls = append(calls, &benchmarkCall{latency: time.Duration(i) * time.Millisecond, code: code})
+	}
+	benchResult := newBenchResult("foo.Bar/Baz", BenchConfig{Concurrency: 2, Rate: 5}, 2*time.Second, calls)
+	assert.Equal(
+		t,
+		&BenchResult{
+			Method:      "foo.Bar/Baz",
+			Concurrency: 2,
+			Rate:        5,
+			Duration:    2 * time.Second,
+			Calls:       10,
+			Latency: BenchLatency{
+				Min:  time.Millisecond,
+				Mean: 5500 * time.Microsecond,
+				P50:  5 * time.Millisecond,
+				P90:  9 * time.Millisecond,
+				P95:  10 * time.Millisecond,
+				P99:  10 * time.Millisecond,
+				Max:  10 * time.Millisecond,
+			},
+			StatusCodeToCount: map[codes.Code]int{
+				codes.OK:          8,
+				codes.Unavailable: 2,
+			},
+		},
+		benchResult,
+	)
+	assert.Equal(t, 5.0, benchResult.Throughput())
+	assert.Equal(t, &BenchResult{Method: "foo.Bar/Baz", Concurrency: 1, StatusCodeToCount: map[codes.Code]int{}}, newBenchResult("foo.Bar/Baz", BenchConfig{Concurrency: 1}, 0, nil))
+}
+
+func testBench(t *testing.T, address string, method string, input string, benchConfig BenchConfig) *BenchResult {
+	benchResult, err := NewHandler().Bench(ptesting.RequireGetMessageFileDescriptorSets(t, &healthpb.HealthCheckRequest{}), address, method, strings.NewReader(input), benchConfig)
+	require.NoError(t, err)
+	return benchResult
+}
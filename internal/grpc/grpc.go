@@ -27,6 +27,7 @@ import (
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -39,6 +40,65 @@ const (
 // Handler handles gRPC calls.
 type Handler interface {
 	Invoke(fileDescriptorSets []*descriptor.FileDescriptorSet, address string, method string, inputReader io.Reader, outputWriter io.Writer) error
+	// InvokeWithReflection resolves the method, request and response types, and
+	// the types of Any messages through the gRPC server reflection API.
+	//
+	// If the server does not support reflection, getFileDescriptorSets is called
+	// and the call is made as with Invoke.
+	InvokeWithReflection(getFileDescriptorSets func() ([]*descriptor.FileDescriptorSet, error), address string, method string, inputReader io.Reader, outputWriter io.Writer) error
+	// Bench calls the unary or server streaming method repeatedly with the
+	// requests read from the input as a stream of JSON messages, using the
+	// requests in turn, and returns the results.
+	//
+	// The call timeout applies to each call, and the details option is ignored.
+	Bench(fileDescriptorSets []*descriptor.FileDescriptorSet, address string, method string, inputReader io.Reader, benchConfig BenchConfig) (*BenchResult, error)
+}
+
+// BenchConfig configures a benchmark.
+type BenchConfig struct {
+	// Concurrency is the number of calls made at the same time.
+	// Must be at least 1.
+	Concurrency int
+	// Rate is the maximum number of calls started per second across
+	// all concurrent calls, or 0 for no maximum.
+	Rate int
+	// Duration is how long to start calls for. Calls in progress at the
+	// end are completed and included in the results.
+	Duration time.Duration
+}
+
+// BenchResult is the result of a benchmark.
+type BenchResult struct {
+	Method      string
+	Concurrency int
+	Rate        int
+	// Duration is the actual duration, including the calls in progress at the end.
+	Duration time.Duration
+	Calls    int
+	// Latency is empty if there were no calls.
+	Latency           BenchLatency
+	StatusCodeToCount map[codes.Code]int
+}
+
+// Throughput returns the number of calls per second.
+func (b *BenchResult) Throughput() float64 {
+	if b.Duration <= 0 {
+		return 0
+	}
+	return float64(b.Calls) / b.Duration.Seconds()
+}
+
+// BenchLatency is the latency of the calls of a benchmark.
+//
+// Percentiles are computed with the nearest-rank method.
+type BenchLatency struct {
+	Min  time.Duration
+	Mean time.Duration
+	P50  time.Duration
+	P90  time.Duration
+	P95  time.Duration
+	P99  time.Duration
+	Max  time.Duration
 }
 
 // HandlerOption is an option for a new Handler.
@@ -63,11 +123,30 @@ func HandlerWithDetails() HandlerOption {
 	}
 }
 
+// HandlerWithStreaming returns a HandlerOption that reads requests from the
+// input line by line as they arrive, with one JSON message per line, and sends
+// each request as soon as its line is read. Responses are printed as they are
+// received. Empty lines are ignored, and invalid lines are logged and skipped.
+//
+// For unary and server streaming methods, only the first request is read.
+// With HandlerWithDetails, each request is also printed in the form
+// {"request":...} when it is sent, so that requests and responses are
+// printed in the order they happened.
+//
+// Calls have no timeout unless HandlerWithCallTimeout is also used.
+//
+// The default is to read all requests as a stream of JSON messages.
+func HandlerWithStreaming() HandlerOption {
+	return func(handler *handler) {
+		handler.streaming = true
+	}
+}
+
 // HandlerWithCallTimeout returns a HandlerOption that has the given call timeout.
 //
 // Each invocation must be completed within this time.
 //
-// The default is to use DefaultCallTimeout.
+// The default is to use DefaultCallTimeout, or no timeout with HandlerWithStreaming.
 func HandlerWithCallTimeout(callTimeout time.Duration) HandlerOption {
 	return func(handler *handler) {
 		handler.callTimeout = callTimeout
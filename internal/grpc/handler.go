@@ -21,6 +21,7 @@
 package grpc
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -40,8 +41,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 )
 
+// maxLineSize is the maximum size of a request line with HandlerWithStreaming.
+//
+// This matches the default maximum message size of gRPC servers.
+const maxLineSize = 4 * 1024 * 1024
+
 type handler struct {
 	logger         *zap.Logger
 	callTimeout    time.Duration
@@ -49,6 +56,7 @@ type handler struct {
 	keepaliveTime  time.Duration
 	headers        []string
 	details        bool
+	streaming      bool
 	tls            bool
 	insecure       bool
 	cacert         string
@@ -64,7 +72,7 @@ func newHandler(options ...HandlerOption) *handler {
 	for _, option := range options {
 		option(handler)
 	}
-	if handler.callTimeout == 0 {
+	if handler.callTimeout == 0 && !handler.streaming {
 		handler.callTimeout = DefaultCallTimeout
 	}
 	if handler.connectTimeout == 0 {
@@ -87,8 +95,45 @@ func (h *handler) Invoke(fileDescriptorSets []*descriptor.FileDescriptorSet, add
 		return err
 	}
 	defer func() { _ = clientConn.Close() }()
+	return h.invoke(clientConn, descriptorSource, anyResolver, method, inputReader, outputWriter)
+}
+
+func (h *handler) InvokeWithReflection(getFileDescriptorSets func() ([]*descriptor.FileDescriptorSet, error), address string, method string, inputReader io.Reader, outputWriter io.Writer) error {
+	clientConn, err := h.dial(address)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = clientConn.Close() }()
+	ctx, cancel := h.newCallContext()
+	defer cancel()
+	reflectionClient := newReflectionClient(metadata.NewOutgoingContext(ctx, grpcurl.MetadataFromHeaders(h.headers)), clientConn)
+	defer reflectionClient.Reset()
+	descriptorSource, anyResolver, err := getReflectionDescriptorSourceForMethod(ctx, reflectionClient, method)
+	if err == grpcurl.ErrReflectionNotSupported {
+		h.logger.Debug("server reflection not supported, using local FileDescriptorSets", zap.String("address", address))
+		fileDescriptorSets, err := getFileDescriptorSets()
+		if err != nil {
+			return err
+		}
+		if descriptorSource, err = getDescriptorSourceForMethod(fileDescriptorSets, method); err != nil {
+			return err
+		}
+		if anyResolver, err = getAnyResolver(fileDescriptorSets); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	return h.invoke(clientConn, descriptorSource, anyResolver, method, inputReader, outputWriter)
+}
+
+func (h *handler) invoke(clientConn *grpc.ClientConn, descriptorSource grpcurl.DescriptorSource, anyResolver jsonpb.AnyResolver, method string, inputReader io.Reader, outputWriter io.Writer) error {
 	invocationEventHandler := newInvocationEventHandler(anyResolver, outputWriter, h.logger, h.details)
-	ctx, cancel := context.WithTimeout(context.Background(), h.callTimeout)
+	requestSupplier := decodeFunc(inputReader)
+	if h.streaming {
+		requestSupplier = h.lineDecodeFunc(inputReader, invocationEventHandler)
+	}
+	ctx, cancel := h.newCallContext()
 	defer cancel()
 	if err := grpcurl.InvokeRPC(
 		ctx,
@@ -97,13 +142,56 @@ func (h *handler) Invoke(fileDescriptorSets []*descriptor.FileDescriptorSet, add
 		method,
 		h.headers,
 		invocationEventHandler,
-		decodeFunc(inputReader),
+		requestSupplier,
 	); err != nil {
 		return err
 	}
 	return invocationEventHandler.Err()
 }
 
+// newCallContext returns a context with the call timeout, if any.
+func (h *handler) newCallContext() (context.Context, context.CancelFunc) {
+	if h.callTimeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), h.callTimeout)
+}
+
+// lineDecodeFunc returns a function that decodes the next non-empty line
+// of the reader as a request, blocking until the line arrives.
+//
+// Invalid lines are logged and skipped so that a typo does not end the call.
+// io.EOF is returned once the reader is closed, or after the first request
+// if the method is not client streaming.
+func (h *handler) lineDecodeFunc(reader io.Reader, invocationEventHandler *invocationEventHandler) func(proto.Message) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	numRequests := 0
+	return func(message proto.Message) error {
+		if numRequests > 0 && !invocationEventHandler.IsClientStreaming() {
+			return io.EOF
+		}
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			if err := jsonpb.UnmarshalString(line, message); err != nil {
+				h.logger.Warn("skipping invalid request", zap.String("line", line), zap.Error(err))
+				message.Reset()
+				continue
+			}
+			numRequests++
+			invocationEventHandler.OnSendRequest(message)
+			return nil
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+}
+
 func (h *handler) dial(address string) (*grpc.ClientConn, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), h.connectTimeout)
 	defer cancel()
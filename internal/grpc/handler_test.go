@@ -21,9 +21,19 @@
 package grpc
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
 
 func TestGetNetworkAddress(t *testing.T) {
@@ -73,3 +83,48 @@ func TestGetNetworkAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestLineDecodeFunc(t *testing.T) {
+	const input = `{"name":"foo"}
+
+  {"name":"bar"}  
+{"name":
+{"name":"baz"}
+`
+	testLineDecodeFunc(t, false, &reflectionpb.ServerReflectionRequest{}, "ServerReflectionInfo", input, []string{"foo", "bar", "baz"}, "")
+	testLineDecodeFunc(t, true, &reflectionpb.ServerReflectionRequest{}, "ServerReflectionInfo", input, []string{"foo", "bar", "baz"}, `{"request":{"name":"foo"}}
+{"request":{"name":"bar"}}
+{"request":{"name":"baz"}}
+`)
+	// only the first request is read if the method is not client streaming
+	testLineDecodeFunc(t, false, &healthpb.HealthCheckRequest{}, "Watch", input, []string{"foo"}, "")
+	testLineDecodeFunc(t, false, &healthpb.HealthCheckRequest{}, "Check", input, []string{"foo"}, "")
+	testLineDecodeFunc(t, false, &healthpb.HealthCheckRequest{}, "Check", "", nil, "")
+}
+
+func testLineDecodeFunc(t *testing.T, details bool, serviceMessage proto.Message, methodName string, input string, expectedNames []string, expectedOutput string) {
+	messageDescriptor, err := desc.LoadMessageDescriptorForMessage(serviceMessage)
+	require.NoError(t, err)
+	serviceDescriptors := messageDescriptor.GetFile().GetServices()
+	require.Len(t, serviceDescriptors, 1)
+	methodDescriptor := serviceDescriptors[0].FindMethodByName(methodName)
+	require.NotNil(t, methodDescriptor)
+
+	output := bytes.NewBuffer(nil)
+	invocationEventHandler := newInvocationEventHandler(nil, output, zap.NewNop(), details)
+	invocationEventHandler.OnResolveMethod(methodDescriptor)
+	decode := newHandler().lineDecodeFunc(strings.NewReader(input), invocationEventHandler)
+	var names []string
+	for {
+		// any message with a name field will do
+		message := &descriptor.FileDescriptorProto{}
+		err := decode(message)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, message.GetName())
+	}
+	assert.Equal(t, expectedNames, names)
+	assert.Equal(t, expectedOutput, output.String())
+}
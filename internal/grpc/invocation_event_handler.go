@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"io"
 	"reflect"
+	"sync"
 
 	"github.com/fullstorydev/grpcurl"
 	"github.com/golang/protobuf/jsonpb"
@@ -42,6 +43,10 @@ type invocationEventHandler struct {
 	logger          *zap.Logger
 	details         bool
 	err             error
+
+	methodDescriptor *desc.MethodDescriptor
+	// requests may be printed while responses are received
+	lock sync.Mutex
 }
 
 func newInvocationEventHandler(anyResolver jsonpb.AnyResolver, output io.Writer, logger *zap.Logger, details bool) *invocationEventHandler {
@@ -55,7 +60,25 @@ func newInvocationEventHandler(anyResolver jsonpb.AnyResolver, output io.Writer,
 	}
 }
 
-func (i *invocationEventHandler) OnResolveMethod(*desc.MethodDescriptor) {}
+func (i *invocationEventHandler) OnResolveMethod(methodDescriptor *desc.MethodDescriptor) {
+	i.methodDescriptor = methodDescriptor
+}
+
+// OnSendRequest is called with each request before it is sent.
+//
+// This is not part of grpcurl.InvocationEventHandler, and is only
+// called for HandlerWithStreaming.
+func (i *invocationEventHandler) OnSendRequest(message proto.Message) {
+	if !i.details {
+		return
+	}
+	i.printProtoMessage(message, "request")
+}
+
+// IsClientStreaming returns true if the resolved method is client streaming.
+func (i *invocationEventHandler) IsClientStreaming() bool {
+	return i.methodDescriptor != nil && i.methodDescriptor.IsClientStreaming()
+}
 
 func (i *invocationEventHandler) OnSendHeaders(metadata.MD) {}
 
@@ -128,6 +151,8 @@ func (i *invocationEventHandler) println(s string) {
 	if s == "" {
 		return
 	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
 	if _, err := i.output.Write([]byte(s + "\n")); err != nil {
 		i.logger.Error("write error", zap.Error(err))
 	}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fullstorydev/grpcurl"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/grpcreflect"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+func newReflectionClient(ctx context.Context, clientConn *grpc.ClientConn) *grpcreflect.Client {
+	return grpcreflect.NewClient(ctx, reflectionpb.NewServerReflectionClient(clientConn))
+}
+
+// getReflectionDescriptorSourceForMethod returns grpcurl.ErrReflectionNotSupported
+// if the server does not support reflection.
+func getReflectionDescriptorSourceForMethod(ctx context.Context, reflectionClient *grpcreflect.Client, method string) (grpcurl.DescriptorSource, jsonpb.AnyResolver, error) {
+	servicePath, err := getServiceForMethod(method)
+	if err != nil {
+		return nil, nil, err
+	}
+	descriptorSource := grpcurl.DescriptorSourceFromServer(ctx, reflectionClient)
+	// resolve the service up front so that we know if reflection is supported
+	// before we start the call
+	if _, err := descriptorSource.FindSymbol(servicePath); err != nil {
+		return nil, nil, err
+	}
+	return descriptorSource, newReflectionAnyResolver(reflectionClient), nil
+}
+
+type reflectionAnyResolver struct {
+	reflectionClient *grpcreflect.Client
+	messageFactory   *dynamic.MessageFactory
+	delegate         jsonpb.AnyResolver
+}
+
+func newReflectionAnyResolver(reflectionClient *grpcreflect.Client) *reflectionAnyResolver {
+	messageFactory := dynamic.NewMessageFactoryWithDefaults()
+	return &reflectionAnyResolver{
+		reflectionClient: reflectionClient,
+		messageFactory:   messageFactory,
+		delegate:         dynamic.AnyResolver(messageFactory),
+	}
+}
+
+func (r *reflectionAnyResolver) Resolve(typeURL string) (proto.Message, error) {
+	messageName := typeURL
+	if i := strings.LastIndex(messageName, "/"); i >= 0 {
+		messageName = messageName[i+1:]
+	}
+	messageDescriptor, err := r.reflectionClient.ResolveMessage(messageName)
+	if err != nil {
+		// the server may not know about well-known or otherwise linked-in types
+		return r.delegate.Resolve(typeURL)
+	}
+	return r.messageFactory.NewMessage(messageDescriptor), nil
+}
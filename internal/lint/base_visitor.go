@@ -84,6 +84,35 @@ func (v *baseAddSuppressableVisitor) AddFailuref(comment *proto.Comment, positio
 	v.add(v.fileDescriptor.ProtoSet, comment, text.NewFailuref(position, "", format, args...))
 }
 
+type baseAddFixVisitor struct {
+	baseVisitor
+	fileDescriptor *FileDescriptor
+	add            func(*text.Failure, ...*TextEdit)
+}
+
+func newBaseAddFixVisitor(add func(*text.Failure, ...*TextEdit)) *baseAddFixVisitor {
+	return &baseAddFixVisitor{add: add}
+}
+
+func (v *baseAddFixVisitor) OnStart(fileDescriptor *FileDescriptor) error {
+	v.fileDescriptor = fileDescriptor
+	return nil
+}
+
+func (v *baseAddFixVisitor) AddFailuref(position scanner.Position, format string, args ...interface{}) {
+	v.add(text.NewFailuref(position, "", format, args...))
+}
+
+// AddFixableFailuref adds a failure that is fixed by the text edit.
+// If the text edit is nil, the failure is added without a fix.
+func (v *baseAddFixVisitor) AddFixableFailuref(textEdit *TextEdit, position scanner.Position, format string, args ...interface{}) {
+	if textEdit == nil {
+		v.AddFailuref(position, format, args...)
+		return
+	}
+	v.add(text.NewFailuref(position, "", format, args...), textEdit)
+}
+
 // extendedVisitor extends the proto.Visitor interface.
 // extendedVisitors are expected to be called with one file at a time,
 // and are not thread-safe.
@@ -21,24 +21,25 @@
 package lint
 
 import (
+	"strings"
 	"text/scanner"
 
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/text"
 )
 
-var commentsNoCStyleLinter = NewLinter(
+var commentsNoCStyleLinter = NewFixableLinter(
 	"COMMENTS_NO_C_STYLE",
 	"Verifies that there are no /* c-style */ comments.",
 	checkCommentsNoCStyle,
 )
 
-func checkCommentsNoCStyle(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
-	return runVisitor(&commentsNoCStyleVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkCommentsNoCStyle(add func(*text.Failure, ...*TextEdit), dirPath string, descriptors []*FileDescriptor) error {
+	return runVisitor(&commentsNoCStyleVisitor{baseAddFixVisitor: newBaseAddFixVisitor(add)}, descriptors)
 }
 
 type commentsNoCStyleVisitor struct {
-	baseAddVisitor
+	*baseAddFixVisitor
 }
 
 func (v commentsNoCStyleVisitor) VisitMessage(element *proto.Message) {
@@ -143,8 +144,55 @@ func (v commentsNoCStyleVisitor) checkComments(position scanner.Position, commen
 	for _, comment := range comments {
 		if comment != nil {
 			if comment.Cstyle {
-				v.AddFailuref(position, "C-Style comments are not allowed.")
+				v.AddFixableFailuref(v.newTextEdit(comment), position, "C-Style comments are not allowed.")
 			}
 		}
 	}
 }
+
+// newTextEdit returns a TextEdit that replaces the c-style comment with
+// line comments, or nil if there is anything after the comment on the
+// same line, as this would then be commented out.
+func (v commentsNoCStyleVisitor) newTextEdit(comment *proto.Comment) *TextEdit {
+	fileData := v.fileDescriptor.FileData
+	start := comment.Position.Offset
+	if start < 0 || start >= len(fileData) || !strings.HasPrefix(fileData[start:], "/*") {
+		return nil
+	}
+	length := strings.Index(fileData[start+2:], "*/")
+	if length < 0 {
+		return nil
+	}
+	end := start + 2 + length + 2
+	restOfLine := fileData[end:]
+	if newline := strings.IndexByte(restOfLine, '\n'); newline >= 0 {
+		restOfLine = restOfLine[:newline]
+	}
+	if strings.TrimSpace(restOfLine) != "" {
+		return nil
+	}
+	var lines []string
+	for _, line := range comment.Lines {
+		line = strings.TrimRight(line, " \t")
+		// remove the leading "*" of javadoc-style comments
+		if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "*") {
+			line = trimmed[1:]
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = "//" + line
+	}
+	return &TextEdit{
+		Filename: v.fileDescriptor.Filename,
+		Start:    start,
+		End:      end,
+		NewText:  strings.Join(lines, "\n"),
+	}
+}
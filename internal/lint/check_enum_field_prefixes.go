@@ -28,18 +28,18 @@ import (
 	"github.com/uber/prototool/internal/text"
 )
 
-var enumFieldPrefixesLinter = NewLinter(
+var enumFieldPrefixesLinter = NewFixableLinter(
 	"ENUM_FIELD_PREFIXES",
 	"Verifies that all enum fields are prefixed with [NESTED_MESSAGE_NAME_]ENUM_NAME_.",
 	checkEnumFieldPrefixes,
 )
 
-func checkEnumFieldPrefixes(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
-	return runVisitor(&enumFieldPrefixesVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkEnumFieldPrefixes(add func(*text.Failure, ...*TextEdit), dirPath string, descriptors []*FileDescriptor) error {
+	return runVisitor(&enumFieldPrefixesVisitor{baseAddFixVisitor: newBaseAddFixVisitor(add)}, descriptors)
 }
 
 type enumFieldPrefixesVisitor struct {
-	baseAddVisitor
+	*baseAddFixVisitor
 
 	nestedNames []string
 }
@@ -63,6 +63,8 @@ func (v *enumFieldPrefixesVisitor) VisitEnum(enum *proto.Enum) {
 func (v *enumFieldPrefixesVisitor) VisitEnumField(enumField *proto.EnumField) {
 	expectedPrefix := strings.Join(v.nestedNames, "_") + "_"
 	if !strings.HasPrefix(enumField.Name, expectedPrefix) {
-		v.AddFailuref(enumField.Position, "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
+		// the fix keeps an existing ENUM_NAME_ prefix so that it is not repeated
+		newName := expectedPrefix + strings.TrimPrefix(enumField.Name, v.nestedNames[len(v.nestedNames)-1]+"_")
+		v.AddFixableFailuref(newEnumFieldRenameTextEdit(v.fileDescriptor, enumField, newName), enumField.Position, "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
 	}
 }
@@ -28,18 +28,18 @@ import (
 	"github.com/uber/prototool/internal/text"
 )
 
-var enumFieldPrefixesExceptMessageLinter = NewLinter(
+var enumFieldPrefixesExceptMessageLinter = NewFixableLinter(
 	"ENUM_FIELD_PREFIXES_EXCEPT_MESSAGE",
 	"Verifies that all enum fields are prefixed with ENUM_NAME_.",
 	checkEnumFieldPrefixesExceptMessage,
 )
 
-func checkEnumFieldPrefixesExceptMessage(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
-	return runVisitor(&enumFieldPrefixesExceptMessageVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkEnumFieldPrefixesExceptMessage(add func(*text.Failure, ...*TextEdit), dirPath string, descriptors []*FileDescriptor) error {
+	return runVisitor(&enumFieldPrefixesExceptMessageVisitor{baseAddFixVisitor: newBaseAddFixVisitor(add)}, descriptors)
 }
 
 type enumFieldPrefixesExceptMessageVisitor struct {
-	baseAddVisitor
+	*baseAddFixVisitor
 }
 
 func (v *enumFieldPrefixesExceptMessageVisitor) VisitMessage(message *proto.Message) {
@@ -65,6 +65,6 @@ func (v *enumFieldPrefixesExceptMessageVisitor) VisitEnumField(enumField *proto.
 	}
 	expectedPrefix := strs.ToUpperSnakeCase(enum.Name) + "_"
 	if !strings.HasPrefix(enumField.Name, expectedPrefix) {
-		v.AddFailuref(enumField.Position, "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
+		v.AddFixableFailuref(newEnumFieldRenameTextEdit(v.fileDescriptor, enumField, expectedPrefix+enumField.Name), enumField.Position, "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
 	}
 }
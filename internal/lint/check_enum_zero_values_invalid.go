@@ -28,18 +28,18 @@ import (
 	"github.com/uber/prototool/internal/text"
 )
 
-var enumZeroValuesInvalidLinter = NewLinter(
+var enumZeroValuesInvalidLinter = NewFixableLinter(
 	"ENUM_ZERO_VALUES_INVALID",
 	"Verifies that all enum zero value names are [NESTED_MESSAGE_NAME_]ENUM_NAME_INVALID.",
 	checkEnumZeroValuesInvalid,
 )
 
-func checkEnumZeroValuesInvalid(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
-	return runVisitor(&enumZeroValuesInvalidVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkEnumZeroValuesInvalid(add func(*text.Failure, ...*TextEdit), dirPath string, descriptors []*FileDescriptor) error {
+	return runVisitor(&enumZeroValuesInvalidVisitor{baseAddFixVisitor: newBaseAddFixVisitor(add)}, descriptors)
 }
 
 type enumZeroValuesInvalidVisitor struct {
-	baseAddVisitor
+	*baseAddFixVisitor
 
 	nestedNames []string
 }
@@ -64,7 +64,7 @@ func (v *enumZeroValuesInvalidVisitor) VisitEnumField(enumField *proto.EnumField
 	if enumField.Integer == 0 {
 		expectedName := strings.Join(v.nestedNames, "_") + "_INVALID"
 		if enumField.Name != expectedName {
-			v.AddFailuref(enumField.Position, "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
+			v.AddFixableFailuref(newEnumFieldRenameTextEdit(v.fileDescriptor, enumField, expectedName), enumField.Position, "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
 		}
 	}
 }
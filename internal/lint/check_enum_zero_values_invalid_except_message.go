@@ -26,18 +26,18 @@ import (
 	"github.com/uber/prototool/internal/text"
 )
 
-var enumZeroValuesInvalidExceptMessageLinter = NewLinter(
+var enumZeroValuesInvalidExceptMessageLinter = NewFixableLinter(
 	"ENUM_ZERO_VALUES_INVALID_EXCEPT_MESSAGE",
 	"Verifies that all enum zero value names are ENUM_NAME_INVALID.",
 	checkEnumZeroValuesInvalidExceptMessage,
 )
 
-func checkEnumZeroValuesInvalidExceptMessage(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
-	return runVisitor(&enumZeroValuesInvalidExceptMessageVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkEnumZeroValuesInvalidExceptMessage(add func(*text.Failure, ...*TextEdit), dirPath string, descriptors []*FileDescriptor) error {
+	return runVisitor(&enumZeroValuesInvalidExceptMessageVisitor{baseAddFixVisitor: newBaseAddFixVisitor(add)}, descriptors)
 }
 
 type enumZeroValuesInvalidExceptMessageVisitor struct {
-	baseAddVisitor
+	*baseAddFixVisitor
 }
 
 func (v *enumZeroValuesInvalidExceptMessageVisitor) VisitMessage(message *proto.Message) {
@@ -64,7 +64,7 @@ func (v *enumZeroValuesInvalidExceptMessageVisitor) VisitEnumField(enumField *pr
 		}
 		expectedName := strs.ToUpperSnakeCase(enum.Name) + "_INVALID"
 		if enumField.Name != expectedName {
-			v.AddFailuref(enumField.Position, "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
+			v.AddFixableFailuref(newEnumFieldRenameTextEdit(v.fileDescriptor, enumField, expectedName), enumField.Position, "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
 		}
 	}
 }
@@ -21,82 +21,99 @@
 package lint
 
 import (
+	"fmt"
+	"strings"
 	"text/scanner"
 
 	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/protostrs"
+	"github.com/uber/prototool/internal/settings"
 	"github.com/uber/prototool/internal/text"
 )
 
-var fileOptionsRequireCSharpNamespaceLinter = NewLinter(
+var fileOptionsRequireCSharpNamespaceLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_CSHARP_NAMESPACE",
 	`Verifies that the file option "csharp_namespace" is set.`,
-	newCheckFileOptionsRequire("csharp_namespace"),
+	newCheckFileOptionsRequire("csharp_namespace", newCSharpNamespaceFileOptionValue),
 )
 
-var fileOptionsRequireGoPackageLinter = NewLinter(
+var fileOptionsRequireGoPackageLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_GO_PACKAGE",
 	`Verifies that the file option "go_package" is set.`,
-	newCheckFileOptionsRequire("go_package"),
+	newCheckFileOptionsRequire("go_package", newGoPackageFileOptionValue),
 )
 
-var fileOptionsRequireJavaMultipleFilesLinter = NewLinter(
+var fileOptionsRequireJavaMultipleFilesLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_JAVA_MULTIPLE_FILES",
 	`Verifies that the file option "java_multiple_files" is set.`,
-	newCheckFileOptionsRequire("java_multiple_files"),
+	newCheckFileOptionsRequire("java_multiple_files", newJavaMultipleFilesFileOptionValue),
 )
 
-var fileOptionsRequireJavaOuterClassnameLinter = NewLinter(
+var fileOptionsRequireJavaOuterClassnameLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_JAVA_OUTER_CLASSNAME",
 	`Verifies that the file option "java_outer_classname" is set.`,
-	newCheckFileOptionsRequire("java_outer_classname"),
+	newCheckFileOptionsRequire("java_outer_classname", newJavaOuterClassnameFileOptionValue),
 )
 
-var fileOptionsRequireJavaPackageLinter = NewLinter(
+var fileOptionsRequireJavaPackageLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_JAVA_PACKAGE",
 	`Verifies that the file option "java_package" is set.`,
-	newCheckFileOptionsRequire("java_package"),
+	newCheckFileOptionsRequire("java_package", newJavaPackageFileOptionValue),
 )
 
-var fileOptionsRequireOBJCClassPrefixLinter = NewLinter(
+var fileOptionsRequireOBJCClassPrefixLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_OBJC_CLASS_PREFIX",
 	`Verifies that the file option "objc_class_prefix" is set.`,
-	newCheckFileOptionsRequire("objc_class_prefix"),
+	newCheckFileOptionsRequire("objc_class_prefix", newOBJCClassPrefixFileOptionValue),
 )
 
-var fileOptionsRequirePHPNamespaceLinter = NewLinter(
+var fileOptionsRequirePHPNamespaceLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_PHP_NAMESPACE",
 	`Verifies that the file option "php_namespace" is set.`,
-	newCheckFileOptionsRequire("php_namespace"),
+	newCheckFileOptionsRequire("php_namespace", newPHPNamespaceFileOptionValue),
 )
 
-var fileOptionsRequireRubyPackageLinter = NewLinter(
+var fileOptionsRequireRubyPackageLinter = NewFixableLinter(
 	"FILE_OPTIONS_REQUIRE_RUBY_PACKAGE",
 	`Verifies that the file option "ruby_package" is set.`,
-	newCheckFileOptionsRequire("ruby_package"),
+	newCheckFileOptionsRequire("ruby_package", nil),
 )
 
-func newCheckFileOptionsRequire(fileOption string) func(func(*text.Failure), string, []*FileDescriptor) error {
-	return func(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
+// newCheckFileOptionsRequire returns a check that the file option is set.
+//
+// If newValue is not nil, failures are fixed by adding the file option with
+// the value returned by newValue after the package declaration. newValue
+// returns the empty string if the value cannot be determined.
+func newCheckFileOptionsRequire(fileOption string, newValue func(*FileDescriptor, string) string) func(func(*text.Failure, ...*TextEdit), string, []*FileDescriptor) error {
+	return func(add func(*text.Failure, ...*TextEdit), dirPath string, descriptors []*FileDescriptor) error {
 		return runVisitor(&fileOptionsRequireVisitor{
-			baseAddVisitor: newBaseAddVisitor(add),
-			fileOption:     fileOption,
+			baseAddFixVisitor: newBaseAddFixVisitor(add),
+			fileOption:        fileOption,
+			newValue:          newValue,
 		}, descriptors)
 	}
 }
 
 type fileOptionsRequireVisitor struct {
-	baseAddVisitor
+	*baseAddFixVisitor
 
 	fileOption string
+	newValue   func(*FileDescriptor, string) string
 
 	filename string
+	pkg      *proto.Package
 	seen     bool
 }
 
 func (v *fileOptionsRequireVisitor) OnStart(descriptor *FileDescriptor) error {
 	v.filename = descriptor.Filename
+	v.pkg = nil
 	v.seen = false
-	return nil
+	return v.baseAddFixVisitor.OnStart(descriptor)
+}
+
+func (v *fileOptionsRequireVisitor) VisitPackage(element *proto.Package) {
+	v.pkg = element
 }
 
 func (v *fileOptionsRequireVisitor) VisitOption(element *proto.Option) {
@@ -108,7 +125,87 @@ func (v *fileOptionsRequireVisitor) VisitOption(element *proto.Option) {
 
 func (v *fileOptionsRequireVisitor) Finally() error {
 	if !v.seen {
-		v.AddFailuref(scanner.Position{Filename: v.filename}, "File option %q is required.", v.fileOption)
+		v.AddFixableFailuref(v.newTextEdit(), scanner.Position{Filename: v.filename}, "File option %q is required.", v.fileOption)
 	}
 	return nil
 }
+
+// newTextEdit returns a TextEdit that adds the file option after the
+// package declaration, or nil if the file option cannot be added.
+func (v *fileOptionsRequireVisitor) newTextEdit() *TextEdit {
+	if v.newValue == nil || v.pkg == nil {
+		return nil
+	}
+	value := v.newValue(v.fileDescriptor, v.pkg.Name)
+	if value == "" {
+		return nil
+	}
+	end := strings.IndexRune(v.fileDescriptor.FileData[v.pkg.Position.Offset:], ';')
+	if end < 0 {
+		return nil
+	}
+	offset := v.pkg.Position.Offset + end + 1
+	return &TextEdit{
+		Filename: v.fileDescriptor.Filename,
+		Start:    offset,
+		End:      offset,
+		NewText:  fmt.Sprintf("\noption %s = %s;", v.fileOption, value),
+	}
+}
+
+func newCSharpNamespaceFileOptionValue(descriptor *FileDescriptor, packageName string) string {
+	return quoteFileOptionValue(protostrs.CSharpNamespace(packageName))
+}
+
+func newGoPackageFileOptionValue(descriptor *FileDescriptor, packageName string) string {
+	if usesGoPackageV2(descriptor.ProtoSet.Config.Lint) {
+		return quoteFileOptionValue(protostrs.GoPackageV2(packageName))
+	}
+	return quoteFileOptionValue(protostrs.GoPackage(packageName))
+}
+
+func newJavaMultipleFilesFileOptionValue(descriptor *FileDescriptor, packageName string) string {
+	return "true"
+}
+
+func newJavaOuterClassnameFileOptionValue(descriptor *FileDescriptor, packageName string) string {
+	return quoteFileOptionValue(protostrs.JavaOuterClassname(descriptor.Filename))
+}
+
+func newJavaPackageFileOptionValue(descriptor *FileDescriptor, packageName string) string {
+	return quoteFileOptionValue(protostrs.JavaPackagePrefixOverride(packageName, descriptor.ProtoSet.Config.Lint.JavaPackagePrefix))
+}
+
+func newOBJCClassPrefixFileOptionValue(descriptor *FileDescriptor, packageName string) string {
+	return quoteFileOptionValue(protostrs.OBJCClassPrefix(packageName))
+}
+
+func newPHPNamespaceFileOptionValue(descriptor *FileDescriptor, packageName string) string {
+	return quoteFileOptionValue(protostrs.PHPNamespace(packageName))
+}
+
+func quoteFileOptionValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return `"` + value + `"`
+}
+
+// usesGoPackageV2 returns true if the go_package file option is expected to
+// have the v2 form, that is if FILE_OPTIONS_EQUAL_GO_PACKAGE_V2_SUFFIX is
+// configured. This does not use GetLinters, as this would be an
+// initialization cycle.
+func usesGoPackageV2(config settings.LintConfig) bool {
+	id := fileOptionsEqualGoPackageV2SuffixLinter.ID()
+	for _, excludeID := range config.ExcludeIDs {
+		if excludeID == id {
+			return false
+		}
+	}
+	for _, includeID := range config.IncludeIDs {
+		if includeID == id {
+			return true
+		}
+	}
+	return config.Group == "uber2"
+}
@@ -22,50 +22,99 @@ package lint
 
 import (
 	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/protostrs"
 	"github.com/uber/prototool/internal/strs"
 	"github.com/uber/prototool/internal/text"
 )
 
-var messageFieldNamesLowerSnakeCaseLinter = NewLinter(
+var messageFieldNamesLowerSnakeCaseLinter = NewFixableLinter(
 	"MESSAGE_FIELD_NAMES_LOWER_SNAKE_CASE",
 	"Verifies that all message field names are lower_snake_case.",
 	checkMessageFieldNamesLowerSnakeCase,
 )
 
-func checkMessageFieldNamesLowerSnakeCase(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
-	return runVisitor(messageFieldNamesLowerSnakeCaseVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkMessageFieldNamesLowerSnakeCase(add func(*text.Failure, ...*TextEdit), dirPath string, descriptors []*FileDescriptor) error {
+	return runVisitor(&messageFieldNamesLowerSnakeCaseVisitor{baseAddFixVisitor: newBaseAddFixVisitor(add)}, descriptors)
 }
 
 type messageFieldNamesLowerSnakeCaseVisitor struct {
-	baseAddVisitor
+	*baseAddFixVisitor
+
+	// the field names of the message currently being visited
+	fieldNames map[string]struct{}
 }
 
-func (v messageFieldNamesLowerSnakeCaseVisitor) VisitMessage(message *proto.Message) {
+func (v *messageFieldNamesLowerSnakeCaseVisitor) VisitMessage(message *proto.Message) {
+	parentFieldNames := v.fieldNames
+	v.fieldNames = getMessageFieldNames(message)
 	for _, element := range message.Elements {
 		element.Accept(v)
 	}
+	v.fieldNames = parentFieldNames
 }
 
-func (v messageFieldNamesLowerSnakeCaseVisitor) VisitOneof(oneof *proto.Oneof) {
+func (v *messageFieldNamesLowerSnakeCaseVisitor) VisitOneof(oneof *proto.Oneof) {
 	for _, element := range oneof.Elements {
 		element.Accept(v)
 	}
 }
 
-func (v messageFieldNamesLowerSnakeCaseVisitor) VisitNormalField(field *proto.NormalField) {
+func (v *messageFieldNamesLowerSnakeCaseVisitor) VisitNormalField(field *proto.NormalField) {
+	v.checkField(field.Field)
+}
+
+func (v *messageFieldNamesLowerSnakeCaseVisitor) VisitOneofField(field *proto.OneOfField) {
+	v.checkField(field.Field)
+}
+
+func (v *messageFieldNamesLowerSnakeCaseVisitor) VisitMapField(field *proto.MapField) {
+	v.checkField(field.Field)
+}
+
+func (v *messageFieldNamesLowerSnakeCaseVisitor) checkField(field *proto.Field) {
 	if !strs.IsLowerSnakeCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower_snake_case.", field.Name)
+		v.AddFixableFailuref(v.newTextEdit(field), field.Position, "Field name %q must be lower_snake_case.", field.Name)
 	}
 }
 
-func (v messageFieldNamesLowerSnakeCaseVisitor) VisitOneofField(field *proto.OneOfField) {
-	if !strs.IsLowerSnakeCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower_snake_case.", field.Name)
+// newTextEdit returns a TextEdit that renames the field to lower_snake_case,
+// or nil if the rename would change the JSON name of the field or collide
+// with another field of the message.
+func (v *messageFieldNamesLowerSnakeCaseVisitor) newTextEdit(field *proto.Field) *TextEdit {
+	newName := strs.ToLowerSnakeCase(field.Name)
+	if _, ok := v.fieldNames[newName]; ok {
+		return nil
+	}
+	if protostrs.JSONName(field.Name) != protostrs.JSONName(newName) && !hasFieldOption(field, "json_name") {
+		return nil
 	}
+	return newFieldRenameTextEdit(v.fileDescriptor, field.Position.Offset, field.Name, newName)
 }
 
-func (v messageFieldNamesLowerSnakeCaseVisitor) VisitMapField(field *proto.MapField) {
-	if !strs.IsLowerSnakeCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower_snake_case.", field.Name)
+func getMessageFieldNames(message *proto.Message) map[string]struct{} {
+	fieldNames := make(map[string]struct{})
+	for _, element := range message.Elements {
+		switch element := element.(type) {
+		case *proto.NormalField:
+			fieldNames[element.Name] = struct{}{}
+		case *proto.MapField:
+			fieldNames[element.Name] = struct{}{}
+		case *proto.Oneof:
+			for _, oneofElement := range element.Elements {
+				if field, ok := oneofElement.(*proto.OneOfField); ok {
+					fieldNames[field.Name] = struct{}{}
+				}
+			}
+		}
+	}
+	return fieldNames
+}
+
+func hasFieldOption(field *proto.Field, name string) bool {
+	for _, option := range field.Options {
+		if option.Name == name {
+			return true
+		}
 	}
+	return false
 }
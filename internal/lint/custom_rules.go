@@ -0,0 +1,213 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/scanner"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/settings"
+	"github.com/uber/prototool/internal/text"
+)
+
+// GetCustomLinters returns the Linters for the custom rules and
+// plugins in the LintConfig.
+//
+// Custom rule and plugin IDs must not be the IDs of built-in Linters.
+func GetCustomLinters(config settings.LintConfig) ([]Linter, error) {
+	linters := make([]Linter, 0, len(config.CustomRules)+len(config.Plugins))
+	for _, customRule := range config.CustomRules {
+		if _, ok := allLintIDs[customRule.ID]; ok {
+			return nil, fmt.Errorf("custom lint rule %s has the same id as a built-in linter", customRule.ID)
+		}
+		linter, err := newCustomLinter(customRule)
+		if err != nil {
+			return nil, err
+		}
+		linters = append(linters, linter)
+	}
+	for _, plugin := range config.Plugins {
+		if _, ok := allLintIDs[plugin.ID]; ok {
+			return nil, fmt.Errorf("lint plugin %s has the same id as a built-in linter", plugin.ID)
+		}
+		linters = append(linters, newPluginLinter(plugin))
+	}
+	return linters, nil
+}
+
+func newCustomLinter(customRule settings.LintCustomRule) (Linter, error) {
+	purpose := customRule.Purpose
+	var newVisitor func(func(*text.Failure)) extendedVisitor
+	switch customRule.Type {
+	case settings.LintCustomRuleTypeMessageFieldRequired:
+		if purpose == "" {
+			purpose = fmt.Sprintf("Verifies that all messages%s have a field named %q.", getMessageNameRegexpPurpose(" with names", customRule), customRule.FieldName)
+		}
+		newVisitor = func(add func(*text.Failure)) extendedVisitor {
+			return customMessageFieldRequiredVisitor{
+				baseAddVisitor: newBaseAddVisitor(add),
+				customRule:     customRule,
+			}
+		}
+	case settings.LintCustomRuleTypeFieldNameForbidden:
+		if purpose == "" {
+			purpose = fmt.Sprintf("Verifies that no field names%s match %q.", getMessageNameRegexpPurpose(" in messages with names", customRule), customRule.FieldNameRegexp.String())
+		}
+		newVisitor = func(add func(*text.Failure)) extendedVisitor {
+			return customFieldNameForbiddenVisitor{
+				baseAddVisitor: newBaseAddVisitor(add),
+				customRule:     customRule,
+			}
+		}
+	case settings.LintCustomRuleTypeFileOptionRequired:
+		if purpose == "" {
+			purpose = fmt.Sprintf("Verifies that the file option %q is set.", customRule.OptionName)
+		}
+		newVisitor = func(add func(*text.Failure)) extendedVisitor {
+			return &fileOptionsRequireVisitor{
+				baseAddFixVisitor: newBaseAddFixVisitor(func(failure *text.Failure, _ ...*TextEdit) {
+					add(failure)
+				}),
+				fileOption: customRule.OptionName,
+			}
+		}
+	default:
+		return nil, fmt.Errorf("custom lint rule %s has unknown type %v", customRule.ID, customRule.Type)
+	}
+	return NewLinter(
+		customRule.ID,
+		purpose,
+		func(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
+			if !isWithinAny(dirPath, customRule.DirPaths) {
+				return nil
+			}
+			return runVisitor(newVisitor(add), descriptors)
+		},
+	), nil
+}
+
+func getMessageNameRegexpPurpose(prefix string, customRule settings.LintCustomRule) string {
+	if customRule.MessageNameRegexp == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s matching %q", prefix, customRule.MessageNameRegexp.String())
+}
+
+// isWithinAny returns true if dirPaths is empty, or if dirPath
+// is equal to or within any of dirPaths.
+func isWithinAny(dirPath string, dirPaths []string) bool {
+	if len(dirPaths) == 0 {
+		return true
+	}
+	for _, candidate := range dirPaths {
+		if dirPath == candidate || strings.HasPrefix(dirPath, candidate+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesMessageName(customRule settings.LintCustomRule, message *proto.Message) bool {
+	return customRule.MessageNameRegexp == nil || customRule.MessageNameRegexp.MatchString(message.Name)
+}
+
+type customMessageFieldRequiredVisitor struct {
+	baseAddVisitor
+
+	customRule settings.LintCustomRule
+}
+
+func (v customMessageFieldRequiredVisitor) VisitMessage(message *proto.Message) {
+	if matchesMessageName(v.customRule, message) && !hasFieldNamed(message.Elements, v.customRule.FieldName) {
+		v.AddFailuref(message.Position, "Message %q must have a field named %q.", message.Name, v.customRule.FieldName)
+	}
+	// for nested messages
+	for _, element := range message.Elements {
+		element.Accept(v)
+	}
+}
+
+func hasFieldNamed(elements []proto.Visitee, fieldName string) bool {
+	for _, element := range elements {
+		switch field := element.(type) {
+		case *proto.NormalField:
+			if field.Name == fieldName {
+				return true
+			}
+		case *proto.MapField:
+			if field.Name == fieldName {
+				return true
+			}
+		case *proto.Oneof:
+			if hasFieldNamed(field.Elements, fieldName) {
+				return true
+			}
+		case *proto.OneOfField:
+			if field.Name == fieldName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type customFieldNameForbiddenVisitor struct {
+	baseAddVisitor
+
+	customRule settings.LintCustomRule
+	// only set when visiting the fields of a matching message
+	inMatchingMessage bool
+}
+
+func (v customFieldNameForbiddenVisitor) VisitMessage(message *proto.Message) {
+	child := v
+	child.inMatchingMessage = matchesMessageName(v.customRule, message)
+	for _, element := range message.Elements {
+		element.Accept(child)
+	}
+}
+
+func (v customFieldNameForbiddenVisitor) VisitOneof(oneof *proto.Oneof) {
+	for _, element := range oneof.Elements {
+		element.Accept(v)
+	}
+}
+
+func (v customFieldNameForbiddenVisitor) VisitNormalField(field *proto.NormalField) {
+	v.checkFieldName(field.Position, field.Name)
+}
+
+func (v customFieldNameForbiddenVisitor) VisitOneofField(field *proto.OneOfField) {
+	v.checkFieldName(field.Position, field.Name)
+}
+
+func (v customFieldNameForbiddenVisitor) VisitMapField(field *proto.MapField) {
+	v.checkFieldName(field.Position, field.Name)
+}
+
+func (v customFieldNameForbiddenVisitor) checkFieldName(position scanner.Position, fieldName string) {
+	if v.inMatchingMessage && v.customRule.FieldNameRegexp.MatchString(fieldName) {
+		v.AddFailuref(position, "Field name %q must not match %q.", fieldName, v.customRule.FieldNameRegexp.String())
+	}
+}
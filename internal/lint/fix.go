@@ -0,0 +1,192 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/text"
+)
+
+// TextEdit is an edit of the data of a file that fixes a lint failure.
+type TextEdit struct {
+	// Filename is the name of the file, equal to the Filename
+	// of the FileDescriptor the edit was created for.
+	Filename string
+	// Start is the byte offset in the file data where the edit starts.
+	Start int
+	// End is the byte offset in the file data where the edit ends, exclusive.
+	// If End is equal to Start, NewText is inserted at Start.
+	End int
+	// NewText is the text to replace the range with.
+	NewText string
+}
+
+// FixableLinter is a Linter that can also fix its failures.
+type FixableLinter interface {
+	Linter
+
+	// CheckAndFix is the same as Check, but also returns the text edits
+	// that fix the failures. Not all failures need to have text edits.
+	CheckAndFix(dirPath string, descriptors []*FileDescriptor) ([]*text.Failure, []*TextEdit, error)
+}
+
+// NewFixableLinter is a convenience function that returns a new FixableLinter
+// for the given parameters, using a function to record failures along with
+// the text edits that fix them.
+//
+// The ID will be upper-cased.
+//
+// Failures returned from check do not need to set the ID, this will be overwritten.
+func NewFixableLinter(id string, purpose string, addCheck func(func(*text.Failure, ...*TextEdit), string, []*FileDescriptor) error) FixableLinter {
+	return newFixableLinter(id, purpose, addCheck)
+}
+
+// ApplyTextEdits applies the text edits to the data.
+//
+// Edits that overlap an edit that starts earlier, or an equal edit that
+// comes earlier in the slice, are dropped, as they were computed against
+// the data before the earlier edit. Insertions at the same offset are all
+// applied in the order of the slice. The filenames of the edits are not checked.
+func ApplyTextEdits(data []byte, textEdits []*TextEdit) []byte {
+	if len(textEdits) == 0 {
+		return data
+	}
+	textEdits = append([]*TextEdit{}, textEdits...)
+	sort.SliceStable(textEdits, func(i int, j int) bool { return textEdits[i].Start < textEdits[j].Start })
+	buffer := bytes.NewBuffer(nil)
+	offset := 0
+	for _, textEdit := range textEdits {
+		if textEdit.Start < offset || textEdit.End < textEdit.Start || textEdit.End > len(data) {
+			continue
+		}
+		_, _ = buffer.Write(data[offset:textEdit.Start])
+		_, _ = buffer.WriteString(textEdit.NewText)
+		offset = textEdit.End
+	}
+	_, _ = buffer.Write(data[offset:])
+	return buffer.Bytes()
+}
+
+type fixableLinter struct {
+	*baseLinter
+
+	addCheck func(func(*text.Failure, ...*TextEdit), string, []*FileDescriptor) error
+}
+
+func newFixableLinter(
+	id string,
+	purpose string,
+	addCheck func(func(*text.Failure, ...*TextEdit), string, []*FileDescriptor) error,
+) *fixableLinter {
+	return &fixableLinter{
+		baseLinter: newBaseLinter(
+			id,
+			purpose,
+			func(add func(*text.Failure), dirPath string, descriptors []*FileDescriptor) error {
+				return addCheck(
+					func(failure *text.Failure, _ ...*TextEdit) {
+						add(failure)
+					},
+					dirPath,
+					descriptors,
+				)
+			},
+		),
+		addCheck: addCheck,
+	}
+}
+
+func (c *fixableLinter) CheckAndFix(dirPath string, descriptors []*FileDescriptor) ([]*text.Failure, []*TextEdit, error) {
+	var failures []*text.Failure
+	var textEdits []*TextEdit
+	err := c.addCheck(
+		func(failure *text.Failure, failureTextEdits ...*TextEdit) {
+			failures = append(failures, failure)
+			textEdits = append(textEdits, failureTextEdits...)
+		},
+		dirPath,
+		descriptors,
+	)
+	for _, failure := range failures {
+		failure.LintID = c.id
+	}
+	return failures, textEdits, err
+}
+
+// newRenameTextEdit returns a TextEdit that renames the identifier name
+// starting at offset, or nil if name does not start at offset.
+func newRenameTextEdit(descriptor *FileDescriptor, offset int, name string, newName string) *TextEdit {
+	if offset < 0 || offset > len(descriptor.FileData) || !strings.HasPrefix(descriptor.FileData[offset:], name) {
+		return nil
+	}
+	return &TextEdit{
+		Filename: descriptor.Filename,
+		Start:    offset,
+		End:      offset + len(name),
+		NewText:  newName,
+	}
+}
+
+// newEnumFieldRenameTextEdit returns a TextEdit that renames the enum field,
+// or nil if another field of the same enum already has the new name.
+//
+// References to the enum field, for example default values, are not renamed.
+func newEnumFieldRenameTextEdit(descriptor *FileDescriptor, enumField *proto.EnumField, newName string) *TextEdit {
+	if enum, ok := enumField.Parent.(*proto.Enum); ok {
+		for _, element := range enum.Elements {
+			if other, ok := element.(*proto.EnumField); ok && other != enumField && other.Name == newName {
+				return nil
+			}
+		}
+	}
+	return newRenameTextEdit(descriptor, enumField.Position.Offset, enumField.Name, newName)
+}
+
+// newFieldRenameTextEdit returns a TextEdit that renames the field with
+// the given name declared at offset, or nil if the field name cannot be found.
+//
+// The position of fields is the start of the field declaration, so the
+// field name is the first identifier followed by "=".
+func newFieldRenameTextEdit(descriptor *FileDescriptor, offset int, name string, newName string) *TextEdit {
+	if offset < 0 || offset > len(descriptor.FileData) {
+		return nil
+	}
+	data := descriptor.FileData[offset:]
+	for start := 0; ; {
+		index := strings.Index(data[start:], name)
+		if index < 0 {
+			return nil
+		}
+		index += start
+		if (index == 0 || !isIdentByte(data[index-1])) && strings.HasPrefix(strings.TrimLeft(data[index+len(name):], " \t\n\r\f"), "=") {
+			return newRenameTextEdit(descriptor, offset+index, name, newName)
+		}
+		start = index + 1
+	}
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
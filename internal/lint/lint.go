@@ -29,7 +29,9 @@ import (
 	"unicode"
 
 	"github.com/emicklei/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/protoc"
 	"github.com/uber/prototool/internal/settings"
 	"github.com/uber/prototool/internal/text"
 	"go.uber.org/zap"
@@ -292,6 +294,9 @@ func init() {
 // Runner runs a lint job.
 type Runner interface {
 	Run(protoSet *file.ProtoSet, absolutePaths bool) ([]*text.Failure, error)
+	// Fix returns the text edits that fix the failures of the
+	// FixableLinters, keyed by the absolute path of the file.
+	Fix(protoSet *file.ProtoSet) (map[string][]*TextEdit, error)
 }
 
 // RunnerOption is an option for a new Runner.
@@ -306,6 +311,17 @@ func RunnerWithLogger(logger *zap.Logger) RunnerOption {
 	}
 }
 
+// RunnerWithCompiler returns a RunnerOption that uses the given compiler
+// to compile the files for lint plugins.
+//
+// The compiler must return FileDescriptorSets that include imports and
+// source info. This is required if the LintConfig has plugins.
+func RunnerWithCompiler(compiler protoc.Compiler) RunnerOption {
+	return func(runner *runner) {
+		runner.compiler = compiler
+	}
+}
+
 // NewRunner returns a new Runner.
 func NewRunner(options ...RunnerOption) Runner {
 	return newRunner(options...)
@@ -317,6 +333,13 @@ type FileDescriptor struct {
 
 	ProtoSet *file.ProtoSet
 	FileData string
+	// FileDescriptorSet is the compiled FileDescriptorSet for the
+	// directory of the file, including imports and source info.
+	// Only set by Runners if the LintConfig has plugins.
+	FileDescriptorSet *descriptor.FileDescriptorSet
+	// FileDescriptorProtoName is the name of the FileDescriptorProto
+	// of the file within FileDescriptorSet.
+	FileDescriptorProtoName string
 }
 
 // The below should not be needed in the CLI
@@ -377,23 +400,35 @@ func GetLinters(config settings.LintConfig) ([]Linter, error) {
 		// we ignore NoDefault if Group is set
 		linters = DefaultLinters
 	}
+	customLinters, err := GetCustomLinters(config)
+	if err != nil {
+		return nil, err
+	}
+	customLintIDs := make(map[string]struct{}, len(customLinters))
+	for _, customLinter := range customLinters {
+		customLintIDs[customLinter.ID()] = struct{}{}
+	}
 	for _, id := range config.IncludeIDs {
-		if err := checkLintID(id); err != nil {
+		if err := checkLintID(id, customLintIDs); err != nil {
 			return nil, err
 		}
 	}
 	for _, excludeID := range config.ExcludeIDs {
-		if err := checkLintID(excludeID); err != nil {
+		if err := checkLintID(excludeID, customLintIDs); err != nil {
 			return nil, err
 		}
 	}
 	for ignoreID := range config.IgnoreIDToFilePaths {
-		if err := checkLintID(ignoreID); err != nil {
+		if err := checkLintID(ignoreID, customLintIDs); err != nil {
 			return nil, err
 		}
 	}
 	if len(config.IncludeIDs) == 0 && len(config.ExcludeIDs) == 0 {
-		return linters, nil
+		if len(customLinters) == 0 {
+			return linters, nil
+		}
+		// do not append to linters directly, as it may be one of the lint groups
+		return append(append(make([]Linter, 0, len(linters)+len(customLinters)), linters...), customLinters...), nil
 	}
 
 	// Apply the configured linters to the default group.
@@ -410,6 +445,10 @@ func GetLinters(config settings.LintConfig) ([]Linter, error) {
 			}
 		}
 	}
+	// custom linters are always included unless excluded
+	for _, l := range customLinters {
+		linterMap[l.ID()] = l
+	}
 	for _, excludeID := range config.ExcludeIDs {
 		delete(linterMap, excludeID)
 	}
@@ -524,7 +563,10 @@ func shouldIgnore(linter Linter, descriptor *FileDescriptor, ignoreIDToFilePaths
 	return file.IsExcluded(filePath, descriptor.ProtoSet.Config.DirPath, ignoreFilePaths...), nil
 }
 
-func checkLintID(lintID string) error {
+func checkLintID(lintID string, customLintIDs map[string]struct{}) error {
+	if _, ok := customLintIDs[lintID]; ok {
+		return nil
+	}
 	if _, ok := allLintIDs[lintID]; !ok {
 		return fmt.Errorf("unknown lint id in configuration file: %s", lintID)
 	}
@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/emicklei/proto"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/settings"
+	"github.com/uber/prototool/internal/text"
+)
+
+func newPluginLinter(plugin settings.LintPlugin) Linter {
+	purpose := plugin.Purpose
+	if purpose == "" {
+		purpose = fmt.Sprintf("Runs the lint plugin %s.", plugin.ID)
+	}
+	return NewSuppressableLinter(
+		plugin.ID,
+		purpose,
+		plugin.SuppressableAnnotation,
+		func(add func(*file.ProtoSet, *proto.Comment, *text.Failure), dirPath string, descriptors []*FileDescriptor) error {
+			return checkPlugin(plugin, add, descriptors)
+		},
+	)
+}
+
+// checkPlugin runs the plugin with the FileDescriptorSet of the descriptors
+// on stdin and the names of the descriptors as arguments, and adds the
+// failures printed to stdout.
+func checkPlugin(plugin settings.LintPlugin, add func(*file.ProtoSet, *proto.Comment, *text.Failure), descriptors []*FileDescriptor) error {
+	if len(descriptors) == 0 {
+		return nil
+	}
+	fileDescriptorSet := descriptors[0].FileDescriptorSet
+	if fileDescriptorSet == nil {
+		return fmt.Errorf("no FileDescriptorSet for lint plugin %s, this is a system error", plugin.ID)
+	}
+	nameToDescriptor := make(map[string]*FileDescriptor, len(descriptors))
+	args := append([]string{}, plugin.Args...)
+	for _, descriptor := range descriptors {
+		nameToDescriptor[descriptor.FileDescriptorProtoName] = descriptor
+		args = append(args, descriptor.FileDescriptorProtoName)
+	}
+	data, err := protobuf.Marshal(fileDescriptorSet)
+	if err != nil {
+		return err
+	}
+	path, err := plugin.GetPath()
+	if err != nil {
+		return fmt.Errorf("could not find lint plugin %s: %v", plugin.ID, err)
+	}
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	cmd := exec.Command(path, args...)
+	cmd.Dir = descriptors[0].ProtoSet.Config.DirPath
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if errString := strings.TrimSpace(stderr.String()); errString != "" {
+			return fmt.Errorf("lint plugin %s failed: %v: %s", plugin.ID, err, errString)
+		}
+		return fmt.Errorf("lint plugin %s failed: %v", plugin.ID, err)
+	}
+	nameToLineToComment := make(map[string]map[int]*proto.Comment)
+	decoder := json.NewDecoder(stdout)
+	for {
+		failure := &text.Failure{}
+		if err := decoder.Decode(failure); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("could not parse output of lint plugin %s: %v", plugin.ID, err)
+		}
+		if failure.Filename == "" {
+			add(nil, nil, failure)
+			continue
+		}
+		descriptor, ok := nameToDescriptor[failure.Filename]
+		if !ok {
+			return fmt.Errorf("lint plugin %s returned a failure for %s which it was not asked to check", plugin.ID, failure.Filename)
+		}
+		failure.Filename = descriptor.Filename
+		lineToComment, ok := nameToLineToComment[descriptor.FileDescriptorProtoName]
+		if !ok {
+			lineToComment = getLineToComment(descriptor)
+			nameToLineToComment[descriptor.FileDescriptorProtoName] = lineToComment
+		}
+		add(descriptor.ProtoSet, lineToComment[failure.Line], failure)
+	}
+}
+
+// getLineToComment returns the map from line to the leading comment of
+// the element declared on that line, for suppressing plugin failures.
+func getLineToComment(descriptor *FileDescriptor) map[int]*proto.Comment {
+	lineToComment := make(map[int]*proto.Comment)
+	proto.Walk(
+		descriptor.Proto,
+		func(visitee proto.Visitee) {
+			var line int
+			var comment *proto.Comment
+			switch element := visitee.(type) {
+			case *proto.Message:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.NormalField:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.MapField:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.Oneof:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.OneOfField:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.Enum:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.EnumField:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.Service:
+				line, comment = element.Position.Line, element.Comment
+			case *proto.RPC:
+				line, comment = element.Position.Line, element.Comment
+			}
+			if comment != nil {
+				lineToComment[line] = comment
+			}
+		},
+	)
+	return lineToComment
+}
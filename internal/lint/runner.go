@@ -21,13 +21,20 @@
 package lint
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/protoc"
+	"github.com/uber/prototool/internal/settings"
 	"github.com/uber/prototool/internal/text"
 	"go.uber.org/zap"
 )
 
 type runner struct {
-	logger *zap.Logger
+	logger   *zap.Logger
+	compiler protoc.Compiler
 }
 
 func newRunner(options ...RunnerOption) *runner {
@@ -49,5 +56,94 @@ func (r *runner) Run(protoSet *file.ProtoSet, absolutePaths bool) ([]*text.Failu
 	if err != nil {
 		return nil, err
 	}
+	if len(protoSet.Config.Lint.Plugins) > 0 {
+		if err := r.setFileDescriptorSets(protoSet, dirPathToDescriptors); err != nil {
+			return nil, err
+		}
+	}
 	return CheckMultiple(linters, dirPathToDescriptors, protoSet.Config.Lint.IgnoreIDToFilePaths)
 }
+
+func (r *runner) Fix(protoSet *file.ProtoSet) (map[string][]*TextEdit, error) {
+	linters, err := GetLinters(protoSet.Config.Lint)
+	if err != nil {
+		return nil, err
+	}
+	var fixableLinters []FixableLinter
+	for _, linter := range linters {
+		if fixableLinter, ok := linter.(FixableLinter); ok {
+			fixableLinters = append(fixableLinters, fixableLinter)
+		}
+	}
+	if len(fixableLinters) == 0 {
+		return nil, nil
+	}
+	// the order of the linters determines which of overlapping edits win
+	sort.Slice(fixableLinters, func(i int, j int) bool { return fixableLinters[i].ID() < fixableLinters[j].ID() })
+	dirPathToDescriptors, err := GetDirPathToDescriptors(protoSet, true)
+	if err != nil {
+		return nil, err
+	}
+	filePathToTextEdits := make(map[string][]*TextEdit)
+	for dirPath, descriptors := range dirPathToDescriptors {
+		for _, fixableLinter := range fixableLinters {
+			filteredDescriptors, err := filterIgnores(fixableLinter, descriptors, protoSet.Config.Lint.IgnoreIDToFilePaths)
+			if err != nil {
+				return nil, err
+			}
+			_, textEdits, err := fixableLinter.CheckAndFix(dirPath, filteredDescriptors)
+			if err != nil {
+				return nil, err
+			}
+			for _, textEdit := range textEdits {
+				filePathToTextEdits[textEdit.Filename] = append(filePathToTextEdits[textEdit.Filename], textEdit)
+			}
+		}
+	}
+	return filePathToTextEdits, nil
+}
+
+// setFileDescriptorSets compiles the ProtoSet and sets the FileDescriptorSet
+// on all descriptors for lint plugins.
+func (r *runner) setFileDescriptorSets(protoSet *file.ProtoSet, dirPathToDescriptors map[string][]*FileDescriptor) error {
+	if r.compiler == nil {
+		return fmt.Errorf("lint plugins configured but no compiler given, this is a system error")
+	}
+	compileResult, err := r.compiler.Compile(protoSet)
+	if err != nil {
+		return err
+	}
+	if len(compileResult.Failures) > 0 {
+		// callers are expected to verify the files are compilable before running lint
+		return fmt.Errorf("could not compile files for lint plugins: %v", compileResult.Failures[0])
+	}
+	// the compiler uses the working directory if there is no config file
+	configDirPath := protoSet.Config.DirPath
+	if configDirPath == "" {
+		configDirPath = protoSet.WorkDirPath
+	}
+	dirPathToFileDescriptorSet := make(map[string]*descriptor.FileDescriptorSet, len(compileResult.FileDescriptorSets))
+	for _, fileDescriptorSet := range compileResult.FileDescriptorSets {
+		dirPathToFileDescriptorSet[fileDescriptorSet.DirPath] = fileDescriptorSet.FileDescriptorSet
+	}
+	for dirPath, descriptors := range dirPathToDescriptors {
+		fileDescriptorSet, ok := dirPathToFileDescriptorSet[dirPath]
+		if !ok {
+			return fmt.Errorf("no FileDescriptorSet for directory %s, this is a system error", dirPath)
+		}
+		protoFiles := protoSet.DirPathToFiles[dirPath]
+		if len(protoFiles) != len(descriptors) {
+			return fmt.Errorf("mismatched number of files for directory %s, this is a system error", dirPath)
+		}
+		for i, fileDescriptor := range descriptors {
+			// the descriptors are parsed in the same order as the files
+			name, ok := settings.GetFileDescriptorProtoName(protoSet.Config.Compile.IncludePaths, configDirPath, protoFiles[i].Path)
+			if !ok {
+				return fmt.Errorf("file %s is not within any include path, this is a system error", protoFiles[i].DisplayPath)
+			}
+			fileDescriptor.FileDescriptorSet = fileDescriptorSet
+			fileDescriptor.FileDescriptorProtoName = name
+		}
+	}
+	return nil
+}
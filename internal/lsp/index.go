@@ -0,0 +1,372 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// the field numbers used to build SourceCodeInfo paths, see descriptor.proto
+const (
+	fileMessageTypeTag   = 4
+	fileEnumTypeTag      = 5
+	fileServiceTag       = 6
+	fileExtensionTag     = 7
+	messageFieldTag      = 2
+	messageNestedTypeTag = 3
+	messageEnumTypeTag   = 4
+	messageExtensionTag  = 6
+	fieldExtendeeTag     = 2
+	fieldTypeNameTag     = 6
+	enumValueTag         = 2
+	serviceMethodTag     = 2
+	methodInputTypeTag   = 2
+	methodOutputTypeTag  = 3
+	nameTag              = 1
+)
+
+// span is a range within a file as in SourceCodeInfo, that is zero-based
+// with the end column exclusive.
+type span struct {
+	startLine   int
+	startColumn int
+	endLine     int
+	endColumn   int
+}
+
+// contains returns true if the position is within the span, including the end
+// position so that a cursor right after a name is on the name.
+func (s span) contains(line int, column int) bool {
+	if line < s.startLine || line > s.endLine {
+		return false
+	}
+	if line == s.startLine && column < s.startColumn {
+		return false
+	}
+	if line == s.endLine && column > s.endColumn {
+		return false
+	}
+	return true
+}
+
+func (s span) textRange() textRange {
+	return textRange{
+		Start: position{Line: s.startLine, Character: s.startColumn},
+		End:   position{Line: s.endLine, Character: s.endColumn},
+	}
+}
+
+// symbol is a definition in a file, such as a message or a field.
+type symbol struct {
+	fullName string
+	// the definition as it would be written in a file, using full names, for example
+	// "repeated foo.v1.Bar bars = 1"
+	declaration string
+	comments    string
+	filePath    string
+	// the span of the name of the definition
+	span span
+}
+
+// reference is a use of a symbol by name, such as the type of a field.
+type reference struct {
+	fullName string
+	filePath string
+	span     span
+}
+
+// index contains the symbols and references of compiled files.
+type index struct {
+	fullNameToSymbol     map[string]*symbol
+	filePathToSymbols    map[string][]*symbol
+	filePathToReferences map[string][]*reference
+	// all references, in the order they were added
+	references []*reference
+}
+
+// newIndex returns a new index for the files in the given FileDescriptorSets.
+//
+// The FileDescriptorSets must have source info. The given function returns the
+// path of the file with the given name, files with unknown paths are skipped.
+func newIndex(fileDescriptorSets []*descriptor.FileDescriptorSet, getFilePath func(string) (string, bool)) *index {
+	index := &index{
+		fullNameToSymbol:     make(map[string]*symbol),
+		filePathToSymbols:    make(map[string][]*symbol),
+		filePathToReferences: make(map[string][]*reference),
+	}
+	seen := make(map[string]struct{})
+	for _, fileDescriptorSet := range fileDescriptorSets {
+		for _, fileDescriptorProto := range fileDescriptorSet.File {
+			// the same file is in every FileDescriptorSet for a directory that imports it
+			if _, ok := seen[fileDescriptorProto.GetName()]; ok {
+				continue
+			}
+			seen[fileDescriptorProto.GetName()] = struct{}{}
+			filePath, ok := getFilePath(fileDescriptorProto.GetName())
+			if !ok {
+				continue
+			}
+			newFileIndexer(index, filePath, fileDescriptorProto).addFile(fileDescriptorProto)
+		}
+	}
+	return index
+}
+
+// getFullNameAt returns the full name of the symbol that is defined or
+// referenced at the given zero-based position.
+func (i *index) getFullNameAt(filePath string, line int, column int) (string, bool) {
+	for _, symbol := range i.filePathToSymbols[filePath] {
+		if symbol.span.contains(line, column) {
+			return symbol.fullName, true
+		}
+	}
+	for _, reference := range i.filePathToReferences[filePath] {
+		if reference.span.contains(line, column) {
+			return reference.fullName, true
+		}
+	}
+	return "", false
+}
+
+// getReferences returns the references to the symbol with the given full name.
+func (i *index) getReferences(fullName string) []*reference {
+	var references []*reference
+	for _, reference := range i.references {
+		if reference.fullName == fullName {
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
+type fileIndexer struct {
+	index          *index
+	filePath       string
+	pathToLocation map[string]*descriptor.SourceCodeInfo_Location
+}
+
+func newFileIndexer(index *index, filePath string, fileDescriptorProto *descriptor.FileDescriptorProto) *fileIndexer {
+	pathToLocation := make(map[string]*descriptor.SourceCodeInfo_Location)
+	for _, location := range fileDescriptorProto.GetSourceCodeInfo().GetLocation() {
+		key := getPathKey(location.Path)
+		// there can be multiple locations for the same path, for
+		// example for extend blocks, the first is the definition
+		if _, ok := pathToLocation[key]; !ok {
+			pathToLocation[key] = location
+		}
+	}
+	return &fileIndexer{
+		index:          index,
+		filePath:       filePath,
+		pathToLocation: pathToLocation,
+	}
+}
+
+func (f *fileIndexer) addFile(fileDescriptorProto *descriptor.FileDescriptorProto) {
+	prefix := fileDescriptorProto.GetPackage()
+	for i, message := range fileDescriptorProto.GetMessageType() {
+		f.addMessage(prefix, message, []int32{fileMessageTypeTag, int32(i)})
+	}
+	for i, enum := range fileDescriptorProto.GetEnumType() {
+		f.addEnum(prefix, enum, []int32{fileEnumTypeTag, int32(i)})
+	}
+	for i, service := range fileDescriptorProto.GetService() {
+		f.addService(prefix, service, []int32{fileServiceTag, int32(i)})
+	}
+	for i, extension := range fileDescriptorProto.GetExtension() {
+		f.addField(prefix, extension, []int32{fileExtensionTag, int32(i)})
+	}
+}
+
+func (f *fileIndexer) addMessage(prefix string, message *descriptor.DescriptorProto, path []int32) {
+	fullName := getFullName(prefix, message.GetName())
+	f.addSymbol(fullName, "message "+fullName, path)
+	for i, field := range message.GetField() {
+		f.addField(fullName, field, appendPath(path, messageFieldTag, int32(i)))
+	}
+	for i, nestedMessage := range message.GetNestedType() {
+		f.addMessage(fullName, nestedMessage, appendPath(path, messageNestedTypeTag, int32(i)))
+	}
+	for i, enum := range message.GetEnumType() {
+		f.addEnum(fullName, enum, appendPath(path, messageEnumTypeTag, int32(i)))
+	}
+	for i, extension := range message.GetExtension() {
+		f.addField(fullName, extension, appendPath(path, messageExtensionTag, int32(i)))
+	}
+}
+
+func (f *fileIndexer) addField(prefix string, field *descriptor.FieldDescriptorProto, path []int32) {
+	fullName := getFullName(prefix, field.GetName())
+	declaration := fmt.Sprintf("%s %s = %d", getFieldTypeName(field), fullName, field.GetNumber())
+	if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		declaration = "repeated " + declaration
+	}
+	f.addSymbol(fullName, declaration, path)
+	if field.GetTypeName() != "" {
+		f.addReference(field.GetTypeName(), appendPath(path, fieldTypeNameTag))
+	}
+	if field.GetExtendee() != "" {
+		f.addReference(field.GetExtendee(), appendPath(path, fieldExtendeeTag))
+	}
+}
+
+func (f *fileIndexer) addEnum(prefix string, enum *descriptor.EnumDescriptorProto, path []int32) {
+	fullName := getFullName(prefix, enum.GetName())
+	f.addSymbol(fullName, "enum "+fullName, path)
+	// enum values are scoped to the parent of the enum, not the enum
+	for i, value := range enum.GetValue() {
+		valueFullName := getFullName(prefix, value.GetName())
+		f.addSymbol(valueFullName, fmt.Sprintf("%s = %d", valueFullName, value.GetNumber()), appendPath(path, enumValueTag, int32(i)))
+	}
+}
+
+func (f *fileIndexer) addService(prefix string, service *descriptor.ServiceDescriptorProto, path []int32) {
+	fullName := getFullName(prefix, service.GetName())
+	f.addSymbol(fullName, "service "+fullName, path)
+	for i, method := range service.GetMethod() {
+		methodPath := appendPath(path, serviceMethodTag, int32(i))
+		methodFullName := getFullName(fullName, method.GetName())
+		f.addSymbol(
+			methodFullName,
+			fmt.Sprintf(
+				"rpc %s(%s%s) returns (%s%s)",
+				methodFullName,
+				getStreamPrefix(method.GetClientStreaming()),
+				strings.TrimPrefix(method.GetInputType(), "."),
+				getStreamPrefix(method.GetServerStreaming()),
+				strings.TrimPrefix(method.GetOutputType(), "."),
+			),
+			methodPath,
+		)
+		f.addReference(method.GetInputType(), appendPath(methodPath, methodInputTypeTag))
+		f.addReference(method.GetOutputType(), appendPath(methodPath, methodOutputTypeTag))
+	}
+}
+
+// addSymbol adds the symbol defined at the given path, if the path has a location.
+func (f *fileIndexer) addSymbol(fullName string, declaration string, path []int32) {
+	nameSpan, ok := f.getSpan(appendPath(path, nameTag))
+	if !ok {
+		// generated definitions such as map entries have no location
+		return
+	}
+	symbol := &symbol{
+		fullName:    fullName,
+		declaration: declaration,
+		filePath:    f.filePath,
+		span:        nameSpan,
+	}
+	if location, ok := f.pathToLocation[getPathKey(path)]; ok {
+		symbol.comments = getComments(location.GetLeadingComments())
+	}
+	f.index.fullNameToSymbol[fullName] = symbol
+	f.index.filePathToSymbols[f.filePath] = append(f.index.filePathToSymbols[f.filePath], symbol)
+}
+
+// addReference adds a reference to the type name at the given path, if the path has a location.
+func (f *fileIndexer) addReference(typeName string, path []int32) {
+	typeNameSpan, ok := f.getSpan(path)
+	if !ok {
+		return
+	}
+	reference := &reference{
+		// type names are fully-qualified once compiled
+		fullName: strings.TrimPrefix(typeName, "."),
+		filePath: f.filePath,
+		span:     typeNameSpan,
+	}
+	f.index.filePathToReferences[f.filePath] = append(f.index.filePathToReferences[f.filePath], reference)
+	f.index.references = append(f.index.references, reference)
+}
+
+func (f *fileIndexer) getSpan(path []int32) (span, bool) {
+	location, ok := f.pathToLocation[getPathKey(path)]
+	if !ok {
+		return span{}, false
+	}
+	switch len(location.Span) {
+	case 3:
+		return span{
+			startLine:   int(location.Span[0]),
+			startColumn: int(location.Span[1]),
+			endLine:     int(location.Span[0]),
+			endColumn:   int(location.Span[2]),
+		}, true
+	case 4:
+		return span{
+			startLine:   int(location.Span[0]),
+			startColumn: int(location.Span[1]),
+			endLine:     int(location.Span[2]),
+			endColumn:   int(location.Span[3]),
+		}, true
+	default:
+		return span{}, false
+	}
+}
+
+func getFieldTypeName(field *descriptor.FieldDescriptorProto) string {
+	if field.GetTypeName() != "" {
+		return strings.TrimPrefix(field.GetTypeName(), ".")
+	}
+	return strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+}
+
+func getStreamPrefix(streaming bool) string {
+	if streaming {
+		return "stream "
+	}
+	return ""
+}
+
+func getFullName(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// getComments removes the space that protoc leaves after the comment
+// markers from every line of the comments.
+func getComments(comments string) string {
+	lines := strings.Split(strings.TrimRight(comments, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func getPathKey(path []int32) string {
+	elems := make([]string, len(path))
+	for i, elem := range path {
+		elems[i] = fmt.Sprintf("%d", elem)
+	}
+	return strings.Join(elems, ".")
+}
+
+// appendPath returns a new path, so that paths are never shared.
+func appendPath(path []int32, elems ...int32) []int32 {
+	newPath := make([]int32, 0, len(path)+len(elems))
+	newPath = append(newPath, path...)
+	return append(newPath, elems...)
+}
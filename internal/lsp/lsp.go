@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package lsp implements a Language Server Protocol server for Protobuf files.
+//
+// See https://microsoft.github.io/language-server-protocol for the specification.
+package lsp
+
+import (
+	"io"
+
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/format"
+	"github.com/uber/prototool/internal/lint"
+	"github.com/uber/prototool/internal/protoc"
+	"go.uber.org/zap"
+)
+
+// Server is a Language Server Protocol server.
+//
+// The server supports diagnostics from compiling and linting, formatting,
+// go-to-definition, hover and find-references. Diagnostics are published
+// when a file is opened or saved, and are computed from the files on disk.
+type Server interface {
+	// Serve reads JSON-RPC messages from the reader and writes responses to
+	// the writer until the exit notification is received or the reader is closed.
+	Serve(reader io.Reader, writer io.Writer) error
+}
+
+// ServerOption is an option for a new Server.
+type ServerOption func(*server)
+
+// ServerWithLogger returns a ServerOption that uses the given logger.
+//
+// The default is to use zap.NewNop().
+func ServerWithLogger(logger *zap.Logger) ServerOption {
+	return func(server *server) {
+		server.logger = logger
+	}
+}
+
+// NewServer returns a new Server.
+//
+// The compiler must return FileDescriptorSets with imports and source info, that
+// is it must be created with protoc.CompilerWithFileDescriptorSetFullControl(true, true).
+// The workDirPath is used if the client does not send a root URI.
+func NewServer(
+	workDirPath string,
+	protoSetProvider file.ProtoSetProvider,
+	compiler protoc.Compiler,
+	lintRunner lint.Runner,
+	transformer format.Transformer,
+	options ...ServerOption,
+) Server {
+	return newServer(workDirPath, protoSetProvider, compiler, lintRunner, transformer, options...)
+}
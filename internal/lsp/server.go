@@ -0,0 +1,557 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/format"
+	"github.com/uber/prototool/internal/lint"
+	"github.com/uber/prototool/internal/protoc"
+	"github.com/uber/prototool/internal/text"
+	"go.uber.org/zap"
+)
+
+const diagnosticSource = "prototool"
+
+type server struct {
+	logger           *zap.Logger
+	workDirPath      string
+	protoSetProvider file.ProtoSetProvider
+	compiler         protoc.Compiler
+	lintRunner       lint.Runner
+	transformer      format.Transformer
+
+	conn *conn
+	// the contents of the open files
+	uriToText map[string]string
+	// the URIs of the open files, so that we use the same URIs as the client
+	filePathToURI map[string]string
+	// the index of each ProtoSet, by the config directory
+	configDirPathToIndex map[string]*index
+	// the compile diagnostics of each ProtoSet by the config directory,
+	// and then by file path
+	configDirPathToCompileDiagnostics map[string]map[string][]diagnostic
+	// the lint diagnostics of each directory, and then by file path
+	dirPathToLintDiagnostics map[string]map[string][]diagnostic
+}
+
+func newServer(
+	workDirPath string,
+	protoSetProvider file.ProtoSetProvider,
+	compiler protoc.Compiler,
+	lintRunner lint.Runner,
+	transformer format.Transformer,
+	options ...ServerOption,
+) *server {
+	server := &server{
+		logger:                            zap.NewNop(),
+		workDirPath:                       workDirPath,
+		protoSetProvider:                  protoSetProvider,
+		compiler:                          compiler,
+		lintRunner:                        lintRunner,
+		transformer:                       transformer,
+		uriToText:                         make(map[string]string),
+		filePathToURI:                     make(map[string]string),
+		configDirPathToIndex:              make(map[string]*index),
+		configDirPathToCompileDiagnostics: make(map[string]map[string][]diagnostic),
+		dirPathToLintDiagnostics:          make(map[string]map[string][]diagnostic),
+	}
+	for _, option := range options {
+		option(server)
+	}
+	return server
+}
+
+func (s *server) Serve(reader io.Reader, writer io.Writer) error {
+	s.conn = newConn(reader, writer)
+	for {
+		data, err := s.conn.read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		message := &message{}
+		if err := json.Unmarshal(data, message); err != nil {
+			// there is no way to know the ID of the request
+			if err := s.conn.reply(nil, nil, newResponseErrorf(errorCodeParseError, "invalid message: %v", err)); err != nil {
+				return err
+			}
+			continue
+		}
+		if message.Method == "exit" {
+			return nil
+		}
+		s.logger.Debug("handling message", zap.String("method", message.Method))
+		result, responseErr := s.handle(message)
+		if message.ID == nil {
+			// notifications have no response, so we tell the user instead
+			if responseErr != nil {
+				s.showError(responseErr.Message)
+			}
+			continue
+		}
+		if err := s.conn.reply(message.ID, result, responseErr); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) handle(message *message) (interface{}, *responseError) {
+	switch message.Method {
+	case "initialize":
+		params := &initializeParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		if params.RootURI != "" {
+			rootDirPath, err := uriToFilePath(params.RootURI)
+			if err != nil {
+				return nil, newResponseErrorf(errorCodeInvalidParams, "%v", err)
+			}
+			s.workDirPath = rootDirPath
+		}
+		return &initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: textDocumentSyncOptions{
+					OpenClose: true,
+					Change:    textDocumentSyncKindFull,
+					Save:      true,
+				},
+				DocumentFormattingProvider: true,
+				DefinitionProvider:         true,
+				HoverProvider:              true,
+				ReferencesProvider:         true,
+			},
+		}, nil
+	case "initialized", "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		params := &didOpenTextDocumentParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		filePath, err := uriToFilePath(params.TextDocument.URI)
+		if err != nil {
+			return nil, newResponseErrorf(errorCodeInvalidParams, "%v", err)
+		}
+		s.uriToText[params.TextDocument.URI] = params.TextDocument.Text
+		s.filePathToURI[filePath] = params.TextDocument.URI
+		return nil, s.check(filePath)
+	case "textDocument/didChange":
+		params := &didChangeTextDocumentParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		// we only support full document sync, so the last change is the document
+		if len(params.ContentChanges) > 0 {
+			s.uriToText[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		}
+		return nil, nil
+	case "textDocument/didSave":
+		params := &didSaveTextDocumentParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		filePath, err := uriToFilePath(params.TextDocument.URI)
+		if err != nil {
+			return nil, newResponseErrorf(errorCodeInvalidParams, "%v", err)
+		}
+		return nil, s.check(filePath)
+	case "textDocument/didClose":
+		params := &didCloseTextDocumentParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		delete(s.uriToText, params.TextDocument.URI)
+		return nil, nil
+	case "textDocument/formatting":
+		params := &documentFormattingParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		return s.format(params.TextDocument.URI)
+	case "textDocument/definition":
+		params := &textDocumentPositionParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		return s.definition(params)
+	case "textDocument/hover":
+		params := &textDocumentPositionParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		return s.hover(params)
+	case "textDocument/references":
+		params := &referenceParams{}
+		if responseErr := unmarshalParams(message, params); responseErr != nil {
+			return nil, responseErr
+		}
+		return s.references(params)
+	default:
+		if message.ID == nil {
+			// clients send notifications we do not handle all the time, such
+			// as $/cancelRequest, and these can be ignored
+			return nil, nil
+		}
+		return nil, newResponseErrorf(errorCodeMethodNotFound, "method not found: %s", message.Method)
+	}
+}
+
+// check compiles the ProtoSet of the file and lints the directory of the file,
+// publishes the resulting diagnostics, and updates the index of the ProtoSet.
+//
+// This uses the files on disk, not the contents of the open files.
+func (s *server) check(filePath string) *responseError {
+	protoSet, err := s.protoSetProvider.GetForDir(s.workDirPath, filepath.Dir(filePath))
+	if err != nil {
+		return newResponseErrorf(errorCodeInternalError, "%v", err)
+	}
+	configDirPath := protoSet.Config.DirPath
+	// we compile all files of the ProtoSet so that all references can be found
+	// all files of a ProtoSet are within the config directory
+	compileProtoSet := *protoSet
+	compileProtoSet.DirPath = configDirPath
+	compileResult, err := s.compiler.Compile(&compileProtoSet)
+	if err != nil {
+		return newResponseErrorf(errorCodeInternalError, "%v", err)
+	}
+	affectedFilePaths := make(map[string]struct{})
+	for iFilePath := range s.configDirPathToCompileDiagnostics[configDirPath] {
+		affectedFilePaths[iFilePath] = struct{}{}
+	}
+	for iFilePath := range s.dirPathToLintDiagnostics[filepath.Dir(filePath)] {
+		affectedFilePaths[iFilePath] = struct{}{}
+	}
+	compileDiagnostics := s.getFilePathToDiagnostics(protoSet, filePath, diagnosticSeverityError, compileResult.Failures)
+	s.configDirPathToCompileDiagnostics[configDirPath] = compileDiagnostics
+	// lint is only run if compilation succeeds, as with the lint command
+	var lintDiagnostics map[string][]diagnostic
+	if len(compileResult.Failures) == 0 {
+		fileDescriptorSets := make([]*descriptor.FileDescriptorSet, 0, len(compileResult.FileDescriptorSets))
+		for _, fileDescriptorSet := range compileResult.FileDescriptorSets {
+			fileDescriptorSets = append(fileDescriptorSets, fileDescriptorSet.FileDescriptorSet)
+		}
+		s.configDirPathToIndex[configDirPath] = newIndex(fileDescriptorSets, newGetFilePathFunc(protoSet))
+
+		lintProtoSet := *protoSet
+		lintProtoSet.DirPathToFiles = map[string][]*file.ProtoFile{
+			protoSet.DirPath: protoSet.DirPathToFiles[protoSet.DirPath],
+		}
+		failures, err := s.lintRunner.Run(&lintProtoSet, true)
+		if err != nil {
+			return newResponseErrorf(errorCodeInternalError, "%v", err)
+		}
+		lintDiagnostics = s.getFilePathToDiagnostics(protoSet, filePath, diagnosticSeverityWarning, failures)
+	}
+	s.dirPathToLintDiagnostics[protoSet.DirPath] = lintDiagnostics
+	for iFilePath := range compileDiagnostics {
+		affectedFilePaths[iFilePath] = struct{}{}
+	}
+	for iFilePath := range lintDiagnostics {
+		affectedFilePaths[iFilePath] = struct{}{}
+	}
+	// the file that was checked always gets diagnostics, even if there are none
+	affectedFilePaths[filePath] = struct{}{}
+	for iFilePath := range affectedFilePaths {
+		if err := s.publishDiagnostics(iFilePath); err != nil {
+			return newResponseErrorf(errorCodeInternalError, "%v", err)
+		}
+	}
+	return nil
+}
+
+func (s *server) getFilePathToDiagnostics(protoSet *file.ProtoSet, defaultFilePath string, severity int, failures []*text.Failure) map[string][]diagnostic {
+	filePathToDiagnostics := make(map[string][]diagnostic)
+	for _, failure := range failures {
+		filePath := failure.Filename
+		switch {
+		case filePath == "":
+			filePath = defaultFilePath
+		case !filepath.IsAbs(filePath):
+			// display paths are relative to the working directory
+			filePath = filepath.Join(protoSet.WorkDirPath, filePath)
+		}
+		filePathToDiagnostics[filePath] = append(filePathToDiagnostics[filePath], newDiagnostic(failure, severity))
+	}
+	return filePathToDiagnostics
+}
+
+// publishDiagnostics publishes all diagnostics for the file.
+func (s *server) publishDiagnostics(filePath string) error {
+	// the diagnostics must not be null
+	diagnostics := make([]diagnostic, 0)
+	for _, filePathToDiagnostics := range s.configDirPathToCompileDiagnostics {
+		diagnostics = append(diagnostics, filePathToDiagnostics[filePath]...)
+	}
+	for _, filePathToDiagnostics := range s.dirPathToLintDiagnostics {
+		diagnostics = append(diagnostics, filePathToDiagnostics[filePath]...)
+	}
+	return s.conn.notify(
+		"textDocument/publishDiagnostics",
+		&publishDiagnosticsParams{
+			URI:         s.getURI(filePath),
+			Diagnostics: diagnostics,
+		},
+	)
+}
+
+func (s *server) format(uri string) (interface{}, *responseError) {
+	filePath, err := uriToFilePath(uri)
+	if err != nil {
+		return nil, newResponseErrorf(errorCodeInvalidParams, "%v", err)
+	}
+	data, ok := s.uriToText[uri]
+	if !ok {
+		fileData, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, newResponseErrorf(errorCodeInternalError, "%v", err)
+		}
+		data = string(fileData)
+	}
+	formattedData, failures, err := s.transformer.Transform(filePath, []byte(data))
+	if err != nil {
+		return nil, newResponseErrorf(errorCodeInternalError, "%v", err)
+	}
+	if len(failures) > 0 {
+		messages := make([]string, 0, len(failures))
+		for _, failure := range failures {
+			messages = append(messages, failure.String())
+		}
+		return nil, newResponseErrorf(errorCodeInternalError, "could not format: %s", strings.Join(messages, "\n"))
+	}
+	if string(formattedData) == data {
+		return []textEdit{}, nil
+	}
+	return []textEdit{
+		{
+			Range: textRange{
+				Start: position{},
+				End:   getEndPosition(data),
+			},
+			NewText: string(formattedData),
+		},
+	}, nil
+}
+
+func (s *server) definition(params *textDocumentPositionParams) (interface{}, *responseError) {
+	symbol, responseErr := s.getSymbolAt(params)
+	if responseErr != nil || symbol == nil {
+		return nil, responseErr
+	}
+	return s.newLocation(symbol.filePath, symbol.span), nil
+}
+
+func (s *server) hover(params *textDocumentPositionParams) (interface{}, *responseError) {
+	symbol, responseErr := s.getSymbolAt(params)
+	if responseErr != nil || symbol == nil {
+		return nil, responseErr
+	}
+	value := fmt.Sprintf("```proto\n%s\n```", symbol.declaration)
+	if symbol.comments != "" {
+		value = value + "\n\n" + symbol.comments
+	}
+	return &hover{
+		Contents: markupContent{
+			Kind:  markupKindMarkdown,
+			Value: value,
+		},
+	}, nil
+}
+
+func (s *server) references(params *referenceParams) (interface{}, *responseError) {
+	filePath, index, responseErr := s.getIndex(params.TextDocument.URI)
+	if responseErr != nil || index == nil {
+		return nil, responseErr
+	}
+	fullName, ok := index.getFullNameAt(filePath, params.Position.Line, params.Position.Character)
+	if !ok {
+		return nil, nil
+	}
+	locations := make([]*location, 0)
+	if params.Context.IncludeDeclaration {
+		if symbol, ok := index.fullNameToSymbol[fullName]; ok {
+			locations = append(locations, s.newLocation(symbol.filePath, symbol.span))
+		}
+	}
+	for _, reference := range index.getReferences(fullName) {
+		locations = append(locations, s.newLocation(reference.filePath, reference.span))
+	}
+	return locations, nil
+}
+
+// getSymbolAt returns the symbol defined or referenced at the position, or nil
+// if there is no symbol or the file has not been compiled.
+func (s *server) getSymbolAt(params *textDocumentPositionParams) (*symbol, *responseError) {
+	filePath, index, responseErr := s.getIndex(params.TextDocument.URI)
+	if responseErr != nil || index == nil {
+		return nil, responseErr
+	}
+	fullName, ok := index.getFullNameAt(filePath, params.Position.Line, params.Position.Character)
+	if !ok {
+		return nil, nil
+	}
+	return index.fullNameToSymbol[fullName], nil
+}
+
+// getIndex returns the index of the ProtoSet that contains the file, or nil
+// if the ProtoSet has not been compiled.
+func (s *server) getIndex(uri string) (string, *index, *responseError) {
+	filePath, err := uriToFilePath(uri)
+	if err != nil {
+		return "", nil, newResponseErrorf(errorCodeInvalidParams, "%v", err)
+	}
+	// ProtoSets can be nested, so the index with the deepest config directory is used
+	var foundConfigDirPath string
+	var foundIndex *index
+	for configDirPath, index := range s.configDirPathToIndex {
+		if isWithin(filePath, configDirPath) && len(configDirPath) > len(foundConfigDirPath) {
+			foundConfigDirPath = configDirPath
+			foundIndex = index
+		}
+	}
+	return filePath, foundIndex, nil
+}
+
+func (s *server) newLocation(filePath string, span span) *location {
+	return &location{
+		URI:   s.getURI(filePath),
+		Range: span.textRange(),
+	}
+}
+
+func (s *server) getURI(filePath string) string {
+	if uri, ok := s.filePathToURI[filePath]; ok {
+		return uri
+	}
+	return filePathToURI(filePath)
+}
+
+func (s *server) showError(errorMessage string) {
+	if err := s.conn.notify(
+		"window/showMessage",
+		&showMessageParams{
+			Type:    messageTypeError,
+			Message: errorMessage,
+		},
+	); err != nil {
+		s.logger.Warn("could not show message", zap.Error(err))
+	}
+}
+
+// newGetFilePathFunc returns a function that returns the path of the compiled
+// file with the given name.
+//
+// The names of compiled files are relative to the include path they were
+// found in, which are the include paths of the config and the config directory,
+// unless the file is in neither. Files that are not found, such as the
+// well-known types, are skipped.
+func newGetFilePathFunc(protoSet *file.ProtoSet) func(string) (string, bool) {
+	includePaths := append(append([]string{}, protoSet.Config.Compile.IncludePaths...), protoSet.Config.DirPath)
+	return func(name string) (string, bool) {
+		for _, includePath := range includePaths {
+			filePath := filepath.Join(includePath, filepath.FromSlash(name))
+			if fileInfo, err := os.Stat(filePath); err == nil && fileInfo.Mode().IsRegular() {
+				return filePath, true
+			}
+		}
+		for _, protoFiles := range protoSet.DirPathToFiles {
+			for _, protoFile := range protoFiles {
+				if strings.HasSuffix(filepath.ToSlash(protoFile.Path), "/"+name) {
+					return protoFile.Path, true
+				}
+			}
+		}
+		return "", false
+	}
+}
+
+func newDiagnostic(failure *text.Failure, severity int) diagnostic {
+	// failures are one-based and zero if unknown, positions are zero-based
+	position := position{}
+	if failure.Line > 0 {
+		position.Line = failure.Line - 1
+	}
+	if failure.Column > 0 {
+		position.Character = failure.Column - 1
+	}
+	return diagnostic{
+		Range: textRange{
+			Start: position,
+			End:   position,
+		},
+		Severity: severity,
+		Code:     failure.LintID,
+		Source:   diagnosticSource,
+		Message:  failure.Message,
+	}
+}
+
+// getEndPosition returns the position after the last character of the data.
+//
+// Characters are counted in UTF-16 code units per the specification.
+func getEndPosition(data string) position {
+	lines := strings.Split(data, "\n")
+	return position{
+		Line:      len(lines) - 1,
+		Character: len(utf16.Encode([]rune(lines[len(lines)-1]))),
+	}
+}
+
+func unmarshalParams(message *message, params interface{}) *responseError {
+	if err := json.Unmarshal(message.Params, params); err != nil {
+		return newResponseErrorf(errorCodeInvalidParams, "invalid params for %s: %v", message.Method, err)
+	}
+	return nil
+}
+
+func uriToFilePath(uri string) (string, error) {
+	parsedURI, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if parsedURI.Scheme != "file" {
+		return "", fmt.Errorf("only file URIs are supported but got %q", uri)
+	}
+	return filepath.Clean(filepath.FromSlash(parsedURI.Path)), nil
+}
+
+func filePathToURI(filePath string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(filePath)}).String()
+}
+
+func isWithin(filePath string, dirPath string) bool {
+	relPath, err := filepath.Rel(dirPath, filePath)
+	return err == nil && !strings.HasPrefix(relPath, "..")
+}
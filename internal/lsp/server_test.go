@@ -0,0 +1,259 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/format"
+	"github.com/uber/prototool/internal/lint"
+	"github.com/uber/prototool/internal/protoc"
+)
+
+const (
+	testConfigData = `compile:
+  backend: go
+lint:
+  rules:
+    no_default: true
+    add:
+      - MESSAGES_HAVE_COMMENTS
+`
+	testBarData = `syntax = "proto3";
+
+package foo;
+
+// Bar is a bar.
+message Bar {
+    int64 baz = 1;
+}
+`
+	testFooData = `syntax = "proto3";
+
+package foo;
+
+import "foo/bar.proto";
+
+message Foo {
+  Bar bar = 1;
+}
+`
+)
+
+func TestServer(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dirPath) }()
+	writeTestFile(t, dirPath, "prototool.yaml", testConfigData)
+	// Bar is not defined until bar.proto is written
+	writeTestFile(t, dirPath, "foo/foo.proto", testFooData)
+	fooURI := filePathToURI(filepath.Join(dirPath, "foo", "foo.proto"))
+	barURI := filePathToURI(filepath.Join(dirPath, "foo", "bar.proto"))
+
+	client := newTestClient(t)
+	defer client.close()
+
+	var initializeResult initializeResult
+	client.request(1, "initialize", &initializeParams{RootURI: filePathToURI(dirPath)}, &initializeResult)
+	assert.True(t, initializeResult.Capabilities.DefinitionProvider)
+	assert.Equal(t, textDocumentSyncKindFull, initializeResult.Capabilities.TextDocumentSync.Change)
+
+	client.notify("textDocument/didOpen", &didOpenTextDocumentParams{TextDocument: textDocumentItem{URI: fooURI, Text: testFooData}})
+	params := client.readDiagnostics()
+	assert.Equal(t, fooURI, params.URI)
+	require.NotEmpty(t, params.Diagnostics)
+	assert.Equal(t, diagnosticSeverityError, params.Diagnostics[0].Severity)
+	assert.Equal(t, diagnosticSource, params.Diagnostics[0].Source)
+
+	writeTestFile(t, dirPath, "foo/bar.proto", testBarData)
+	client.notify("textDocument/didSave", &didSaveTextDocumentParams{TextDocument: textDocumentIdentifier{URI: fooURI}})
+	// the compile failure is gone and foo.proto is missing a comment
+	params = client.readDiagnostics()
+	assert.Equal(t, fooURI, params.URI)
+	require.Len(t, params.Diagnostics, 1)
+	assert.Equal(t, diagnosticSeverityWarning, params.Diagnostics[0].Severity)
+	assert.Equal(t, "MESSAGES_HAVE_COMMENTS", params.Diagnostics[0].Code)
+	assert.Equal(t, position{Line: 6, Character: 0}, params.Diagnostics[0].Range.Start)
+
+	var definition location
+	client.request(2, "textDocument/definition", newTestPositionParams(fooURI, 7, 3), &definition)
+	assert.Equal(t, location{URI: barURI, Range: textRange{Start: position{Line: 5, Character: 8}, End: position{Line: 5, Character: 11}}}, definition)
+
+	var hover hover
+	client.request(3, "textDocument/hover", newTestPositionParams(fooURI, 7, 3), &hover)
+	assert.Equal(t, markupKindMarkdown, hover.Contents.Kind)
+	assert.Equal(t, "```proto\nmessage foo.Bar\n```\n\nBar is a bar.", hover.Contents.Value)
+
+	var references []location
+	client.request(
+		4,
+		"textDocument/references",
+		&referenceParams{
+			TextDocument: textDocumentIdentifier{URI: barURI},
+			Position:     position{Line: 5, Character: 9},
+			Context:      referenceContext{IncludeDeclaration: true},
+		},
+		&references,
+	)
+	assert.Equal(
+		t,
+		[]location{
+			{URI: barURI, Range: textRange{Start: position{Line: 5, Character: 8}, End: position{Line: 5, Character: 11}}},
+			{URI: fooURI, Range: textRange{Start: position{Line: 7, Character: 2}, End: position{Line: 7, Character: 5}}},
+		},
+		references,
+	)
+
+	var textEdits []textEdit
+	client.request(5, "textDocument/formatting", &documentFormattingParams{TextDocument: textDocumentIdentifier{URI: barURI}}, &textEdits)
+	require.Len(t, textEdits, 1)
+	assert.Equal(t, textRange{End: position{Line: 8, Character: 0}}, textEdits[0].Range)
+	assert.Contains(t, textEdits[0].NewText, "\n  int64 baz = 1;\n")
+
+	// formatting uses the open contents, not the contents on disk
+	client.notify(
+		"textDocument/didChange",
+		&didChangeTextDocumentParams{
+			TextDocument:   textDocumentIdentifier{URI: fooURI},
+			ContentChanges: []textDocumentContentChangeEvent{{Text: "syntax = \"proto3\";\n\npackage foo;\n\n\n"}},
+		},
+	)
+	client.request(6, "textDocument/formatting", &documentFormattingParams{TextDocument: textDocumentIdentifier{URI: fooURI}}, &textEdits)
+	require.Len(t, textEdits, 1)
+	assert.Equal(t, textRange{End: position{Line: 5, Character: 0}}, textEdits[0].Range)
+	assert.Equal(t, "syntax = \"proto3\";\n\npackage foo;\n", textEdits[0].NewText)
+
+	// unknown notifications are ignored, so the next message read is the response
+	client.notify("$/cancelRequest", map[string]interface{}{"id": 1})
+	client.notify("workspace/didChangeConfiguration", map[string]interface{}{"settings": struct{}{}})
+	responseErr := client.request(7, "foo/bar", struct{}{}, nil)
+	require.NotNil(t, responseErr)
+	assert.Equal(t, errorCodeMethodNotFound, responseErr.Code)
+}
+
+func TestGetEndPosition(t *testing.T) {
+	assert.Equal(t, position{}, getEndPosition(""))
+	assert.Equal(t, position{Line: 1, Character: 0}, getEndPosition("foo\n"))
+	assert.Equal(t, position{Line: 1, Character: 3}, getEndPosition("foo\nbar"))
+	// characters outside the basic multilingual plane are two UTF-16 code units
+	assert.Equal(t, position{Line: 0, Character: 3}, getEndPosition("a\U0001F600"))
+}
+
+type testClient struct {
+	t        *testing.T
+	conn     *conn
+	writer   io.Closer
+	serveErr chan error
+}
+
+func newTestClient(t *testing.T) *testClient {
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+	server := NewServer(
+		"",
+		file.NewProtoSetProvider(),
+		protoc.NewCompiler(protoc.CompilerWithFileDescriptorSetFullControl(true, true)),
+		lint.NewRunner(),
+		format.NewTransformer(),
+	)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(serverReader, serverWriter)
+		_ = serverWriter.Close()
+	}()
+	return &testClient{
+		t:        t,
+		conn:     newConn(clientReader, clientWriter),
+		writer:   clientWriter,
+		serveErr: serveErr,
+	}
+}
+
+func (c *testClient) notify(method string, params interface{}) {
+	require.NoError(c.t, c.conn.notify(method, params))
+}
+
+// request sends a request and reads the response into result, failing
+// if a notification is received before the response.
+func (c *testClient) request(id int, method string, params interface{}, result interface{}) *responseError {
+	rawID := json.RawMessage(fmt.Sprintf("%d", id))
+	require.NoError(c.t, c.conn.write(map[string]interface{}{"jsonrpc": "2.0", "id": &rawID, "method": method, "params": params}))
+	response := &struct {
+		ID     int             `json:"id"`
+		Method string          `json:"method"`
+		Result json.RawMessage `json:"result"`
+		Error  *responseError  `json:"error"`
+	}{}
+	c.read(response)
+	require.Empty(c.t, response.Method)
+	require.Equal(c.t, id, response.ID)
+	if response.Error != nil {
+		return response.Error
+	}
+	if result != nil {
+		require.NoError(c.t, json.Unmarshal(response.Result, result))
+	}
+	return nil
+}
+
+func (c *testClient) readDiagnostics() *publishDiagnosticsParams {
+	notification := &struct {
+		Method string                    `json:"method"`
+		Params *publishDiagnosticsParams `json:"params"`
+	}{}
+	c.read(notification)
+	require.Equal(c.t, "textDocument/publishDiagnostics", notification.Method)
+	return notification.Params
+}
+
+func (c *testClient) read(value interface{}) {
+	data, err := c.conn.read()
+	require.NoError(c.t, err)
+	require.NoError(c.t, json.Unmarshal(data, value))
+}
+
+func (c *testClient) close() {
+	require.NoError(c.t, c.conn.notify("exit", nil))
+	require.NoError(c.t, <-c.serveErr)
+	require.NoError(c.t, c.writer.Close())
+}
+
+func newTestPositionParams(uri string, line int, character int) *textDocumentPositionParams {
+	return &textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: uri},
+		Position:     position{Line: line, Character: character},
+	}
+}
+
+func writeTestFile(t *testing.T, dirPath string, relFilePath string, data string) {
+	filePath := filepath.Join(dirPath, relFilePath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(data), 0644))
+}
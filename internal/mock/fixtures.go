@@ -0,0 +1,241 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+)
+
+type externalFixture struct {
+	Request   interface{}           `json:"request,omitempty" yaml:"request,omitempty"`
+	Response  interface{}           `json:"response,omitempty" yaml:"response,omitempty"`
+	Responses []interface{}         `json:"responses,omitempty" yaml:"responses,omitempty"`
+	Error     *externalFixtureError `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type externalFixtureError struct {
+	Code    string `json:"code,omitempty" yaml:"code,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+type fixture struct {
+	// nil if every request matches
+	request   interface{}
+	responses []proto.Message
+	err       error
+}
+
+// matches returns true if the request, as a JSON value, matches the fixture.
+func (f *fixture) matches(request interface{}) bool {
+	return f.request == nil || jsonValueContains(request, f.request)
+}
+
+func readFixtures(filePath string, methodNameToMethodDescriptor map[string]*desc.MethodDescriptor) (map[string][]*fixture, error) {
+	ext := filepath.Ext(filePath)
+	if ext != ".json" && ext != ".yaml" {
+		return nil, fmt.Errorf("unknown fixtures file extension, must be .json or .yaml: %s", filePath)
+	}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	externalFixtures := make(map[string][]*externalFixture)
+	switch ext {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&externalFixtures); err != nil {
+			return nil, fmt.Errorf("%s: %v", filePath, err)
+		}
+	case ".yaml":
+		if err := yaml.UnmarshalStrict(data, &externalFixtures); err != nil {
+			return nil, fmt.Errorf("%s: %v", filePath, err)
+		}
+	}
+	methodNameToFixtures := make(map[string][]*fixture, len(externalFixtures))
+	for methodName, methodExternalFixtures := range externalFixtures {
+		methodDescriptor, ok := methodNameToMethodDescriptor[methodName]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown method %q, methods must be in the form package.Service/Method", filePath, methodName)
+		}
+		fixtures := make([]*fixture, len(methodExternalFixtures))
+		for i, methodExternalFixture := range methodExternalFixtures {
+			fixture, err := newFixture(methodDescriptor, methodExternalFixture)
+			if err != nil {
+				return nil, fmt.Errorf("%s: fixture %d of %s: %v", filePath, i+1, methodName, err)
+			}
+			fixtures[i] = fixture
+		}
+		methodNameToFixtures[methodName] = fixtures
+	}
+	return methodNameToFixtures, nil
+}
+
+func newFixture(methodDescriptor *desc.MethodDescriptor, externalFixture *externalFixture) (*fixture, error) {
+	if externalFixture == nil {
+		return nil, errors.New("fixture is empty")
+	}
+	fixture := &fixture{}
+	if externalFixture.Request != nil {
+		request, err := newMessageFromJSONValue(methodDescriptor.GetInputType(), externalFixture.Request)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request: %v", err)
+		}
+		// the request is normalized so that field names and values
+		// are in the same form as the requests of calls
+		requestValue, err := getJSONValue(request)
+		if err != nil {
+			return nil, err
+		}
+		fixture.request = requestValue
+	}
+	if externalFixture.Response != nil && len(externalFixture.Responses) > 0 {
+		return nil, errors.New("only one of response or responses can be set")
+	}
+	externalResponses := externalFixture.Responses
+	if externalFixture.Response != nil {
+		externalResponses = []interface{}{externalFixture.Response}
+	}
+	for _, externalResponse := range externalResponses {
+		response, err := newMessageFromJSONValue(methodDescriptor.GetOutputType(), externalResponse)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response: %v", err)
+		}
+		fixture.responses = append(fixture.responses, response)
+	}
+	if externalFixture.Error != nil {
+		fixtureStatus, err := newStatus(externalFixture.Error)
+		if err != nil {
+			return nil, err
+		}
+		fixture.err = fixtureStatus.Err()
+	}
+	if !methodDescriptor.IsServerStreaming() {
+		if len(fixture.responses) > 1 {
+			return nil, errors.New("only one response can be set for a method that is not server streaming")
+		}
+		if len(fixture.responses) == 1 && fixture.err != nil {
+			return nil, errors.New("only one of response or error can be set for a method that is not server streaming")
+		}
+		if len(fixture.responses) == 0 && fixture.err == nil {
+			return nil, errors.New("one of response or error must be set for a method that is not server streaming")
+		}
+	}
+	return fixture, nil
+}
+
+func newStatus(externalFixtureError *externalFixtureError) (*status.Status, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(externalFixtureError.Code))); err != nil {
+		return nil, fmt.Errorf("invalid error code %q, must be a code such as NOT_FOUND", externalFixtureError.Code)
+	}
+	if code == codes.OK {
+		return nil, errors.New("error code must not be OK")
+	}
+	return status.New(code, externalFixtureError.Message), nil
+}
+
+// newMessageFromJSONValue returns a new message from a value decoded from JSON or YAML.
+func newMessageFromJSONValue(messageDescriptor *desc.MessageDescriptor, value interface{}) (*dynamic.Message, error) {
+	data, err := json.Marshal(toJSONValue(value))
+	if err != nil {
+		return nil, err
+	}
+	message := dynamic.NewMessage(messageDescriptor)
+	if err := message.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// getJSONValue returns the message as a value decoded from JSON.
+func getJSONValue(message *dynamic.Message) (interface{}, error) {
+	data, err := message.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// toJSONValue converts the maps of a value decoded from YAML, which
+// have interface{} keys, to maps with string keys.
+func toJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		jsonValue := make(map[string]interface{}, len(value))
+		for key, elem := range value {
+			jsonValue[fmt.Sprint(key)] = toJSONValue(elem)
+		}
+		return jsonValue
+	case map[string]interface{}:
+		jsonValue := make(map[string]interface{}, len(value))
+		for key, elem := range value {
+			jsonValue[key] = toJSONValue(elem)
+		}
+		return jsonValue
+	case []interface{}:
+		jsonValue := make([]interface{}, len(value))
+		for i, elem := range value {
+			jsonValue[i] = toJSONValue(elem)
+		}
+		return jsonValue
+	default:
+		return value
+	}
+}
+
+// jsonValueContains returns true if every field of the expected value
+// is in the value with an equal value, recursively for objects.
+func jsonValueContains(value interface{}, expected interface{}) bool {
+	expectedObject, ok := expected.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(value, expected)
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for key, expectedElem := range expectedObject {
+		elem, ok := object[key]
+		if !ok || !jsonValueContains(elem, expectedElem) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package mock implements a mock gRPC server for the services in FileDescriptorSets.
+package mock
+
+import (
+	"net"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"go.uber.org/zap"
+)
+
+// Server is a mock gRPC server.
+//
+// Every method of every service is served. Responses are taken from the
+// fixtures of the method whose request matches the request of the call,
+// and are otherwise random messages with every field set.
+type Server interface {
+	// Serve accepts connections on the listener until Stop is called.
+	Serve(listener net.Listener) error
+	// Stop stops the server and closes all connections.
+	Stop()
+}
+
+// ServerOption is an option for a new Server.
+type ServerOption func(*server)
+
+// ServerWithLogger returns a ServerOption that uses the given logger.
+//
+// The default is to use zap.NewNop().
+func ServerWithLogger(logger *zap.Logger) ServerOption {
+	return func(server *server) {
+		server.logger = logger
+	}
+}
+
+// ServerWithFixturesFilePath returns a ServerOption that reads the fixtures
+// from the given .json or .yaml file.
+//
+// The file maps methods in the form package.Service/Method to lists of
+// fixtures. The first fixture whose request matches the request of a call
+// is used. A fixture without a request matches every request, and a fixture
+// with a request matches if every field set in its request has the same
+// value in the request of the call. As in JSON, fields set to their default
+// value are not set, so they match any value. Client streaming methods match
+// the last request.
+//
+// A fixture sets a response, a list of responses for server streaming methods,
+// or an error with a code such as NOT_FOUND and a message.
+//
+// The default is to use no fixtures.
+func ServerWithFixturesFilePath(fixturesFilePath string) ServerOption {
+	return func(server *server) {
+		server.fixturesFilePath = fixturesFilePath
+	}
+}
+
+// NewServer returns a new Server for the services in the given FileDescriptorSets.
+//
+// The FileDescriptorSets must include imports.
+func NewServer(fileDescriptorSets []*descriptor.FileDescriptorSet, options ...ServerOption) (Server, error) {
+	return newServer(fileDescriptorSets, options...)
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mock
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptesting "github.com/uber/prototool/internal/testing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+const testProto = `syntax = "proto3";
+
+package foo;
+
+import "google/protobuf/any.proto";
+import "google/protobuf/duration.proto";
+import "google/protobuf/struct.proto";
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/wrappers.proto";
+
+enum Kind {
+  KIND_INVALID = 0;
+  KIND_ONE = 1;
+  KIND_TWO = 2;
+}
+
+message Foo {
+  string name = 1;
+  int64 count = 2;
+  double ratio = 3;
+  bytes data = 4;
+  Kind kind = 5;
+  repeated string tags = 6;
+  map<string, Foo> children = 7;
+  Foo parent = 8;
+  oneof value {
+    string string_value = 9;
+    uint32 uint32_value = 10;
+    Foo foo_value = 11;
+  }
+  google.protobuf.Any any = 12;
+  google.protobuf.Duration duration = 13;
+  google.protobuf.Struct struct = 14;
+  google.protobuf.ListValue list_value = 15;
+  google.protobuf.Value value = 16;
+  google.protobuf.Timestamp timestamp = 17;
+  google.protobuf.StringValue string_wrapper = 18;
+}
+`
+
+func TestServer(t *testing.T) {
+	for _, fixturesFilePath := range []string{
+		"testdata/fixtures.json",
+		"testdata/fixtures.yaml",
+	} {
+		t.Run(fixturesFilePath, func(t *testing.T) {
+			clientConn := startTestServer(t, ServerWithFixturesFilePath(fixturesFilePath))
+			healthClient := healthpb.NewHealthClient(clientConn)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			response, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "foo"})
+			require.NoError(t, err)
+			assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+			_, err = healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "bar"})
+			assert.Equal(t, status.Error(codes.NotFound, "bar not found").Error(), err.Error())
+			// no fixture matches, so the response is random
+			_, err = healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "baz"})
+			require.NoError(t, err)
+
+			assert.Equal(
+				t,
+				[]healthpb.HealthCheckResponse_ServingStatus{
+					healthpb.HealthCheckResponse_SERVING,
+					healthpb.HealthCheckResponse_NOT_SERVING,
+				},
+				testWatch(ctx, t, healthClient, "foo"),
+			)
+			statuses := testWatch(ctx, t, healthClient, "baz")
+			assert.True(t, len(statuses) >= 1 && len(statuses) <= maxServerStreamResponses, "statuses: %v", statuses)
+
+			// the reflection service is not in the FileDescriptorSets
+			reflectionClient, err := reflectionpb.NewServerReflectionClient(clientConn).ServerReflectionInfo(ctx)
+			require.NoError(t, err)
+			_, err = reflectionClient.Recv()
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		})
+	}
+}
+
+func TestNewServerErrors(t *testing.T) {
+	fileDescriptorSets := ptesting.RequireGetMessageFileDescriptorSets(t, &healthpb.HealthCheckRequest{})
+	_, err := NewServer(nil)
+	assert.EqualError(t, err, "no services to serve")
+	_, err = NewServer(fileDescriptorSets, ServerWithFixturesFilePath("testdata/fixtures.txt"))
+	assert.EqualError(t, err, "unknown fixtures file extension, must be .json or .yaml: testdata/fixtures.txt")
+	_, err = NewServer(fileDescriptorSets, ServerWithFixturesFilePath("testdata/unknown_method.yaml"))
+	assert.EqualError(t, err, `testdata/unknown_method.yaml: unknown method "grpc.health.v1.Health/Unknown", methods must be in the form package.Service/Method`)
+}
+
+func TestNewFixture(t *testing.T) {
+	fileDescriptorSets := ptesting.RequireGetMessageFileDescriptorSets(t, &healthpb.HealthCheckRequest{})
+	methodNameToMethodDescriptor, err := getMethodNameToMethodDescriptor(fileDescriptorSets)
+	require.NoError(t, err)
+	check := methodNameToMethodDescriptor["grpc.health.v1.Health/Check"]
+	watch := methodNameToMethodDescriptor["grpc.health.v1.Health/Watch"]
+	require.NotNil(t, check)
+	require.NotNil(t, watch)
+	response := map[interface{}]interface{}{"status": "SERVING"}
+	fixtureError := &externalFixtureError{Code: "NOT_FOUND"}
+
+	testNewFixture(t, check, &externalFixture{Response: response}, "")
+	testNewFixture(t, check, &externalFixture{Error: fixtureError}, "")
+	testNewFixture(t, check, &externalFixture{}, "one of response or error must be set for a method that is not server streaming")
+	testNewFixture(t, check, &externalFixture{Response: response, Error: fixtureError}, "only one of response or error can be set for a method that is not server streaming")
+	testNewFixture(t, check, &externalFixture{Responses: []interface{}{response, response}}, "only one response can be set for a method that is not server streaming")
+	testNewFixture(t, check, &externalFixture{Response: response, Responses: []interface{}{response}}, "only one of response or responses can be set")
+	testNewFixture(t, check, &externalFixture{Error: &externalFixtureError{Code: "OK"}}, "error code must not be OK")
+	testNewFixture(t, check, &externalFixture{Error: &externalFixtureError{Code: "not_found"}}, `invalid error code "not_found", must be a code such as NOT_FOUND`)
+	testNewFixture(t, check, &externalFixture{Request: map[interface{}]interface{}{"foo": "bar"}, Response: response}, `invalid request: message type grpc.health.v1.HealthCheckRequest has no known field named foo`)
+	testNewFixture(t, check, &externalFixture{Response: map[interface{}]interface{}{"status": "FOO"}}, `invalid response: enum "grpc.health.v1.HealthCheckResponse.ServingStatus" does not have value named "FOO"`)
+	testNewFixture(t, watch, &externalFixture{}, "")
+	testNewFixture(t, watch, &externalFixture{Responses: []interface{}{response, response}, Error: fixtureError}, "")
+}
+
+func TestFixtureMatches(t *testing.T) {
+	value := map[string]interface{}{
+		"foo": "bar",
+		"baz": map[string]interface{}{
+			"one": 1.0,
+			"two": []interface{}{"a", "b"},
+		},
+	}
+	assert.True(t, (&fixture{}).matches(value))
+	assert.True(t, (&fixture{request: map[string]interface{}{}}).matches(value))
+	assert.True(t, (&fixture{request: map[string]interface{}{"foo": "bar"}}).matches(value))
+	assert.True(t, (&fixture{request: map[string]interface{}{"baz": map[string]interface{}{"one": 1.0}}}).matches(value))
+	assert.True(t, (&fixture{request: value}).matches(value))
+	assert.False(t, (&fixture{request: map[string]interface{}{"foo": "baz"}}).matches(value))
+	assert.False(t, (&fixture{request: map[string]interface{}{"bar": "baz"}}).matches(value))
+	assert.False(t, (&fixture{request: map[string]interface{}{"baz": map[string]interface{}{"two": []interface{}{"a"}}}}).matches(value))
+	assert.False(t, (&fixture{request: map[string]interface{}{"foo": map[string]interface{}{}}}).matches(value))
+}
+
+func TestMessageGenerator(t *testing.T) {
+	fileDescriptors, err := (&protoparse.Parser{
+		Accessor: protoparse.FileContentsFromMap(map[string]string{"foo.proto": testProto}),
+	}).ParseFiles("foo.proto")
+	require.NoError(t, err)
+	require.Len(t, fileDescriptors, 1)
+	messageDescriptor := fileDescriptors[0].FindMessage("foo.Foo")
+	require.NotNil(t, messageDescriptor)
+
+	messageGenerator := newMessageGenerator(1)
+	for i := 0; i < 100; i++ {
+		message := messageGenerator.newMessage(messageDescriptor)
+		// the message must be valid JSON for clients
+		_, err := message.MarshalJSON()
+		require.NoError(t, err)
+		assert.NotEmpty(t, message.GetFieldByName("name"))
+		assert.NotEmpty(t, message.GetFieldByName("tags"))
+		assert.NotEmpty(t, message.GetFieldByName("children"))
+		var numOneOfFields int
+		for _, fieldName := range []string{"string_value", "uint32_value", "foo_value"} {
+			if message.HasFieldName(fieldName) {
+				numOneOfFields++
+			}
+		}
+		assert.Equal(t, 1, numOneOfFields)
+		depth := 0
+		for parent := message; parent.HasFieldName("parent"); depth++ {
+			parent = parent.GetFieldByName("parent").(*dynamic.Message)
+		}
+		assert.Equal(t, maxMessageDepth, depth)
+	}
+}
+
+func testWatch(ctx context.Context, t *testing.T, healthClient healthpb.HealthClient, service string) []healthpb.HealthCheckResponse_ServingStatus {
+	watchClient, err := healthClient.Watch(ctx, &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	var statuses []healthpb.HealthCheckResponse_ServingStatus
+	for {
+		response, err := watchClient.Recv()
+		if err == io.EOF {
+			return statuses
+		}
+		require.NoError(t, err)
+		statuses = append(statuses, response.Status)
+	}
+}
+
+func testNewFixture(t *testing.T, methodDescriptor *desc.MethodDescriptor, externalFixture *externalFixture, expectedError string) {
+	_, err := newFixture(methodDescriptor, externalFixture)
+	if expectedError == "" {
+		assert.NoError(t, err)
+	} else {
+		assert.EqualError(t, err, expectedError)
+	}
+}
+
+func startTestServer(t *testing.T, options ...ServerOption) *grpc.ClientConn {
+	server, err := NewServer(ptesting.RequireGetMessageFileDescriptorSets(t, &healthpb.HealthCheckRequest{}), options...)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	clientConn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = clientConn.Close() })
+	return clientConn
+}
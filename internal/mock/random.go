@@ -0,0 +1,173 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mock
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+)
+
+const (
+	// maxMessageDepth is the maximum depth of nested messages in random messages.
+	// Message fields deeper than this are not set, so that messages that
+	// contain themselves are finite.
+	maxMessageDepth = 3
+	// maxRepeatedLength is the maximum number of elements of repeated
+	// and map fields in random messages.
+	maxRepeatedLength = 3
+)
+
+// messageGenerator generates random messages with every field set.
+//
+// Exactly one field of each oneof is set, and repeated and map fields have
+// at least one element. Well-known types are set to valid values, except
+// for google.protobuf.Any which is not set.
+type messageGenerator struct {
+	rand *rand.Rand
+	lock sync.Mutex
+}
+
+func newMessageGenerator(seed int64) *messageGenerator {
+	return &messageGenerator{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (g *messageGenerator) newMessage(messageDescriptor *desc.MessageDescriptor) *dynamic.Message {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.getMessage(messageDescriptor, 0)
+}
+
+func (g *messageGenerator) intn(n int) int {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.rand.Intn(n)
+}
+
+func (g *messageGenerator) getMessage(messageDescriptor *desc.MessageDescriptor, depth int) *dynamic.Message {
+	message := dynamic.NewMessage(messageDescriptor)
+	switch messageDescriptor.GetFullyQualifiedName() {
+	case "google.protobuf.ListValue", "google.protobuf.Struct":
+		return message
+	case "google.protobuf.Duration":
+		message.SetFieldByName("seconds", int64(g.rand.Intn(3600)))
+		return message
+	case "google.protobuf.Timestamp":
+		// within the past year
+		message.SetFieldByName("seconds", time.Now().Unix()-int64(g.rand.Intn(365*24*3600)))
+		return message
+	case "google.protobuf.Value":
+		message.SetFieldByName("string_value", fmt.Sprintf("value-%d", g.rand.Intn(1000)))
+		return message
+	}
+	for _, fieldDescriptor := range messageDescriptor.GetFields() {
+		if fieldDescriptor.GetOneOf() != nil {
+			continue
+		}
+		g.setField(message, fieldDescriptor, depth)
+	}
+	for _, oneOfDescriptor := range messageDescriptor.GetOneOfs() {
+		choices := oneOfDescriptor.GetChoices()
+		g.setField(message, choices[g.rand.Intn(len(choices))], depth)
+	}
+	return message
+}
+
+func (g *messageGenerator) setField(message *dynamic.Message, fieldDescriptor *desc.FieldDescriptor, depth int) {
+	length := g.rand.Intn(maxRepeatedLength) + 1
+	switch {
+	case fieldDescriptor.IsMap():
+		for i := 0; i < length; i++ {
+			value, ok := g.getValue(fieldDescriptor.GetMapValueType(), depth)
+			if !ok {
+				return
+			}
+			key, _ := g.getValue(fieldDescriptor.GetMapKeyType(), depth)
+			message.PutMapField(fieldDescriptor, key, value)
+		}
+	case fieldDescriptor.IsRepeated():
+		for i := 0; i < length; i++ {
+			value, ok := g.getValue(fieldDescriptor, depth)
+			if !ok {
+				return
+			}
+			message.AddRepeatedField(fieldDescriptor, value)
+		}
+	default:
+		if value, ok := g.getValue(fieldDescriptor, depth); ok {
+			message.SetField(fieldDescriptor, value)
+		}
+	}
+}
+
+// getValue returns a random value for a single element of the field.
+//
+// The second return value is false if the field should not be set.
+func (g *messageGenerator) getValue(fieldDescriptor *desc.FieldDescriptor, depth int) (interface{}, bool) {
+	switch fieldDescriptor.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return math.Round(g.rand.Float64()*100000) / 100, true
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return float32(math.Round(g.rand.Float64()*100000) / 100), true
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return int64(g.rand.Intn(1000)), true
+	case descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return uint64(g.rand.Intn(1000)), true
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return int32(g.rand.Intn(1000)), true
+	case descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return uint32(g.rand.Intn(1000)), true
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return g.rand.Intn(2) == 1, true
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return fmt.Sprintf("%s-%d", fieldDescriptor.GetName(), g.rand.Intn(1000)), true
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		value := make([]byte, 8)
+		_, _ = g.rand.Read(value)
+		return value, true
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		values := fieldDescriptor.GetEnumType().GetValues()
+		return values[g.rand.Intn(len(values))].GetNumber(), true
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		// an Any must have a type that can be resolved, so it is not set
+		if depth >= maxMessageDepth || fieldDescriptor.GetMessageType().GetFullyQualifiedName() == "google.protobuf.Any" {
+			return nil, false
+		}
+		return g.getMessage(fieldDescriptor.GetMessageType(), depth+1), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mock
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxServerStreamResponses is the maximum number of random responses
+// sent for a server streaming method.
+const maxServerStreamResponses = 3
+
+type server struct {
+	logger           *zap.Logger
+	fixturesFilePath string
+
+	methodNameToMethodDescriptor map[string]*desc.MethodDescriptor
+	methodNameToFixtures         map[string][]*fixture
+	messageGenerator             *messageGenerator
+	grpcServer                   *grpc.Server
+}
+
+func newServer(fileDescriptorSets []*descriptor.FileDescriptorSet, options ...ServerOption) (*server, error) {
+	server := &server{
+		logger:           zap.NewNop(),
+		messageGenerator: newMessageGenerator(time.Now().UnixNano()),
+	}
+	for _, option := range options {
+		option(server)
+	}
+	methodNameToMethodDescriptor, err := getMethodNameToMethodDescriptor(fileDescriptorSets)
+	if err != nil {
+		return nil, err
+	}
+	if len(methodNameToMethodDescriptor) == 0 {
+		return nil, errors.New("no services to serve")
+	}
+	server.methodNameToMethodDescriptor = methodNameToMethodDescriptor
+	if server.fixturesFilePath != "" {
+		methodNameToFixtures, err := readFixtures(server.fixturesFilePath, methodNameToMethodDescriptor)
+		if err != nil {
+			return nil, err
+		}
+		server.methodNameToFixtures = methodNameToFixtures
+	}
+	// all methods are unknown to the gRPC server, so every call goes through handleStream
+	server.grpcServer = grpc.NewServer(grpc.UnknownServiceHandler(server.handleStream))
+	return server, nil
+}
+
+func (s *server) Serve(listener net.Listener) error {
+	return s.grpcServer.Serve(listener)
+}
+
+func (s *server) Stop() {
+	s.grpcServer.Stop()
+}
+
+func (s *server) handleStream(_ interface{}, serverStream grpc.ServerStream) error {
+	fullMethodName, _ := grpc.MethodFromServerStream(serverStream)
+	methodName := strings.TrimPrefix(fullMethodName, "/")
+	methodDescriptor, ok := s.methodNameToMethodDescriptor[methodName]
+	if !ok {
+		s.logger.Warn("unknown method", zap.String("method", methodName))
+		return status.Errorf(codes.Unimplemented, "unknown method %s", methodName)
+	}
+	err := s.handleMethod(methodName, methodDescriptor, serverStream)
+	s.logger.Debug("handled call", zap.String("method", methodName), zap.Stringer("code", status.Code(err)))
+	return err
+}
+
+func (s *server) handleMethod(methodName string, methodDescriptor *desc.MethodDescriptor, serverStream grpc.ServerStream) error {
+	if methodDescriptor.IsClientStreaming() && methodDescriptor.IsServerStreaming() {
+		// every request is responded to as soon as it is received
+		for {
+			request, err := recvRequest(methodDescriptor, serverStream)
+			if err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			if err := s.respond(methodName, methodDescriptor, serverStream, request); err != nil {
+				return err
+			}
+		}
+	}
+	// the last request is used for client streaming methods
+	request := dynamic.NewMessage(methodDescriptor.GetInputType())
+	for {
+		nextRequest, err := recvRequest(methodDescriptor, serverStream)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		request = nextRequest
+		if !methodDescriptor.IsClientStreaming() {
+			break
+		}
+	}
+	return s.respond(methodName, methodDescriptor, serverStream, request)
+}
+
+func (s *server) respond(methodName string, methodDescriptor *desc.MethodDescriptor, serverStream grpc.ServerStream, request *dynamic.Message) error {
+	responses, err := s.getResponses(methodName, methodDescriptor, request)
+	for _, response := range responses {
+		if err := serverStream.SendMsg(response); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// getResponses returns the responses and error of the first matching fixture,
+// or random responses if no fixture matches.
+func (s *server) getResponses(methodName string, methodDescriptor *desc.MethodDescriptor, request *dynamic.Message) ([]proto.Message, error) {
+	if fixtures := s.methodNameToFixtures[methodName]; len(fixtures) > 0 {
+		requestValue, err := getJSONValue(request)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, fixture := range fixtures {
+			if fixture.matches(requestValue) {
+				return fixture.responses, fixture.err
+			}
+		}
+	}
+	numResponses := 1
+	if methodDescriptor.IsServerStreaming() && !methodDescriptor.IsClientStreaming() {
+		numResponses = s.messageGenerator.intn(maxServerStreamResponses) + 1
+	}
+	responses := make([]proto.Message, numResponses)
+	for i := range responses {
+		responses[i] = s.messageGenerator.newMessage(methodDescriptor.GetOutputType())
+	}
+	return responses, nil
+}
+
+func recvRequest(methodDescriptor *desc.MethodDescriptor, serverStream grpc.ServerStream) (*dynamic.Message, error) {
+	request := dynamic.NewMessage(methodDescriptor.GetInputType())
+	if err := serverStream.RecvMsg(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func getMethodNameToMethodDescriptor(fileDescriptorSets []*descriptor.FileDescriptorSet) (map[string]*desc.MethodDescriptor, error) {
+	methodNameToMethodDescriptor := make(map[string]*desc.MethodDescriptor)
+	for _, fileDescriptorSet := range fileDescriptorSets {
+		if len(fileDescriptorSet.File) == 0 {
+			continue
+		}
+		fileNameToFileDescriptor, err := desc.CreateFileDescriptorsFromSet(fileDescriptorSet)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileDescriptor := range fileNameToFileDescriptor {
+			for _, serviceDescriptor := range fileDescriptor.GetServices() {
+				for _, methodDescriptor := range serviceDescriptor.GetMethods() {
+					methodNameToMethodDescriptor[serviceDescriptor.GetFullyQualifiedName()+"/"+methodDescriptor.GetName()] = methodDescriptor
+				}
+			}
+		}
+	}
+	return methodNameToMethodDescriptor, nil
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protoc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/settings"
+	"github.com/uber/prototool/internal/vars"
+	"go.uber.org/zap"
+)
+
+// compileCacheVersion is part of every compile cache key, this should be
+// incremented if what is cached or how the key is computed changes
+const compileCacheVersion = "2"
+
+// compileWithCache compiles the ProtoSet, reusing the FileDescriptorSets of
+// directories that have been compiled before with the same inputs.
+//
+// Each directory is cached separately, with the imports and the source info,
+// so that any FileDescriptorSet can be returned from the cache. Only
+// directories that compile without failures are cached.
+func (c *compiler) compileWithCache(protoSet *file.ProtoSet) (*CompileResult, error) {
+	downloader, err := c.newDownloader(protoSet.Config)
+	if err != nil {
+		return nil, err
+	}
+	compileCachePath, err := downloader.CompileCachePath()
+	if err != nil {
+		return nil, err
+	}
+	var dirPaths []string
+	for dirPath := range protoSet.DirPathToFiles {
+		if c.shouldCompileDir(protoSet, dirPath) {
+			dirPaths = append(dirPaths, dirPath)
+		}
+	}
+	sort.Strings(dirPaths)
+
+	dirPathToNames := make(map[string][]string, len(dirPaths))
+	dirPathToFileDescriptorProtos := make(map[string][]*descriptor.FileDescriptorProto, len(dirPaths))
+	uncachedDirPathToCacheFilePath := make(map[string]string)
+	for _, dirPath := range dirPaths {
+		key, names, err := c.getCompileCacheKey(protoSet, dirPath)
+		if err != nil {
+			return nil, err
+		}
+		dirPathToNames[dirPath] = names
+		if key == "" {
+			c.logger.Debug("not using compile cache", zap.String("dirPath", dirPath))
+			uncachedDirPathToCacheFilePath[dirPath] = ""
+			continue
+		}
+		cacheFilePath := filepath.Join(compileCachePath, key)
+		if fileDescriptorSet := readCompileCacheFile(cacheFilePath); fileDescriptorSet != nil {
+			c.logger.Debug("using compile cache", zap.String("dirPath", dirPath), zap.String("path", cacheFilePath))
+			dirPathToFileDescriptorProtos[dirPath] = fileDescriptorSet.File
+			continue
+		}
+		uncachedDirPathToCacheFilePath[dirPath] = cacheFilePath
+	}
+
+	if len(uncachedDirPathToCacheFilePath) > 0 {
+		// compile everything that was not cached, asking for everything
+		// that could be needed from the FileDescriptorSets
+		uncachedCompiler := *c
+		uncachedCompiler.useCompileCache = false
+		uncachedCompiler.doFileDescriptorSet = true
+		uncachedCompiler.fileDescriptorSetFullControl = true
+		uncachedCompiler.fileDescriptorSetIncludeImports = true
+		uncachedCompiler.fileDescriptorSetIncludeSourceInfo = true
+		uncachedCompiler.compileDirPaths = make(map[string]struct{}, len(uncachedDirPathToCacheFilePath))
+		for dirPath := range uncachedDirPathToCacheFilePath {
+			uncachedCompiler.compileDirPaths[dirPath] = struct{}{}
+		}
+		compileResult, err := uncachedCompiler.compile(protoSet)
+		if err != nil {
+			return nil, err
+		}
+		if len(compileResult.Failures) > 0 {
+			return compileResult, nil
+		}
+		for _, fileDescriptorSet := range compileResult.FileDescriptorSets {
+			dirPathToFileDescriptorProtos[fileDescriptorSet.DirPath] = fileDescriptorSet.File
+			cacheFilePath := uncachedDirPathToCacheFilePath[fileDescriptorSet.DirPath]
+			if cacheFilePath == "" {
+				continue
+			}
+			// the cache is only an optimization, so we do not fail if we cannot write to it
+			if err := writeCompileCacheFile(cacheFilePath, fileDescriptorSet.FileDescriptorSet); err != nil {
+				c.logger.Debug("could not write to compile cache", zap.String("path", cacheFilePath), zap.Error(err))
+			}
+		}
+	}
+
+	compileResult := &CompileResult{}
+	if c.doFileDescriptorSet {
+		// see the comments in getCmdMetas
+		includeImports := !c.fileDescriptorSetFullControl || c.fileDescriptorSetIncludeImports
+		for _, dirPath := range dirPaths {
+			genRequest := &genRequest{
+				protoSet:             protoSet,
+				dirPath:              dirPath,
+				fileToGenerate:       dirPathToNames[dirPath],
+				fileDescriptorProtos: dirPathToFileDescriptorProtos[dirPath],
+			}
+			compileResult.FileDescriptorSets = append(compileResult.FileDescriptorSets, genRequest.newFileDescriptorSet(includeImports, c.fileDescriptorSetIncludeSourceInfo))
+		}
+	}
+	return compileResult, nil
+}
+
+// getCompileCacheKey returns the compile cache key for the given directory of the
+// ProtoSet, along with the names of the files in the directory.
+//
+// The key is a hash of the files in the directory, their transitive imports, the
+// protoc version and the include paths, along with any other configuration that
+// affects compilation. The key is empty if the directory should not be cached
+// because the imports of a file could not be determined.
+func (c *compiler) getCompileCacheKey(protoSet *file.ProtoSet, dirPath string) (string, []string, error) {
+	// see the comments in getCmdMetas
+	configDirPath := protoSet.Config.DirPath
+	if configDirPath == "" {
+		configDirPath = protoSet.WorkDirPath
+	}
+	// the well-known types include path is not needed, as the well-known
+	// types are determined by the protoc version or the protoc paths
+	includes, err := getIncludes(nil, protoSet.Config, dirPath, configDirPath)
+	if err != nil {
+		return "", nil, err
+	}
+	protobufVersion := protoSet.Config.Compile.ProtobufVersion
+	if protobufVersion == "" {
+		protobufVersion = vars.DefaultProtocVersion
+	}
+
+	hash := sha256.New()
+	writeCompileCacheKeyValue(hash, "version", compileCacheVersion)
+	writeCompileCacheKeyValue(hash, "prototool_version", vars.Version)
+	writeCompileCacheKeyValue(hash, "backend", protoSet.Config.Compile.Backend.String())
+	writeCompileCacheKeyValue(hash, "protobuf_version", protobufVersion)
+	writeCompileCacheKeyValue(hash, "protoc_url", c.protocURL)
+	writeCompileCacheKeyValue(hash, "protoc_bin_path", c.protocBinPath)
+	if c.protocBinPath != "" {
+		// we do not know the version of a protoc binary we did not download
+		if fileInfo, err := os.Stat(c.protocBinPath); err == nil {
+			writeCompileCacheKeyValue(hash, "protoc_bin", fmt.Sprintf("%d %d", fileInfo.Size(), fileInfo.ModTime().UnixNano()))
+		}
+	}
+	writeCompileCacheKeyValue(hash, "protoc_wkt_path", c.protocWKTPath)
+	writeCompileCacheKeyValue(hash, "include_wkt", fmt.Sprintf("%v", protoSet.Config.Compile.IncludeWellKnownTypes))
+	writeCompileCacheKeyValue(hash, "allow_unused_imports", fmt.Sprintf("%v", protoSet.Config.Compile.AllowUnusedImports))
+	for _, include := range includes {
+		writeCompileCacheKeyValue(hash, "include", include)
+	}
+
+	protoFiles := make([]*file.ProtoFile, len(protoSet.DirPathToFiles[dirPath]))
+	copy(protoFiles, protoSet.DirPathToFiles[dirPath])
+	sort.Slice(protoFiles, func(i int, j int) bool {
+		return protoFiles[i].Path < protoFiles[j].Path
+	})
+	var names []string
+	seen := make(map[string]struct{})
+	for _, protoFile := range protoFiles {
+		// see the comments in getCmdMetas
+		if name, ok := settings.GetIncludeRelPath(includes, protoFile.Path); ok {
+			names = append(names, name)
+		}
+		writeCompileCacheKeyValue(hash, "file", protoFile.Path)
+		ok, err := writeCompileCacheKeyFile(hash, includes, protoFile.Path, seen)
+		if err != nil {
+			return "", nil, err
+		}
+		if !ok {
+			return "", names, nil
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), names, nil
+}
+
+// writeCompileCacheKeyFile writes the contents of the file and all of its
+// transitive imports to the compile cache key.
+//
+// This returns false if the file or one of its imports cannot be parsed, as
+// its imports are then unknown. Compiling will fail in almost all of these
+// cases, and failures are not cached anyway.
+func writeCompileCacheKeyFile(writer io.Writer, includes []string, filePath string, seen map[string]struct{}) (bool, error) {
+	if _, ok := seen[filePath]; ok {
+		return true, nil
+	}
+	seen[filePath] = struct{}{}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	writeCompileCacheKeyValue(writer, "contents", fmt.Sprintf("%s %x", filePath, sha256.Sum256(data)))
+	importNames, ok := getImportNames(filePath, data)
+	if !ok {
+		return false, nil
+	}
+	for _, name := range importNames {
+		importFilePath, ok := findInIncludes(includes, name)
+		if !ok {
+			// this is a well-known type or a file that does not exist, both
+			// of which are covered by the name and the rest of the key
+			writeCompileCacheKeyValue(writer, "import", name)
+			continue
+		}
+		writeCompileCacheKeyValue(writer, "import", fmt.Sprintf("%s %s", name, importFilePath))
+		if ok, err := writeCompileCacheKeyFile(writer, includes, importFilePath, seen); !ok || err != nil {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+// getImportNames parses the file without linking it to get the names of
+// the files it imports, and returns false if the file cannot be parsed.
+func getImportNames(filePath string, data []byte) ([]string, bool) {
+	parser := protoparse.Parser{
+		Accessor: func(string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+	fileDescriptorProtos, err := parser.ParseFilesButDoNotLink(filePath)
+	if err != nil {
+		return nil, false
+	}
+	return fileDescriptorProtos[0].GetDependency(), true
+}
+
+func writeCompileCacheKeyValue(writer io.Writer, key string, value string) {
+	_, _ = fmt.Fprintf(writer, "%s=%q\n", key, value)
+}
+
+// findInIncludes returns the path of the imported file with the given name,
+// the same way protoc searches the include paths.
+func findInIncludes(includes []string, name string) (string, bool) {
+	for _, include := range includes {
+		filePath := filepath.Join(include, filepath.FromSlash(name))
+		if fileInfo, err := os.Stat(filePath); err == nil && fileInfo.Mode().IsRegular() {
+			return filePath, true
+		}
+	}
+	return "", false
+}
+
+// readCompileCacheFile returns nil if the file cannot be read for any reason.
+func readCompileCacheFile(filePath string) *descriptor.FileDescriptorSet {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	fileDescriptorSet := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, fileDescriptorSet); err != nil {
+		return nil
+	}
+	return fileDescriptorSet
+}
+
+// writeCompileCacheFile writes to a temporary file and then renames it so that
+// concurrent prototool invocations never read a partially written file.
+func writeCompileCacheFile(filePath string, fileDescriptorSet *descriptor.FileDescriptorSet) (retErr error) {
+	data, err := proto.Marshal(fileDescriptorSet)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			_ = os.Remove(tempFile.Name())
+		}
+	}()
+	if _, err := tempFile.Write(data); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), filePath)
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protoc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileCache(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `compile:
+  backend: go
+`,
+			"a/a.proto": `syntax = "proto3";
+
+package a;
+
+import "google/protobuf/timestamp.proto";
+
+message A {
+  google.protobuf.Timestamp time = 1;
+}
+`,
+			"b/b.proto": `syntax = "proto3";
+
+package b;
+
+import "a/a.proto";
+
+message B {
+  a.A a = 1;
+}
+`,
+		},
+	)
+	defer cleanup()
+	cachePath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(cachePath) }()
+	compileCachePath := filepath.Join(cachePath, "compile")
+
+	compileResult, err := NewCompiler(
+		CompilerWithCachePath(cachePath),
+		CompilerWithCompileCache(),
+		CompilerWithFileDescriptorSetFullControl(false, true),
+	).Compile(protoSet)
+	require.NoError(t, err)
+	require.Empty(t, compileResult.Failures)
+	require.Len(t, compileResult.FileDescriptorSets, 2)
+	assert.Equal(t, []string{"a/a.proto"}, getFileNames(compileResult.FileDescriptorSets[0]))
+	assert.Equal(t, []string{"b/b.proto"}, getFileNames(compileResult.FileDescriptorSets[1]))
+	assert.NotNil(t, compileResult.FileDescriptorSets[1].File[0].SourceCodeInfo)
+	cacheFilePaths := getCompileCacheFilePaths(t, compileCachePath)
+	require.Len(t, cacheFilePaths, 2)
+
+	// the cache has everything, so other FileDescriptorSets can be returned from it
+	compileResult, err = NewCompiler(
+		CompilerWithCachePath(cachePath),
+		CompilerWithCompileCache(),
+		CompilerWithFileDescriptorSet(),
+	).Compile(protoSet)
+	require.NoError(t, err)
+	require.Len(t, compileResult.FileDescriptorSets, 2)
+	assert.Equal(t, []string{"google/protobuf/timestamp.proto", "a/a.proto", "b/b.proto"}, getFileNames(compileResult.FileDescriptorSets[1]))
+	for _, fileDescriptorProto := range compileResult.FileDescriptorSets[1].File {
+		assert.Nil(t, fileDescriptorProto.SourceCodeInfo)
+	}
+
+	// make sure what is returned actually comes from the cache
+	for _, cacheFilePath := range cacheFilePaths {
+		data, err := proto.Marshal(
+			&descriptor.FileDescriptorSet{
+				File: []*descriptor.FileDescriptorProto{
+					{
+						Name: proto.String("cached.proto"),
+					},
+				},
+			},
+		)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(cacheFilePath, data, 0644))
+	}
+	compileResult, err = NewCompiler(
+		CompilerWithCachePath(cachePath),
+		CompilerWithCompileCache(),
+		CompilerWithFileDescriptorSet(),
+	).Compile(protoSet)
+	require.NoError(t, err)
+	require.Len(t, compileResult.FileDescriptorSets, 2)
+	assert.Equal(t, []string{"cached.proto"}, getFileNames(compileResult.FileDescriptorSets[0]))
+	assert.Equal(t, []string{"cached.proto"}, getFileNames(compileResult.FileDescriptorSets[1]))
+
+	// changing a file changes the key for its directory and every directory that imports it
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(protoSet.Config.DirPath, "a", "a.proto"),
+		[]byte(`syntax = "proto3";
+
+package a;
+
+message A {
+  int64 time = 1;
+}
+`),
+		0644,
+	))
+	compileResult, err = NewCompiler(
+		CompilerWithCachePath(cachePath),
+		CompilerWithCompileCache(),
+		CompilerWithFileDescriptorSet(),
+	).Compile(protoSet)
+	require.NoError(t, err)
+	require.Len(t, compileResult.FileDescriptorSets, 2)
+	assert.Equal(t, []string{"a/a.proto", "b/b.proto"}, getFileNames(compileResult.FileDescriptorSets[1]))
+	assert.Len(t, getCompileCacheFilePaths(t, compileCachePath), 4)
+
+	// failures are not cached
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(protoSet.Config.DirPath, "a", "a.proto"),
+		[]byte(`syntax = "proto3";
+
+package a;
+
+message A {
+  Foo foo = 1;
+}
+`),
+		0644,
+	))
+	compileResult, err = NewCompiler(
+		CompilerWithCachePath(cachePath),
+		CompilerWithCompileCache(),
+	).Compile(protoSet)
+	require.NoError(t, err)
+	assert.NotEmpty(t, compileResult.Failures)
+	assert.Len(t, getCompileCacheFilePaths(t, compileCachePath), 4)
+}
+
+func TestCompileCacheDisabled(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `compile:
+  backend: go
+  disable_cache: true
+`,
+			"a/a.proto": `syntax = "proto3";
+
+package a;
+
+message A {}
+`,
+		},
+	)
+	defer cleanup()
+	cachePath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(cachePath) }()
+
+	compileResult, err := NewCompiler(
+		CompilerWithCachePath(cachePath),
+		CompilerWithCompileCache(),
+		CompilerWithFileDescriptorSet(),
+	).Compile(protoSet)
+	require.NoError(t, err)
+	require.Empty(t, compileResult.Failures)
+	require.Len(t, compileResult.FileDescriptorSets, 1)
+	_, err = os.Stat(filepath.Join(cachePath, "compile"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCompileCacheKeyImports(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `compile:
+  backend: go
+`,
+			"a/a.proto": `syntax = "proto3";
+
+package a;
+
+message A {}
+`,
+			"b/b.proto": `syntax = "proto3";
+
+package b;
+
+import
+  // the messages of a
+  "a/a.proto";
+
+message B {
+  a.A a = 1;
+}
+`,
+			"c/c.proto": `syntax = "proto3";
+
+package c;
+
+import "a/a.proto"
+
+message C {}
+`,
+		},
+	)
+	defer cleanup()
+	compiler := newCompiler()
+	bDirPath := filepath.Join(protoSet.Config.DirPath, "b")
+	cDirPath := filepath.Join(protoSet.Config.DirPath, "c")
+
+	key, names, err := compiler.getCompileCacheKey(protoSet, bDirPath)
+	require.NoError(t, err)
+	assert.NotEmpty(t, key)
+	assert.Equal(t, []string{"b/b.proto"}, names)
+
+	// imports that are split across lines with comments are still part of the key
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(protoSet.Config.DirPath, "a", "a.proto"),
+		[]byte(`syntax = "proto3";
+
+package a;
+
+message A {
+  int64 time = 1;
+}
+`),
+		0644,
+	))
+	newKey, _, err := compiler.getCompileCacheKey(protoSet, bDirPath)
+	require.NoError(t, err)
+	assert.NotEmpty(t, newKey)
+	assert.NotEqual(t, key, newKey)
+
+	// files whose imports cannot be determined are not cached
+	key, names, err = compiler.getCompileCacheKey(protoSet, cDirPath)
+	require.NoError(t, err)
+	assert.Empty(t, key)
+	assert.Equal(t, []string{"c/c.proto"}, names)
+}
+
+func getCompileCacheFilePaths(t *testing.T, compileCachePath string) []string {
+	fileInfos, err := ioutil.ReadDir(compileCachePath)
+	require.NoError(t, err)
+	filePaths := make([]string, 0, len(fileInfos))
+	for _, fileInfo := range fileInfos {
+		filePaths = append(filePaths, filepath.Join(compileCachePath, fileInfo.Name()))
+	}
+	return filePaths
+}
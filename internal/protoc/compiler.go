@@ -88,6 +88,10 @@ type compiler struct {
 	fileDescriptorSetFullControl       bool
 	fileDescriptorSetIncludeImports    bool
 	fileDescriptorSetIncludeSourceInfo bool
+	useCompileCache                    bool
+	// if set, only these directories are compiled, this is used
+	// to compile the directories that are not in the compile cache
+	compileDirPaths map[string]struct{}
 }
 
 func newCompiler(options ...CompilerOption) *compiler {
@@ -101,6 +105,18 @@ func newCompiler(options ...CompilerOption) *compiler {
 }
 
 func (c *compiler) Compile(protoSet *file.ProtoSet) (*CompileResult, error) {
+	// generation has side effects outside of the FileDescriptorSets
+	// so we never skip compiling when generating
+	if c.useCompileCache && !c.doGen && !protoSet.Config.Compile.DisableCache {
+		return c.compileWithCache(protoSet)
+	}
+	return c.compile(protoSet)
+}
+
+func (c *compiler) compile(protoSet *file.ProtoSet) (*CompileResult, error) {
+	if protoSet.Config.Compile.Backend == settings.CompileBackendGo {
+		return c.compileGo(protoSet)
+	}
 	cmdMetas, err := c.getCmdMetas(protoSet)
 	if err != nil {
 		cleanCmdMetas(cmdMetas)
@@ -115,8 +131,8 @@ func (c *compiler) Compile(protoSet *file.ProtoSet) (*CompileResult, error) {
 	if c.doGen {
 		// the directories for the output files have to exist
 		// so if we are generating, we create them before running
-		// protoc, which calls the plugins, which results in created
-		// generated files potentially
+		// protoc, which calls the built-in code generators, which results
+		// in created generated files potentially
 		// we know the directories from the output option in the
 		// config files
 		if err := c.makeGenDirs(protoSet); err != nil {
@@ -148,16 +164,7 @@ func (c *compiler) Compile(protoSet *file.ProtoSet) (*CompileResult, error) {
 	// errors are not text.Failures, these are actual unhandled
 	// system errors from calling protoc, so we short circuit
 	if len(errs) > 0 {
-		// I want newlines instead of spaces so not using multierr
-		errStrings := make([]string, 0, len(errs))
-		for _, err := range errs {
-			// errors.New("") is a non-nil error, so even
-			// if all error strings are empty, we still get an error
-			if errString := err.Error(); errString != "" {
-				errStrings = append(errStrings, errString)
-			}
-		}
-		return nil, errors.New(strings.Join(errStrings, "\n"))
+		return nil, newlineJoinedError(errs)
 	}
 	// if we have failures, it does not matter if we have file descriptor sets
 	// as we should error out, so we do not do any parsing of file descriptor sets
@@ -170,15 +177,46 @@ func (c *compiler) Compile(protoSet *file.ProtoSet) (*CompileResult, error) {
 	}
 
 	fileDescriptorSets := make([]*FileDescriptorSet, 0, len(cmdMetas))
+	var genRequests []*genRequest
 	for _, cmdMeta := range cmdMetas {
-		// if doFileDescriptorSet is not set, we won't get a fileDescriptorSet anyways,
-		// so the end result will be an empty CompileResult at this point
+		// if doFileDescriptorSet is not set and we are not generating, we won't get
+		// a fileDescriptorSet anyways, so the end result will be an empty CompileResult
+		// at this point
 		fileDescriptorSet, err := getFileDescriptorSet(cmdMeta)
 		if err != nil {
 			return nil, err
 		}
-		if fileDescriptorSet != nil {
+		if fileDescriptorSet == nil {
+			continue
+		}
+		if !cmdMeta.isGen {
 			fileDescriptorSets = append(fileDescriptorSets, fileDescriptorSet)
+			continue
+		}
+		genRequest := &genRequest{
+			protoSet:             cmdMeta.protoSet,
+			dirPath:              cmdMeta.dirPath,
+			fileToGenerate:       cmdMeta.fileToGenerate,
+			fileDescriptorProtos: fileDescriptorSet.File,
+		}
+		genRequests = append(genRequests, genRequest)
+		if c.doFileDescriptorSet {
+			// the file descriptor set for generation has everything, so we
+			// give back only what was asked for, see the comments in getCmdMetas
+			includeImports := !c.fileDescriptorSetFullControl || c.fileDescriptorSetIncludeImports
+			fileDescriptorSets = append(fileDescriptorSets, genRequest.newFileDescriptorSet(includeImports, c.fileDescriptorSetIncludeSourceInfo))
+		}
+	}
+	if len(genRequests) > 0 {
+		failures, err := c.runGenPlugins(genRequests)
+		if err != nil {
+			return nil, err
+		}
+		if len(failures) > 0 {
+			text.SortFailures(failures)
+			return &CompileResult{
+				Failures: failures,
+			}, nil
 		}
 	}
 	return &CompileResult{
@@ -187,6 +225,9 @@ func (c *compiler) Compile(protoSet *file.ProtoSet) (*CompileResult, error) {
 }
 
 func (c *compiler) ProtocCommands(protoSet *file.ProtoSet) ([]string, error) {
+	if protoSet.Config.Compile.Backend == settings.CompileBackendGo {
+		return nil, fmt.Errorf("no protoc commands are run with the %s compile backend", protoSet.Config.Compile.Backend)
+	}
 	// we end up calling the logic that creates temporary files for file descriptor sets
 	// anyways, so we need to clean them up with cleanCmdMetas
 	// this logic could be simplified to have a "dry run" option, but ProtocCommands
@@ -203,6 +244,20 @@ func (c *compiler) ProtocCommands(protoSet *file.ProtoSet) ([]string, error) {
 	return cmdMetaStrings, nil
 }
 
+// shouldCompileDir returns true if the files in the given directory of the
+// ProtoSet should be compiled.
+func (c *compiler) shouldCompileDir(protoSet *file.ProtoSet, dirPath string) bool {
+	// skip those files not under the directory
+	if !strings.HasPrefix(dirPath, protoSet.DirPath) {
+		return false
+	}
+	if c.compileDirPaths == nil {
+		return true
+	}
+	_, ok := c.compileDirPaths[dirPath]
+	return ok
+}
+
 func (c *compiler) makeGenDirs(protoSet *file.ProtoSet) error {
 	genDirs := make(map[string]struct{})
 	for _, genPlugin := range protoSet.Config.Gen.Plugins {
@@ -253,6 +308,7 @@ func (c *compiler) runCmdMeta(cmdMeta *cmdMeta) ([]*text.Failure, error) {
 	sig := make(chan os.Signal, 1)
 	done := make(chan error, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
 
 	go func() {
 		done <- cmdMeta.execCmd.Run()
@@ -314,8 +370,7 @@ func (c *compiler) getCmdMetas(protoSet *file.ProtoSet) (cmdMetas []*cmdMeta, re
 		return cmdMetas, err
 	}
 	for dirPath, protoFiles := range protoSet.DirPathToFiles {
-		// skip those files not under the directory
-		if !strings.HasPrefix(dirPath, protoSet.DirPath) {
+		if !c.shouldCompileDir(protoSet, dirPath) {
 			continue
 		}
 		// you want your proto files to be in at least one of the -I directories
@@ -355,54 +410,57 @@ func (c *compiler) getCmdMetas(protoSet *file.ProtoSet) (cmdMetas []*cmdMeta, re
 		if err != nil {
 			return cmdMetas, err
 		}
-		if descriptorSetFilePath != "" {
-			descriptorSetTempFilePath := descriptorSetFilePath
-			if !isTempFile {
-				descriptorSetTempFilePath = ""
-			}
-			// either /dev/null or a temporary file
-			iArgs := append(args, "-o", descriptorSetFilePath)
-			// if its a temporary file, that means we actually care about the output
-			// so we do --include_imports to get all necessary info in the output file descriptor set
-			if descriptorSetTempFilePath != "" {
-				// we included imports historically by default
-				// if fileDescriptorSetFullControl is not set, add include imports
-				// else, if fileDescriptorSetIncludeImports is set, still include imports
-				if !c.fileDescriptorSetFullControl || c.fileDescriptorSetIncludeImports {
-					iArgs = append(iArgs, "--include_imports")
-				}
-				if c.fileDescriptorSetIncludeSourceInfo {
-					iArgs = append(iArgs, "--include_source_info")
-				}
+		descriptorSetTempFilePath := descriptorSetFilePath
+		if !isTempFile {
+			descriptorSetTempFilePath = ""
+		}
+		// if we are generating, protoc only compiles once and we then run the
+		// plugins ourselves with the resulting file descriptor set
+		isGen := c.doGen && len(protoSet.Config.Gen.Plugins) > 0
+		// either /dev/null or a temporary file
+		iArgs := append(args, "-o", descriptorSetFilePath)
+		// if its a temporary file, that means we actually care about the output
+		// so we do --include_imports to get all necessary info in the output file descriptor set
+		if descriptorSetTempFilePath != "" {
+			// plugins always get all imports and source info
+			// we included imports historically by default
+			// if fileDescriptorSetFullControl is not set, add include imports
+			// else, if fileDescriptorSetIncludeImports is set, still include imports
+			if isGen || !c.fileDescriptorSetFullControl || c.fileDescriptorSetIncludeImports {
+				iArgs = append(iArgs, "--include_imports")
 			}
-			for _, protoFile := range protoFiles {
-				iArgs = append(iArgs, protoFile.Path)
+			if isGen || c.fileDescriptorSetIncludeSourceInfo {
+				iArgs = append(iArgs, "--include_source_info")
 			}
-			cmdMetas = append(cmdMetas, &cmdMeta{
-				execCmd:    exec.Command(protocPath, iArgs...),
-				protoSet:   protoSet,
-				dirPath:    dirPath,
-				protoFiles: protoFiles,
-				// used for cleaning up the cmdMeta after everything is done
-				descriptorSetTempFilePath: descriptorSetTempFilePath,
-			})
 		}
+		// the code generators built into protoc still have to be run by protoc
 		pluginFlagSets, err := c.getPluginFlagSets(protoSet, dirPath)
 		if err != nil {
 			return cmdMetas, err
 		}
 		for _, pluginFlagSet := range pluginFlagSets {
-			iArgs := append(args, pluginFlagSet...)
-			for _, protoFile := range protoFiles {
-				iArgs = append(iArgs, protoFile.Path)
+			iArgs = append(iArgs, pluginFlagSet...)
+		}
+		var fileToGenerate []string
+		for _, protoFile := range protoFiles {
+			iArgs = append(iArgs, protoFile.Path)
+			if isGen {
+				// protoc will fail if the file is not within an include path
+				if name, ok := settings.GetIncludeRelPath(includes, protoFile.Path); ok {
+					fileToGenerate = append(fileToGenerate, name)
+				}
 			}
-			cmdMetas = append(cmdMetas, &cmdMeta{
-				execCmd:    exec.Command(protocPath, iArgs...),
-				protoSet:   protoSet,
-				dirPath:    dirPath,
-				protoFiles: protoFiles,
-			})
 		}
+		cmdMetas = append(cmdMetas, &cmdMeta{
+			execCmd:    exec.Command(protocPath, iArgs...),
+			protoSet:   protoSet,
+			dirPath:    dirPath,
+			protoFiles: protoFiles,
+			// used for cleaning up the cmdMeta after everything is done
+			descriptorSetTempFilePath: descriptorSetTempFilePath,
+			isGen:                     isGen,
+			fileToGenerate:            fileToGenerate,
+		})
 	}
 	return cmdMetas, nil
 }
@@ -447,17 +505,24 @@ func (c *compiler) getDescriptorSetFilePath(protoSet *file.ProtoSet) (string, bo
 		}
 		return tempFilePath, true, nil
 	}
+	// plugins are run with the file descriptor set
 	if c.doGen && len(protoSet.Config.Gen.Plugins) > 0 {
-		return "", false, nil
+		tempFilePath, err := getTempFilePath()
+		if err != nil {
+			return "", false, err
+		}
+		return tempFilePath, true, nil
 	}
 	devNullFilePath, err := devNull()
 	return devNullFilePath, false, err
 }
 
 // each value in the slice of string slices is a flag passed to protoc
+// this is only done for the code generators built into protoc, all other
+// plugins are run directly in runGenPlugins
 // examples:
-// []string{"--go_out=plugins=grpc:."}
-// []string{"--grpc-cpp_out=.", "--plugin=protoc-gen-grpc-cpp=/path/to/foo"}
+// []string{"--java_out=."}
+// []string{"--cpp_out=."}
 func (c *compiler) getPluginFlagSets(protoSet *file.ProtoSet, dirPath string) ([][]string, error) {
 	// if not generating, or there are no plugins, nothing to do
 	if !c.doGen || len(protoSet.Config.Gen.Plugins) == 0 {
@@ -465,6 +530,13 @@ func (c *compiler) getPluginFlagSets(protoSet *file.ProtoSet, dirPath string) ([
 	}
 	pluginFlagSets := make([][]string, 0, len(protoSet.Config.Gen.Plugins))
 	for _, genPlugin := range protoSet.Config.Gen.Plugins {
+		isBuiltin, err := c.isBuiltinGenPlugin(protoSet, genPlugin)
+		if err != nil {
+			return nil, err
+		}
+		if !isBuiltin {
+			continue
+		}
 		pluginFlagSet, err := getPluginFlagSet(protoSet, dirPath, genPlugin)
 		if err != nil {
 			return nil, err
@@ -596,7 +668,8 @@ func getIncludes(downloader Downloader, config settings.Config, dirPath string,
 			includedConfigDirPath = true
 		}
 	}
-	if config.Compile.IncludeWellKnownTypes {
+	// the go backend has the well-known types built in and passes no downloader
+	if config.Compile.IncludeWellKnownTypes && downloader != nil {
 		wellKnownTypesIncludePath, err := downloader.WellKnownTypesIncludePath()
 		if err != nil {
 			return nil, err
@@ -897,6 +970,22 @@ func getFileDescriptorSet(cmdMeta *cmdMeta) (*FileDescriptorSet, error) {
 	}, nil
 }
 
+// newlineJoinedError returns an error with the non-empty error strings of errs joined by newlines.
+//
+// errs must not be empty.
+func newlineJoinedError(errs []error) error {
+	// I want newlines instead of spaces so not using multierr
+	errStrings := make([]string, 0, len(errs))
+	for _, err := range errs {
+		// errors.New("") is a non-nil error, so even
+		// if all error strings are empty, we still get an error
+		if errString := err.Error(); errString != "" {
+			errStrings = append(errStrings, errString)
+		}
+	}
+	return errors.New(strings.Join(errStrings, "\n"))
+}
+
 func devNull() (string, error) {
 	switch runtime.GOOS {
 	case "darwin", "linux":
@@ -928,6 +1017,10 @@ type cmdMeta struct {
 	dirPath                   string
 	protoFiles                []*file.ProtoFile
 	descriptorSetTempFilePath string
+	// if set, plugins are to be run with the file descriptor set
+	isGen bool
+	// the names of protoFiles within the file descriptor set
+	fileToGenerate []string
 }
 
 func (c *cmdMeta) String() string {
@@ -141,6 +141,14 @@ func (d *downloader) WellKnownTypesIncludePath() (string, error) {
 	return filepath.Join(basePath, "include"), nil
 }
 
+func (d *downloader) CompileCachePath() (string, error) {
+	basePath, err := d.getBasePathNoProtobuf()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(basePath, "compile"), nil
+}
+
 func (d *downloader) Delete() error {
 	basePath, err := d.getBasePathNoVersionOSARCH()
 	if err != nil {
@@ -354,6 +362,14 @@ func (d *downloader) getBasePathNoVersionOSARCH() (string, error) {
 }
 
 func (d *downloader) getBasePathNoVersion() (string, error) {
+	basePath, err := d.getBasePathNoProtobuf()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(basePath, "protobuf"), nil
+}
+
+func (d *downloader) getBasePathNoProtobuf() (string, error) {
 	basePath := d.cachePath
 	var err error
 	if basePath == "" {
@@ -370,7 +386,7 @@ func (d *downloader) getBasePathNoVersion() (string, error) {
 	if err := file.CheckAbs(basePath); err != nil {
 		return "", err
 	}
-	return filepath.Join(basePath, "protobuf"), nil
+	return basePath, nil
 }
 
 func (d *downloader) getBasePathVersionPart() string {
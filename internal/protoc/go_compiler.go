@@ -0,0 +1,484 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protoc
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/settings"
+	"github.com/uber/prototool/internal/text"
+)
+
+// the field number of dependency in google.protobuf.FileDescriptorProto
+const fileDescriptorProtoDependencyTag = 3
+
+// compileGo compiles the ProtoSet in-process instead of calling protoc.
+//
+// The result is meant to be equivalent to compiling with protoc. The
+// well-known types are built into the parser, so nothing is downloaded.
+// Plugins are invoked directly with a CodeGeneratorRequest built from
+// the compiled descriptors.
+func (c *compiler) compileGo(protoSet *file.ProtoSet) (*CompileResult, error) {
+	if c.doGen {
+		if err := c.makeGenDirs(protoSet); err != nil {
+			return nil, err
+		}
+	}
+	var dirPaths []string
+	for dirPath := range protoSet.DirPathToFiles {
+		if c.shouldCompileDir(protoSet, dirPath) {
+			dirPaths = append(dirPaths, dirPath)
+		}
+	}
+	sort.Strings(dirPaths)
+
+	var failures []*text.Failure
+	var errs []error
+	genRequests := make([]*genRequest, len(dirPaths))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	semaphoreC := make(chan struct{}, runtime.NumCPU())
+	for i, dirPath := range dirPaths {
+		i := i
+		dirPath := dirPath
+		wg.Add(1)
+		semaphoreC <- struct{}{}
+		go func() {
+			defer wg.Done()
+			iFailures, genRequest, iErr := c.compileGoDir(protoSet, dirPath)
+			lock.Lock()
+			failures = append(failures, iFailures...)
+			genRequests[i] = genRequest
+			if iErr != nil {
+				errs = append(errs, iErr)
+			}
+			lock.Unlock()
+			<-semaphoreC
+		}()
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, newlineJoinedError(errs)
+	}
+	if len(failures) > 0 {
+		text.SortFailures(failures)
+		return &CompileResult{
+			Failures: failures,
+		}, nil
+	}
+	if c.doGen {
+		failures, err := c.runGenPlugins(genRequests)
+		if err != nil {
+			return nil, err
+		}
+		if len(failures) > 0 {
+			text.SortFailures(failures)
+			return &CompileResult{
+				Failures: failures,
+			}, nil
+		}
+	}
+	result := &CompileResult{}
+	if c.doFileDescriptorSet {
+		// see the comments in getCmdMetas
+		includeImports := !c.fileDescriptorSetFullControl || c.fileDescriptorSetIncludeImports
+		for _, genRequest := range genRequests {
+			result.FileDescriptorSets = append(result.FileDescriptorSets, genRequest.newFileDescriptorSet(includeImports, c.fileDescriptorSetIncludeSourceInfo))
+		}
+	}
+	return result, nil
+}
+
+// compileGoDir compiles the files in the given directory of the ProtoSet,
+// the equivalent of a single protoc call.
+//
+// The returned genRequest has the compiled files.
+func (c *compiler) compileGoDir(protoSet *file.ProtoSet, dirPath string) ([]*text.Failure, *genRequest, error) {
+	protoFiles := protoSet.DirPathToFiles[dirPath]
+	// see the comments in getCmdMetas
+	configDirPath := protoSet.Config.DirPath
+	if configDirPath == "" {
+		configDirPath = protoSet.WorkDirPath
+	}
+	includes, err := getIncludes(nil, protoSet.Config, dirPath, configDirPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the parser names files by their path relative to the include
+	// path they were found in, as protoc does, so we need a way back
+	// to the display paths for failures
+	nameToDisplayPath := make(map[string]string)
+	for _, iProtoFiles := range protoSet.DirPathToFiles {
+		for _, protoFile := range iProtoFiles {
+			if name, ok := settings.GetIncludeRelPath(includes, protoFile.Path); ok {
+				nameToDisplayPath[name] = protoFile.DisplayPath
+			}
+		}
+	}
+	var failures []*text.Failure
+	names := make([]string, 0, len(protoFiles))
+	for _, protoFile := range protoFiles {
+		name, ok := settings.GetIncludeRelPath(includes, protoFile.Path)
+		if !ok {
+			failures = append(failures, &text.Failure{
+				Filename: protoFile.DisplayPath,
+				Message:  "File does not reside within any include path.",
+			})
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(failures) > 0 {
+		return failures, nil, nil
+	}
+
+	parser := protoparse.Parser{
+		ImportPaths: includes,
+		// plugins always get source info, we strip it if it was not
+		// requested for the FileDescriptorSet
+		IncludeSourceCodeInfo: true,
+		ErrorReporter: func(errWithPos protoparse.ErrorWithPos) error {
+			failures = append(failures, newErrorWithPosFailure(nameToDisplayPath, errWithPos))
+			// keep going to report as many failures as possible
+			return nil
+		},
+	}
+	fileDescriptors, err := parser.ParseFiles(names...)
+	if len(failures) > 0 {
+		return failures, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !protoSet.Config.Compile.AllowUnusedImports {
+		for _, fileDescriptor := range fileDescriptors {
+			failures = append(failures, getUnusedImportFailures(nameToDisplayPath, fileDescriptor)...)
+		}
+		if len(failures) > 0 {
+			return failures, nil, nil
+		}
+	}
+
+	return nil, &genRequest{
+		protoSet:             protoSet,
+		dirPath:              dirPath,
+		fileToGenerate:       names,
+		fileDescriptorProtos: getSortedFileDescriptorProtos(fileDescriptors),
+	}, nil
+}
+
+func newErrorWithPosFailure(nameToDisplayPath map[string]string, errWithPos protoparse.ErrorWithPos) *text.Failure {
+	pos := errWithPos.GetPosition()
+	filename, ok := nameToDisplayPath[pos.Filename]
+	if !ok {
+		filename = pos.Filename
+	}
+	return &text.Failure{
+		Filename: filename,
+		Line:     pos.Line,
+		Column:   pos.Col,
+		Message:  errWithPos.Unwrap().Error(),
+	}
+}
+
+// getSortedFileDescriptorProtos returns the FileDescriptorProtos of the given
+// files and all their imports, with every file placed after its imports.
+func getSortedFileDescriptorProtos(fileDescriptors []*desc.FileDescriptor) []*descriptor.FileDescriptorProto {
+	var fileDescriptorProtos []*descriptor.FileDescriptorProto
+	seen := make(map[string]struct{})
+	var add func(*desc.FileDescriptor)
+	add = func(fileDescriptor *desc.FileDescriptor) {
+		if _, ok := seen[fileDescriptor.GetName()]; ok {
+			return
+		}
+		seen[fileDescriptor.GetName()] = struct{}{}
+		for _, dependency := range fileDescriptor.GetDependencies() {
+			add(dependency)
+		}
+		fileDescriptorProtos = append(fileDescriptorProtos, fileDescriptor.AsFileDescriptorProto())
+	}
+	for _, fileDescriptor := range fileDescriptors {
+		add(fileDescriptor)
+	}
+	return fileDescriptorProtos
+}
+
+// newFileDescriptorSet returns a new FileDescriptorSet of the sorted FileDescriptorProtos,
+// the equivalent of protoc --descriptor_set_out.
+//
+// If includeImports is false, only the files with the given names are included.
+// The given FileDescriptorProtos are not modified.
+func newFileDescriptorSet(fileDescriptorProtos []*descriptor.FileDescriptorProto, names []string, includeImports bool, includeSourceInfo bool) *descriptor.FileDescriptorSet {
+	nameMap := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		nameMap[name] = struct{}{}
+	}
+	fileDescriptorSet := &descriptor.FileDescriptorSet{}
+	for _, fileDescriptorProto := range fileDescriptorProtos {
+		if _, ok := nameMap[fileDescriptorProto.GetName()]; !ok && !includeImports {
+			continue
+		}
+		if !includeSourceInfo && fileDescriptorProto.SourceCodeInfo != nil {
+			fileDescriptorProto = proto.Clone(fileDescriptorProto).(*descriptor.FileDescriptorProto)
+			fileDescriptorProto.SourceCodeInfo = nil
+		}
+		fileDescriptorSet.File = append(fileDescriptorSet.File, fileDescriptorProto)
+	}
+	return fileDescriptorSet
+}
+
+// getUnusedImportFailures returns a failure for every import of the file that
+// is not used, the equivalent of the protoc unused import warnings.
+//
+// An import is used if the file references a type or a custom option defined
+// in the imported file, or in a file publicly imported by the imported file.
+func getUnusedImportFailures(nameToDisplayPath map[string]string, fileDescriptor *desc.FileDescriptor) []*text.Failure {
+	usedNames := make(map[string]struct{})
+	for _, message := range fileDescriptor.GetMessageTypes() {
+		addMessageUsedNames(usedNames, message)
+	}
+	for _, extension := range fileDescriptor.GetExtensions() {
+		addFieldUsedNames(usedNames, extension)
+	}
+	for _, service := range fileDescriptor.GetServices() {
+		for _, method := range service.GetMethods() {
+			usedNames[method.GetInputType().GetFile().GetName()] = struct{}{}
+			usedNames[method.GetOutputType().GetFile().GetName()] = struct{}{}
+		}
+	}
+	extensionKeyToName := make(map[string]string)
+	addExtensionKeyToName(extensionKeyToName, fileDescriptor, make(map[string]struct{}))
+	for _, options := range getOptionsMessages(fileDescriptor.AsFileDescriptorProto()) {
+		// unknown extensions are returned with only the field number set
+		extensionDescs, err := proto.ExtensionDescs(options)
+		if err != nil {
+			continue
+		}
+		for _, extensionDesc := range extensionDescs {
+			if name, ok := extensionKeyToName[getExtensionKey(proto.MessageName(options), extensionDesc.Field)]; ok {
+				usedNames[name] = struct{}{}
+			}
+		}
+	}
+
+	weakDependencies := make(map[int32]struct{})
+	for _, index := range fileDescriptor.AsFileDescriptorProto().WeakDependency {
+		weakDependencies[index] = struct{}{}
+	}
+	filename, ok := nameToDisplayPath[fileDescriptor.GetName()]
+	if !ok {
+		filename = fileDescriptor.GetName()
+	}
+	var failures []*text.Failure
+	for i, dependency := range fileDescriptor.GetDependencies() {
+		if _, ok := weakDependencies[int32(i)]; ok {
+			continue
+		}
+		if isDependencyUsed(usedNames, dependency) {
+			continue
+		}
+		failure := &text.Failure{
+			Filename: filename,
+			Message:  fmt.Sprintf(`Import "%s" was not used.`, dependency.GetName()),
+		}
+		if location := getSourceLocation(fileDescriptor.AsFileDescriptorProto(), fileDescriptorProtoDependencyTag, int32(i)); location != nil && len(location.Span) > 1 {
+			failure.Line = int(location.Span[0]) + 1
+			failure.Column = int(location.Span[1]) + 1
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+func addMessageUsedNames(usedNames map[string]struct{}, message *desc.MessageDescriptor) {
+	for _, field := range message.GetFields() {
+		addFieldUsedNames(usedNames, field)
+	}
+	for _, extension := range message.GetNestedExtensions() {
+		addFieldUsedNames(usedNames, extension)
+	}
+	for _, nestedMessage := range message.GetNestedMessageTypes() {
+		addMessageUsedNames(usedNames, nestedMessage)
+	}
+}
+
+func addFieldUsedNames(usedNames map[string]struct{}, field *desc.FieldDescriptor) {
+	if messageType := field.GetMessageType(); messageType != nil {
+		usedNames[messageType.GetFile().GetName()] = struct{}{}
+	}
+	if enumType := field.GetEnumType(); enumType != nil {
+		usedNames[enumType.GetFile().GetName()] = struct{}{}
+	}
+	if field.IsExtension() {
+		// the owner of an extension is the extendee
+		usedNames[field.GetOwner().GetFile().GetName()] = struct{}{}
+	}
+}
+
+// addExtensionKeyToName adds all extensions defined in the imports of the
+// file, transitively, to extensionKeyToName.
+func addExtensionKeyToName(extensionKeyToName map[string]string, fileDescriptor *desc.FileDescriptor, seen map[string]struct{}) {
+	for _, dependency := range fileDescriptor.GetDependencies() {
+		if _, ok := seen[dependency.GetName()]; ok {
+			continue
+		}
+		seen[dependency.GetName()] = struct{}{}
+		for _, extension := range dependency.GetExtensions() {
+			extensionKeyToName[getExtensionKey(extension.GetOwner().GetFullyQualifiedName(), extension.GetNumber())] = dependency.GetName()
+		}
+		for _, message := range dependency.GetMessageTypes() {
+			addMessageExtensionKeyToName(extensionKeyToName, message, dependency.GetName())
+		}
+		addExtensionKeyToName(extensionKeyToName, dependency, seen)
+	}
+}
+
+func addMessageExtensionKeyToName(extensionKeyToName map[string]string, message *desc.MessageDescriptor, name string) {
+	for _, extension := range message.GetNestedExtensions() {
+		extensionKeyToName[getExtensionKey(extension.GetOwner().GetFullyQualifiedName(), extension.GetNumber())] = name
+	}
+	for _, nestedMessage := range message.GetNestedMessageTypes() {
+		addMessageExtensionKeyToName(extensionKeyToName, nestedMessage, name)
+	}
+}
+
+func getExtensionKey(extendee string, number int32) string {
+	return fmt.Sprintf("%s:%d", extendee, number)
+}
+
+// isDependencyUsed returns true if the dependency or any file it
+// publicly imports, transitively, is used.
+func isDependencyUsed(usedNames map[string]struct{}, dependency *desc.FileDescriptor) bool {
+	if _, ok := usedNames[dependency.GetName()]; ok {
+		return true
+	}
+	for _, publicDependency := range dependency.GetPublicDependencies() {
+		if isDependencyUsed(usedNames, publicDependency) {
+			return true
+		}
+	}
+	return false
+}
+
+// getOptionsMessages returns all options messages set within the file.
+func getOptionsMessages(fileDescriptorProto *descriptor.FileDescriptorProto) []proto.Message {
+	var optionsMessages []proto.Message
+	if fileDescriptorProto.Options != nil {
+		optionsMessages = append(optionsMessages, fileDescriptorProto.Options)
+	}
+	for _, message := range fileDescriptorProto.MessageType {
+		optionsMessages = append(optionsMessages, getMessageOptionsMessages(message)...)
+	}
+	for _, enum := range fileDescriptorProto.EnumType {
+		optionsMessages = append(optionsMessages, getEnumOptionsMessages(enum)...)
+	}
+	for _, extension := range fileDescriptorProto.Extension {
+		if extension.Options != nil {
+			optionsMessages = append(optionsMessages, extension.Options)
+		}
+	}
+	for _, service := range fileDescriptorProto.Service {
+		if service.Options != nil {
+			optionsMessages = append(optionsMessages, service.Options)
+		}
+		for _, method := range service.Method {
+			if method.Options != nil {
+				optionsMessages = append(optionsMessages, method.Options)
+			}
+		}
+	}
+	return optionsMessages
+}
+
+func getMessageOptionsMessages(message *descriptor.DescriptorProto) []proto.Message {
+	var optionsMessages []proto.Message
+	if message.Options != nil {
+		optionsMessages = append(optionsMessages, message.Options)
+	}
+	for _, field := range message.Field {
+		if field.Options != nil {
+			optionsMessages = append(optionsMessages, field.Options)
+		}
+	}
+	for _, extension := range message.Extension {
+		if extension.Options != nil {
+			optionsMessages = append(optionsMessages, extension.Options)
+		}
+	}
+	for _, oneof := range message.OneofDecl {
+		if oneof.Options != nil {
+			optionsMessages = append(optionsMessages, oneof.Options)
+		}
+	}
+	for _, extensionRange := range message.ExtensionRange {
+		if extensionRange.Options != nil {
+			optionsMessages = append(optionsMessages, extensionRange.Options)
+		}
+	}
+	for _, nestedMessage := range message.NestedType {
+		optionsMessages = append(optionsMessages, getMessageOptionsMessages(nestedMessage)...)
+	}
+	for _, enum := range message.EnumType {
+		optionsMessages = append(optionsMessages, getEnumOptionsMessages(enum)...)
+	}
+	return optionsMessages
+}
+
+func getEnumOptionsMessages(enum *descriptor.EnumDescriptorProto) []proto.Message {
+	var optionsMessages []proto.Message
+	if enum.Options != nil {
+		optionsMessages = append(optionsMessages, enum.Options)
+	}
+	for _, value := range enum.Value {
+		if value.Options != nil {
+			optionsMessages = append(optionsMessages, value.Options)
+		}
+	}
+	return optionsMessages
+}
+
+// getSourceLocation returns the SourceCodeInfo location for the given path, or nil.
+func getSourceLocation(fileDescriptorProto *descriptor.FileDescriptorProto, path ...int32) *descriptor.SourceCodeInfo_Location {
+	for _, location := range fileDescriptorProto.GetSourceCodeInfo().GetLocation() {
+		if len(location.Path) != len(path) {
+			continue
+		}
+		equal := true
+		for i := range path {
+			if location.Path[i] != path[i] {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return location
+		}
+	}
+	return nil
+}
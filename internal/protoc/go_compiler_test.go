@@ -0,0 +1,246 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protoc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/text"
+)
+
+func TestCompileGo(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `compile:
+  backend: go
+`,
+			"a/a.proto": `syntax = "proto3";
+
+package a;
+
+import "google/protobuf/timestamp.proto";
+
+message A {
+  google.protobuf.Timestamp time = 1;
+}
+`,
+			"b/b.proto": `syntax = "proto3";
+
+package b;
+
+import "a/a.proto";
+
+message B {
+  a.A a = 1;
+}
+`,
+		},
+	)
+	defer cleanup()
+	compileResult, err := NewCompiler(CompilerWithFileDescriptorSet()).Compile(protoSet)
+	require.NoError(t, err)
+	require.Empty(t, compileResult.Failures)
+	require.Len(t, compileResult.FileDescriptorSets, 2)
+	assert.Equal(t, []string{"google/protobuf/timestamp.proto", "a/a.proto"}, getFileNames(compileResult.FileDescriptorSets[0]))
+	assert.Equal(t, []string{"google/protobuf/timestamp.proto", "a/a.proto", "b/b.proto"}, getFileNames(compileResult.FileDescriptorSets[1]))
+	for _, fileDescriptorProto := range compileResult.FileDescriptorSets[1].File {
+		assert.Nil(t, fileDescriptorProto.SourceCodeInfo)
+	}
+
+	compileResult, err = NewCompiler(CompilerWithFileDescriptorSetFullControl(false, true)).Compile(protoSet)
+	require.NoError(t, err)
+	require.Len(t, compileResult.FileDescriptorSets, 2)
+	assert.Equal(t, []string{"b/b.proto"}, getFileNames(compileResult.FileDescriptorSets[1]))
+	assert.NotNil(t, compileResult.FileDescriptorSets[1].File[0].SourceCodeInfo)
+}
+
+func TestCompileGoFailures(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `compile:
+  backend: go
+`,
+			"a/a.proto": `syntax = "proto3";
+
+package a;
+
+import "google/protobuf/timestamp.proto";
+
+message A {
+  int64 foo = 1;
+}
+`,
+			"b/b.proto": `syntax = "proto3";
+
+package b;
+
+message B {
+  Foo foo = 1;
+}
+`,
+		},
+	)
+	defer cleanup()
+	compileResult, err := NewCompiler().Compile(protoSet)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*text.Failure{
+			{
+				Filename: "a/a.proto",
+				Line:     5,
+				Column:   1,
+				Message:  `Import "google/protobuf/timestamp.proto" was not used.`,
+			},
+			{
+				Filename: "b/b.proto",
+				Line:     6,
+				Column:   3,
+				Message:  `field b.B.foo: unknown type Foo`,
+			},
+		},
+		compileResult.Failures,
+	)
+}
+
+func TestCompileGoAllowUnusedImports(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `protoc:
+  allow_unused_imports: true
+compile:
+  backend: go
+`,
+			"a/a.proto": `syntax = "proto3";
+
+package a;
+
+import "google/protobuf/timestamp.proto";
+
+message A {
+  int64 foo = 1;
+}
+`,
+		},
+	)
+	defer cleanup()
+	compileResult, err := NewCompiler().Compile(protoSet)
+	require.NoError(t, err)
+	assert.Empty(t, compileResult.Failures)
+}
+
+func TestCompileGoDotDotDirectory(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `protoc:
+  includes:
+    - proto
+compile:
+  backend: go
+`,
+			"proto/..gen/a.proto": `syntax = "proto3";
+
+package gen;
+
+message A {}
+`,
+		},
+	)
+	defer cleanup()
+	compileResult, err := NewCompiler(CompilerWithFileDescriptorSet()).Compile(protoSet)
+	require.NoError(t, err)
+	require.Empty(t, compileResult.Failures)
+	require.Len(t, compileResult.FileDescriptorSets, 1)
+	assert.Equal(t, []string{"..gen/a.proto"}, getFileNames(compileResult.FileDescriptorSets[0]))
+}
+
+func TestCompileGoGenDescriptorSet(t *testing.T) {
+	protoSet, cleanup := newTestProtoSet(
+		t,
+		map[string]string{
+			"prototool.yaml": `compile:
+  backend: go
+generate:
+  plugins:
+    - name: descriptor_set
+      output: gen
+      file_suffix: bin
+      include_imports: true
+`,
+			"a/a.proto": `syntax = "proto3";
+
+package a;
+
+import "google/protobuf/timestamp.proto";
+
+message A {
+  google.protobuf.Timestamp time = 1;
+}
+`,
+		},
+	)
+	defer cleanup()
+	compileResult, err := NewCompiler(CompilerWithGen()).Compile(protoSet)
+	require.NoError(t, err)
+	require.Empty(t, compileResult.Failures)
+	data, err := ioutil.ReadFile(filepath.Join(protoSet.Config.DirPath, "gen", "a", "a.bin"))
+	require.NoError(t, err)
+	fileDescriptorSet := &descriptor.FileDescriptorSet{}
+	require.NoError(t, proto.Unmarshal(data, fileDescriptorSet))
+	assert.Equal(t, []string{"google/protobuf/timestamp.proto", "a/a.proto"}, getFileNames(&FileDescriptorSet{FileDescriptorSet: fileDescriptorSet}))
+}
+
+func newTestProtoSet(t *testing.T, relFilePathToContent map[string]string) (*file.ProtoSet, func()) {
+	tmpDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	cleanup := func() { _ = os.RemoveAll(tmpDirPath) }
+	for relFilePath, content := range relFilePathToContent {
+		filePath := filepath.Join(tmpDirPath, relFilePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		require.NoError(t, ioutil.WriteFile(filePath, []byte(content), 0644))
+	}
+	protoSet, err := file.NewProtoSetProvider().GetForDir(tmpDirPath, tmpDirPath)
+	if err != nil {
+		cleanup()
+		require.NoError(t, err)
+	}
+	return protoSet, cleanup
+}
+
+func getFileNames(fileDescriptorSet *FileDescriptorSet) []string {
+	fileNames := make([]string, 0, len(fileDescriptorSet.File))
+	for _, fileDescriptorProto := range fileDescriptorSet.File {
+		fileNames = append(fileNames, fileDescriptorProto.GetName())
+	}
+	return fileNames
+}
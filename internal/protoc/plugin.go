@@ -0,0 +1,521 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protoc
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/semver"
+	"github.com/uber/prototool/internal/settings"
+	"github.com/uber/prototool/internal/text"
+	"github.com/uber/prototool/internal/vars"
+	"go.uber.org/zap"
+)
+
+const insertionPointFormat = "@@protoc_insertion_point(%s)"
+
+// the code generators built into protoc, these have no protoc-gen-NAME plugin
+var builtinGenPluginNames = map[string]struct{}{
+	"cpp":    {},
+	"csharp": {},
+	"java":   {},
+	"js":     {},
+	"objc":   {},
+	"php":    {},
+	"python": {},
+	"ruby":   {},
+}
+
+// genRequest is the equivalent of a single protoc call with plugins, that is
+// all plugins should be run for the files in a directory.
+type genRequest struct {
+	protoSet *file.ProtoSet
+	dirPath  string
+	// the names of the files in the directory
+	fileToGenerate []string
+	// the files in the directory and all their imports, with every
+	// file placed after its imports
+	fileDescriptorProtos []*descriptor.FileDescriptorProto
+}
+
+// newFileDescriptorSet returns a new FileDescriptorSet for the compiled files.
+func (g *genRequest) newFileDescriptorSet(includeImports bool, includeSourceInfo bool) *FileDescriptorSet {
+	return &FileDescriptorSet{
+		FileDescriptorSet: newFileDescriptorSet(g.fileDescriptorProtos, g.fileToGenerate, includeImports, includeSourceInfo),
+		ProtoSet:          g.protoSet,
+		DirPath:           g.dirPath,
+		ProtoFiles:        g.protoSet.DirPathToFiles[g.dirPath],
+	}
+}
+
+// genResponse is the result of running a single plugin for a genRequest.
+type genResponse struct {
+	genPlugin  settings.GenPlugin
+	outputPath string
+	files      []*plugin_go.CodeGeneratorResponse_File
+	failures   []*text.Failure
+	err        error
+}
+
+// runGenPlugins runs the plugins for all the genRequests by sending them a
+// CodeGeneratorRequest directly, and writes the generated files.
+//
+// Plugins are run concurrently. Once all plugins have succeeded, the files
+// for each genRequest are written in plugin order, so that insertion points
+// can refer to files generated by previous plugins for the same genRequest.
+// Code generators built into protoc are skipped, these are expected to be
+// run by protoc.
+func (c *compiler) runGenPlugins(genRequests []*genRequest) ([]*text.Failure, error) {
+	genResponses := make([][]*genResponse, len(genRequests))
+	for i, genRequest := range genRequests {
+		genResponses[i] = make([]*genResponse, 0, len(genRequest.protoSet.Config.Gen.Plugins))
+		for _, genPlugin := range genRequest.protoSet.Config.Gen.Plugins {
+			isBuiltin, err := c.isBuiltinGenPlugin(genRequest.protoSet, genPlugin)
+			if err != nil {
+				return nil, err
+			}
+			if !isBuiltin {
+				genResponses[i] = append(genResponses[i], &genResponse{genPlugin: genPlugin})
+			}
+		}
+	}
+	var wg sync.WaitGroup
+	semaphoreC := make(chan struct{}, runtime.NumCPU())
+	for i, genRequest := range genRequests {
+		for _, genResponse := range genResponses[i] {
+			genRequest := genRequest
+			genResponse := genResponse
+			wg.Add(1)
+			semaphoreC <- struct{}{}
+			go func() {
+				defer wg.Done()
+				c.runGenPlugin(genRequest, genResponse)
+				<-semaphoreC
+			}()
+		}
+	}
+	wg.Wait()
+	var failures []*text.Failure
+	var errs []error
+	for _, iGenResponses := range genResponses {
+		for _, genResponse := range iGenResponses {
+			failures = append(failures, genResponse.failures...)
+			if genResponse.err != nil {
+				errs = append(errs, genResponse.err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, newlineJoinedError(errs)
+	}
+	if len(failures) > 0 {
+		return failures, nil
+	}
+	for _, iGenResponses := range genResponses {
+		iFailures, err := writeGenResponses(iGenResponses)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, iFailures...)
+	}
+	return failures, nil
+}
+
+// isBuiltinGenPlugin returns true if the plugin is a code generator built into
+// protoc that has to be run by protoc.
+//
+// This is never true for the go compile backend, which reports these plugins
+// as failures instead.
+func (c *compiler) isBuiltinGenPlugin(protoSet *file.ProtoSet, genPlugin settings.GenPlugin) (bool, error) {
+	if protoSet.Config.Compile.Backend == settings.CompileBackendGo {
+		return false, nil
+	}
+	if _, ok := builtinGenPluginNames[genPlugin.Name]; !ok {
+		return false, nil
+	}
+	// if a path is set, this is a plugin that happens to have the same name
+	genPluginPath, err := genPlugin.GetPath()
+	if err != nil {
+		return false, err
+	}
+	return genPluginPath == "", nil
+}
+
+// runGenPlugin runs a single plugin and populates the genResponse.
+func (c *compiler) runGenPlugin(genRequest *genRequest, genResponse *genResponse) {
+	genPlugin := genResponse.genPlugin
+	outputPath := genPlugin.OutputPath.AbsPath
+	if genPlugin.FileSuffix != "" {
+		relOutputFilePath, err := getRelOutputFilePath(genRequest.protoSet, genRequest.dirPath, genPlugin.FileSuffix)
+		if err != nil {
+			genResponse.err = err
+			return
+		}
+		outputPath = filepath.Join(outputPath, relOutputFilePath)
+	}
+	genResponse.outputPath = outputPath
+	// descriptor_set is handled by protoc with --descriptor_set_out, so we handle it ourselves
+	if genPlugin.Name == "descriptor_set" {
+		data, err := proto.Marshal(newFileDescriptorSet(genRequest.fileDescriptorProtos, genRequest.fileToGenerate, genPlugin.IncludeImports, genPlugin.IncludeSourceInfo))
+		if err != nil {
+			genResponse.err = err
+			return
+		}
+		genResponse.outputPath = filepath.Dir(outputPath)
+		genResponse.files = []*plugin_go.CodeGeneratorResponse_File{
+			{
+				Name:    proto.String(filepath.Base(outputPath)),
+				Content: proto.String(string(data)),
+			},
+		}
+		return
+	}
+	genPluginPath, failure, err := getGenPluginPath(genPlugin)
+	if err != nil || failure != nil {
+		genResponse.failures = newFailures(failure)
+		genResponse.err = err
+		return
+	}
+	parameter, err := getPluginFlagSetProtoFlags(genRequest.protoSet, genRequest.dirPath, genPlugin)
+	if err != nil {
+		genResponse.err = err
+		return
+	}
+	request := &plugin_go.CodeGeneratorRequest{
+		FileToGenerate:  genRequest.fileToGenerate,
+		ProtoFile:       genRequest.fileDescriptorProtos,
+		CompilerVersion: getCompilerVersion(genRequest.protoSet.Config.Compile.ProtobufVersion),
+	}
+	if parameter != "" {
+		request.Parameter = proto.String(parameter)
+	}
+	response, failures, err := c.execGenPlugin(genPlugin.Name, genPluginPath, request)
+	if err != nil || len(failures) > 0 {
+		genResponse.failures = failures
+		genResponse.err = err
+		return
+	}
+	if response.Error != nil {
+		genResponse.failures = newFailures(&text.Failure{
+			Message: fmt.Sprintf("protoc-gen-%s: %s", genPlugin.Name, response.GetError()),
+		})
+		return
+	}
+	genResponse.files = response.File
+}
+
+// getCompilerVersion returns the version of protoc that plugins are told
+// they were run by, which is the configured protoc version, or nil if the
+// version is not a semantic version.
+func getCompilerVersion(protobufVersion string) *plugin_go.Version {
+	if protobufVersion == "" {
+		protobufVersion = vars.DefaultProtocVersion
+	}
+	version, err := semver.ParseVersion(protobufVersion)
+	if err != nil {
+		return nil
+	}
+	compilerVersion := &plugin_go.Version{
+		Major: proto.Int32(int32(version.Major)),
+		Minor: proto.Int32(int32(version.Minor)),
+		Patch: proto.Int32(int32(version.Patch)),
+	}
+	if version.Prerelease != "" {
+		compilerVersion.Suffix = proto.String(version.Prerelease)
+	}
+	return compilerVersion
+}
+
+// getGenPluginPath returns the path to the plugin executable, or a failure
+// if the plugin cannot be found.
+func getGenPluginPath(genPlugin settings.GenPlugin) (string, *text.Failure, error) {
+	genPluginPath, err := genPlugin.GetPath()
+	if err != nil {
+		return "", nil, err
+	}
+	if genPluginPath != "" {
+		return genPluginPath, nil, nil
+	}
+	genPluginPath, err = exec.LookPath("protoc-gen-" + genPlugin.Name)
+	if err == nil {
+		return genPluginPath, nil, nil
+	}
+	if _, ok := builtinGenPluginNames[genPlugin.Name]; ok {
+		return "", &text.Failure{
+			Message: fmt.Sprintf("%s is a code generator built into protoc and cannot be run without protoc.", genPlugin.Name),
+		}, nil
+	}
+	return "", &text.Failure{
+		Message: fmt.Sprintf("protoc-gen-%s not found or is not executable.", genPlugin.Name),
+	}, nil
+}
+
+// execGenPlugin runs the plugin executable with the given CodeGeneratorRequest.
+//
+// If the plugin fails or writes to stderr, failures are returned.
+func (c *compiler) execGenPlugin(name string, genPluginPath string, request *plugin_go.CodeGeneratorRequest) (*plugin_go.CodeGeneratorResponse, []*text.Failure, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.logger.Debug("running plugin", zap.String("name", name), zap.String("path", genPluginPath), zap.Strings("files", request.FileToGenerate))
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	execCmd := exec.Command(genPluginPath)
+	execCmd.Stdin = bytes.NewReader(data)
+	execCmd.Stdout = stdout
+	execCmd.Stderr = stderr
+	runErr := execCmd.Run()
+	output := strings.TrimSpace(stderr.String())
+	if runErr != nil {
+		exitError, ok := runErr.(*exec.ExitError)
+		if !ok {
+			return nil, nil, runErr
+		}
+		message := fmt.Sprintf("protoc-gen-%s failed with status code %d.", name, exitError.ExitCode())
+		if output != "" {
+			message = fmt.Sprintf("protoc-gen-%s failed with status code %d: %s", name, exitError.ExitCode(), output)
+		}
+		return nil, newFailures(&text.Failure{Message: message}), nil
+	}
+	// We treat any output from a plugin as a failure, as we do with protoc.
+	// See https://github.com/uber/prototool/issues/128 for a full discussion.
+	if output != "" {
+		var failures []*text.Failure
+		for _, line := range strings.Split(output, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				failures = append(failures, &text.Failure{
+					Message: fmt.Sprintf("protoc-gen-%s: %s", name, line),
+				})
+			}
+		}
+		return nil, failures, nil
+	}
+	response := &plugin_go.CodeGeneratorResponse{}
+	if err := proto.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, newFailures(&text.Failure{
+			Message: fmt.Sprintf("protoc-gen-%s: could not parse CodeGeneratorResponse: %v", name, err),
+		}), nil
+	}
+	return response, nil, nil
+}
+
+// writeGenResponses writes the files of the genResponses for a single genRequest.
+//
+// As with protoc, if the output path ends in .zip or .jar, the files are written
+// to an archive at the output path instead of to the output directory.
+// Insertion points can refer to files generated by previous plugins, or
+// to files that already exist, for example files generated by protoc.
+func writeGenResponses(genResponses []*genResponse) ([]*text.Failure, error) {
+	var filePaths []string
+	filePathToContent := make(map[string]*bytes.Buffer)
+	for _, genResponse := range genResponses {
+		name := genResponse.genPlugin.Name
+		isZip := isZipOutputPath(genResponse.outputPath)
+		var zipFilePaths []string
+		zipFilePathToContent := make(map[string]*bytes.Buffer)
+		// the last file with a name, or "" if there is none
+		var lastFilePath string
+		for _, responseFile := range genResponse.files {
+			relFilePath := responseFile.GetName()
+			// an empty name means the content is a continuation of the previous file
+			if relFilePath == "" {
+				if lastFilePath == "" {
+					return newFailures(&text.Failure{
+						Message: fmt.Sprintf("protoc-gen-%s: first file in CodeGeneratorResponse has no name.", name),
+					}), nil
+				}
+				if isZip {
+					zipFilePathToContent[lastFilePath].WriteString(responseFile.GetContent())
+				} else {
+					filePathToContent[lastFilePath].WriteString(responseFile.GetContent())
+				}
+				continue
+			}
+			if filepath.IsAbs(relFilePath) || strings.HasPrefix(filepath.Clean(relFilePath), "..") {
+				return newFailures(&text.Failure{
+					Message: fmt.Sprintf("protoc-gen-%s: file name %s must be relative and within the output directory.", name, relFilePath),
+				}), nil
+			}
+			if isZip {
+				if responseFile.GetInsertionPoint() != "" {
+					return newFailures(&text.Failure{
+						Message: fmt.Sprintf("protoc-gen-%s: insertion point %q for %s cannot be used with a zip or jar output.", name, responseFile.GetInsertionPoint(), relFilePath),
+					}), nil
+				}
+				if _, ok := zipFilePathToContent[relFilePath]; ok {
+					return newFailures(newWriteFileTwiceFailure(name, relFilePath)), nil
+				}
+				zipFilePaths = append(zipFilePaths, relFilePath)
+				zipFilePathToContent[relFilePath] = bytes.NewBufferString(responseFile.GetContent())
+				lastFilePath = relFilePath
+				continue
+			}
+			filePath := filepath.Join(genResponse.outputPath, relFilePath)
+			if insertionPoint := responseFile.GetInsertionPoint(); insertionPoint != "" {
+				content, ok := filePathToContent[filePath]
+				if !ok {
+					data, err := ioutil.ReadFile(filePath)
+					if err != nil {
+						return newFailures(&text.Failure{
+							Message: fmt.Sprintf("protoc-gen-%s: tried to insert into file %s but it was not generated and could not be read.", name, relFilePath),
+						}), nil
+					}
+					content = bytes.NewBuffer(data)
+					filePaths = append(filePaths, filePath)
+					filePathToContent[filePath] = content
+				}
+				newContent, ok := insertAtInsertionPoint(content.Bytes(), insertionPoint, responseFile.GetContent())
+				if !ok {
+					return newFailures(&text.Failure{
+						Message: fmt.Sprintf("protoc-gen-%s: %s does not contain insertion point %s.", name, relFilePath, insertionPoint),
+					}), nil
+				}
+				filePathToContent[filePath] = bytes.NewBuffer(newContent)
+				// continuations of an insertion are not supported by protoc either
+				lastFilePath = ""
+				continue
+			}
+			if _, ok := filePathToContent[filePath]; ok {
+				return newFailures(newWriteFileTwiceFailure(name, relFilePath)), nil
+			}
+			filePaths = append(filePaths, filePath)
+			filePathToContent[filePath] = bytes.NewBufferString(responseFile.GetContent())
+			lastFilePath = filePath
+		}
+		if isZip {
+			if err := writeZipFile(genResponse.outputPath, zipFilePaths, zipFilePathToContent); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, filePath := range filePaths {
+		if err := os.MkdirAll(filepath.Dir(filePath), 0744); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(filePath, filePathToContent[filePath].Bytes(), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// insertAtInsertionPoint inserts the insertion above the line containing the given
+// insertion point, indenting every inserted line with the indentation of that line.
+//
+// Returns false if the insertion point cannot be found.
+func insertAtInsertionPoint(content []byte, insertionPoint string, insertion string) ([]byte, bool) {
+	index := bytes.Index(content, []byte(fmt.Sprintf(insertionPointFormat, insertionPoint)))
+	if index < 0 {
+		return nil, false
+	}
+	lineStart := bytes.LastIndexByte(content[:index], '\n') + 1
+	line := content[lineStart:index]
+	indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+	buffer := bytes.NewBuffer(nil)
+	_, _ = buffer.Write(content[:lineStart])
+	for _, insertionLine := range strings.SplitAfter(insertion, "\n") {
+		if insertionLine == "" {
+			continue
+		}
+		// do not indent empty lines
+		if insertionLine != "\n" {
+			_, _ = buffer.Write(indent)
+		}
+		_, _ = buffer.WriteString(insertionLine)
+	}
+	if insertion != "" && !strings.HasSuffix(insertion, "\n") {
+		_, _ = buffer.WriteString("\n")
+	}
+	_, _ = buffer.Write(content[lineStart:])
+	return buffer.Bytes(), true
+}
+
+func isZipOutputPath(outputPath string) bool {
+	switch filepath.Ext(outputPath) {
+	case ".zip", ".jar":
+		return true
+	default:
+		return false
+	}
+}
+
+func writeZipFile(outputPath string, filePaths []string, filePathToContent map[string]*bytes.Buffer) (retErr error) {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0744); err != nil {
+		return err
+	}
+	zipFile, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := zipFile.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	zipWriter := zip.NewWriter(zipFile)
+	if filepath.Ext(outputPath) == ".jar" {
+		// protoc writes this manifest for jar files
+		writer, err := zipWriter.Create("META-INF/MANIFEST.MF")
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write([]byte("Manifest-Version: 1.0\nCreated-By: 1.6.0 (protoc)\n\n")); err != nil {
+			return err
+		}
+	}
+	for _, filePath := range filePaths {
+		writer, err := zipWriter.Create(filepath.ToSlash(filePath))
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(filePathToContent[filePath].Bytes()); err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
+
+func newWriteFileTwiceFailure(name string, relFilePath string) *text.Failure {
+	return &text.Failure{
+		Message: fmt.Sprintf("protoc-gen-%s: tried to write the same file %s twice.", name, relFilePath),
+	}
+}
+
+func newFailures(failure *text.Failure) []*text.Failure {
+	if failure == nil {
+		return nil
+	}
+	return []*text.Failure{failure}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protoc
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/prototool/internal/settings"
+)
+
+func TestGetCompilerVersion(t *testing.T) {
+	assert.Equal(
+		t,
+		&plugin_go.Version{Major: proto.Int32(3), Minor: proto.Int32(11), Patch: proto.Int32(0)},
+		getCompilerVersion("3.11.0"),
+	)
+	assert.Equal(
+		t,
+		&plugin_go.Version{Major: proto.Int32(3), Minor: proto.Int32(0), Patch: proto.Int32(0), Suffix: proto.String("beta-2")},
+		getCompilerVersion("3.0.0-beta-2"),
+	)
+	assert.NotNil(t, getCompilerVersion(""))
+	assert.Nil(t, getCompilerVersion("3.11"))
+}
+
+func TestInsertAtInsertionPoint(t *testing.T) {
+	tests := []struct {
+		desc            string
+		content         string
+		insertionPoint  string
+		insertion       string
+		expectedContent string
+		expectNotFound  bool
+	}{
+		{
+			desc:            "top level",
+			content:         "package foo\n// @@protoc_insertion_point(imports)\n",
+			insertionPoint:  "imports",
+			insertion:       "import \"bar\"\n",
+			expectedContent: "package foo\nimport \"bar\"\n// @@protoc_insertion_point(imports)\n",
+		},
+		{
+			desc:            "indented",
+			content:         "class Foo {\n  // @@protoc_insertion_point(class_scope:Foo)\n}\n",
+			insertionPoint:  "class_scope:Foo",
+			insertion:       "int bar;\n\nint baz;",
+			expectedContent: "class Foo {\n  int bar;\n\n  int baz;\n  // @@protoc_insertion_point(class_scope:Foo)\n}\n",
+		},
+		{
+			desc:           "not found",
+			content:        "package foo\n// @@protoc_insertion_point(imports)\n",
+			insertionPoint: "builder_scope",
+			insertion:      "bar\n",
+			expectNotFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			content, ok := insertAtInsertionPoint([]byte(tt.content), tt.insertionPoint, tt.insertion)
+			if tt.expectNotFound {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedContent, string(content))
+		})
+	}
+}
+
+func TestWriteGenResponses(t *testing.T) {
+	tmpDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tmpDirPath) }()
+
+	failures, err := writeGenResponses(
+		[]*genResponse{
+			{
+				genPlugin:  settings.GenPlugin{Name: "foo"},
+				outputPath: tmpDirPath,
+				files: []*plugin_go.CodeGeneratorResponse_File{
+					{
+						Name:    proto.String("a/a.txt"),
+						Content: proto.String("one\n"),
+					},
+					{
+						Content: proto.String("// @@protoc_insertion_point(bar)\n"),
+					},
+				},
+			},
+			{
+				genPlugin:  settings.GenPlugin{Name: "bar"},
+				outputPath: tmpDirPath,
+				files: []*plugin_go.CodeGeneratorResponse_File{
+					{
+						Name:           proto.String("a/a.txt"),
+						InsertionPoint: proto.String("bar"),
+						Content:        proto.String("two\n"),
+					},
+				},
+			},
+			{
+				genPlugin:  settings.GenPlugin{Name: "baz"},
+				outputPath: filepath.Join(tmpDirPath, "baz.jar"),
+				files: []*plugin_go.CodeGeneratorResponse_File{
+					{
+						Name:    proto.String("b/b.txt"),
+						Content: proto.String("three\n"),
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, failures)
+	data, err := ioutil.ReadFile(filepath.Join(tmpDirPath, "a", "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo\n// @@protoc_insertion_point(bar)\n", string(data))
+	zipReader, err := zip.OpenReader(filepath.Join(tmpDirPath, "baz.jar"))
+	require.NoError(t, err)
+	defer func() { _ = zipReader.Close() }()
+	var zipFileNames []string
+	for _, zipFile := range zipReader.File {
+		zipFileNames = append(zipFileNames, zipFile.Name)
+	}
+	assert.Equal(t, []string{"META-INF/MANIFEST.MF", "b/b.txt"}, zipFileNames)
+
+	failures, err = writeGenResponses(
+		[]*genResponse{
+			{
+				genPlugin:  settings.GenPlugin{Name: "foo"},
+				outputPath: tmpDirPath,
+				files: []*plugin_go.CodeGeneratorResponse_File{
+					{
+						Name:           proto.String("c/c.txt"),
+						InsertionPoint: proto.String("bar"),
+						Content:        proto.String("two\n"),
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "protoc-gen-foo: tried to insert into file c/c.txt but it was not generated and could not be read.", failures[0].Message)
+}
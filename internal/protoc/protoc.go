@@ -56,7 +56,13 @@ type Downloader interface {
 	// If not downloaded, this downloads and caches protobuf. This is thread-safe.
 	WellKnownTypesIncludePath() (string, error)
 
-	// Delete any downloaded artifacts.
+	// Get the path to the compile cache.
+	//
+	// This is a sibling of the directory protobuf is downloaded to, and
+	// does not download protobuf.
+	CompileCachePath() (string, error)
+
+	// Delete any downloaded artifacts, including the compile cache.
 	//
 	// This is not thread-safe and no calls to other functions can be reliably
 	// made simultaneously.
@@ -161,7 +167,8 @@ type CompileResult struct {
 
 // Compiler compiles protobuf files.
 type Compiler interface {
-	// Compile the protobuf files with protoc.
+	// Compile the protobuf files with protoc, or in-process if the
+	// compile backend of the ProtoSet config is CompileBackendGo.
 	//
 	// If there are compile failures, they will be returned in the slice
 	// and there will be no error. The caller can determine if this is
@@ -172,6 +179,7 @@ type Compiler interface {
 	// Return the protoc commands that would be run on Compile.
 	//
 	// This will ignore the CompilerWithFileDescriptorSet option.
+	// This will return an error if the compile backend is CompileBackendGo.
 	ProtocCommands(*file.ProtoSet) ([]string, error)
 }
 
@@ -248,6 +256,18 @@ func CompilerWithFileDescriptorSetFullControl(includeImports bool, includeSource
 	}
 }
 
+// CompilerWithCompileCache says to reuse the FileDescriptorSets of directories
+// that were compiled before with the same inputs.
+//
+// The FileDescriptorSets are cached per directory within the cache path, and are
+// keyed by a hash of the .proto files, their transitive imports, the protoc version
+// and the include paths. This has no effect if CompilerWithGen is used.
+func CompilerWithCompileCache() CompilerOption {
+	return func(compiler *compiler) {
+		compiler.useCompileCache = true
+	}
+}
+
 // NewCompiler returns a new Compiler.
 func NewCompiler(options ...CompilerOption) Compiler {
 	return newCompiler(options...)
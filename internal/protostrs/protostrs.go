@@ -26,6 +26,7 @@
 package protostrs
 
 import (
+	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -166,6 +167,132 @@ func OBJCClassPrefix(packageName string) string {
 	}
 }
 
+// JSONName returns the default JSON name for a field given the field name.
+// This is the same as the json_name that protoc computes: underscores are
+// removed and the letter after each underscore is capitalized.
+func JSONName(fieldName string) string {
+	var builder strings.Builder
+	capitalizeNext := false
+	for _, r := range fieldName {
+		if r == '_' {
+			capitalizeNext = true
+			continue
+		}
+		if capitalizeNext && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		capitalizeNext = false
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// Stability is the stability level of a package.
+//
+// Stability levels are ordered from most to least stable, so a package
+// should only depend on packages with a Stability less than or equal
+// to its own.
+type Stability int
+
+const (
+	// StabilityStable is the stability level of packages without an
+	// alpha, beta or test version, such as "foo.v1".
+	StabilityStable Stability = iota
+	// StabilityBeta is the stability level of packages of the form
+	// "foo.vMAJORVERSIONbetaVERSION".
+	StabilityBeta
+	// StabilityAlpha is the stability level of packages of the form
+	// "foo.vMAJORVERSIONalphaVERSION".
+	StabilityAlpha
+	// StabilityTest is the stability level of packages of the form
+	// "foo.vMAJORVERSIONtestVERSION".
+	StabilityTest
+)
+
+var (
+	// AllStabilities are all Stabilities, from most to least stable.
+	AllStabilities = []Stability{
+		StabilityStable,
+		StabilityBeta,
+		StabilityAlpha,
+		StabilityTest,
+	}
+
+	_stabilityToString = map[Stability]string{
+		StabilityStable: "stable",
+		StabilityBeta:   "beta",
+		StabilityAlpha:  "alpha",
+		StabilityTest:   "test",
+	}
+	_stringToStability = map[string]Stability{
+		"stable": StabilityStable,
+		"beta":   StabilityBeta,
+		"alpha":  StabilityAlpha,
+		"test":   StabilityTest,
+	}
+)
+
+// String implements fmt.Stringer.
+func (s Stability) String() string {
+	if str, ok := _stabilityToString[s]; ok {
+		return str
+	}
+	return strconv.Itoa(int(s))
+}
+
+// ParseStability parses the Stability from the given string.
+//
+// Input is case-insensitive.
+func ParseStability(s string) (Stability, error) {
+	stability, ok := _stringToStability[strings.ToLower(s)]
+	if !ok {
+		return StabilityStable, fmt.Errorf("could not parse %s to a Stability", s)
+	}
+	return stability, nil
+}
+
+// PackageStability returns the stability level of the package.
+//
+// A package is StabilityAlpha, StabilityBeta or StabilityTest if it is of the
+// form "foo.vMAJORVERSIONalphaVERSION", "foo.vMAJORVERSIONbetaVERSION" or
+// "foo.vMAJORVERSIONtestVERSION" respectively, and StabilityStable otherwise.
+// Valid versions are >=1.
+func PackageStability(packageName string) Stability {
+	split := strings.Split(packageName, ".")
+	// A package named "vX" should not count as it is just a single package name,
+	// not a package name and a version.
+	if len(split) < 2 {
+		return StabilityStable
+	}
+	versionPart := split[len(split)-1]
+	if !strings.HasPrefix(versionPart, "v") {
+		return StabilityStable
+	}
+	for _, stability := range []Stability{StabilityAlpha, StabilityBeta, StabilityTest} {
+		versionStringSplit := strings.Split(versionPart[1:], stability.String())
+		if len(versionStringSplit) != 2 {
+			continue
+		}
+		majorVersion, err := strconv.ParseUint(versionStringSplit[0], 10, 64)
+		if err != nil || majorVersion == 0 {
+			return StabilityStable
+		}
+		stabilityVersion, err := strconv.ParseUint(versionStringSplit[1], 10, 64)
+		if err != nil || stabilityVersion == 0 {
+			return StabilityStable
+		}
+		return stability
+	}
+	return StabilityStable
+}
+
+// IsUnstablePackage returns true if the package name is of the form
+// "foo.vMAJORVERSIONalphaVERSION", "foo.vMAJORVERSIONbetaVERSION" or
+// "foo.vMAJORVERSIONtestVERSION". Valid versions are >=1.
+func IsUnstablePackage(packageName string) bool {
+	return PackageStability(packageName) != StabilityStable
+}
+
 // MajorBetaVersion extracts the major and beta version number from the package
 // name, if present. A package must be of the form "foo.vMAJORVERSION" or
 // "foo.vMAJORVERSIONbetaBETAVERSION" . Returns the major version, beta version
@@ -108,6 +108,58 @@ func TestOBJCClassPrefix(t *testing.T) {
 	assert.Equal(t, "GPX", OBJCClassPrefix("goo.par.baz.v1beta1"))
 }
 
+func TestJSONName(t *testing.T) {
+	assert.Equal(t, "", JSONName(""))
+	assert.Equal(t, "foo", JSONName("foo"))
+	assert.Equal(t, "fooBar", JSONName("foo_bar"))
+	assert.Equal(t, "fooBar", JSONName("fooBar"))
+	assert.Equal(t, "FooBar", JSONName("Foo_bar"))
+	assert.Equal(t, "fooBar", JSONName("foo__bar"))
+	assert.Equal(t, "foo1Bar", JSONName("foo1_bar"))
+	assert.Equal(t, "foo1bar", JSONName("foo_1bar"))
+	assert.Equal(t, "foo", JSONName("foo_"))
+}
+
+func TestPackageStability(t *testing.T) {
+	assert.Equal(t, StabilityAlpha, PackageStability("foo.v1alpha1"))
+	assert.Equal(t, StabilityBeta, PackageStability("foo.bar.v1beta1"))
+	assert.Equal(t, StabilityTest, PackageStability("foo.bar.v18test2"))
+	assert.Equal(t, StabilityStable, PackageStability(""))
+	assert.Equal(t, StabilityStable, PackageStability("foo"))
+	assert.Equal(t, StabilityStable, PackageStability("foo.v1"))
+	assert.Equal(t, StabilityStable, PackageStability("foo.v1alpha0"))
+	assert.Equal(t, StabilityStable, PackageStability("foo.v1gamma1"))
+}
+
+func TestParseStability(t *testing.T) {
+	for _, stability := range AllStabilities {
+		parsed, err := ParseStability(stability.String())
+		assert.NoError(t, err)
+		assert.Equal(t, stability, parsed)
+	}
+	stability, err := ParseStability("ALPHA")
+	assert.NoError(t, err)
+	assert.Equal(t, StabilityAlpha, stability)
+	_, err = ParseStability("gamma")
+	assert.Error(t, err)
+}
+
+func TestIsUnstablePackage(t *testing.T) {
+	assert.True(t, IsUnstablePackage("foo.v1alpha1"))
+	assert.True(t, IsUnstablePackage("foo.bar.v1beta1"))
+	assert.True(t, IsUnstablePackage("foo.bar.v18test2"))
+	assert.False(t, IsUnstablePackage(""))
+	assert.False(t, IsUnstablePackage("foo.v1"))
+	assert.False(t, IsUnstablePackage("foo.v0alpha1"))
+	assert.False(t, IsUnstablePackage("foo.v1alpha0"))
+	assert.False(t, IsUnstablePackage("foo.v1alpha"))
+	assert.False(t, IsUnstablePackage("foo.valpha1"))
+	assert.False(t, IsUnstablePackage("foo.V1alpha1"))
+	assert.False(t, IsUnstablePackage("foo.barv1alpha1"))
+	assert.False(t, IsUnstablePackage("v1alpha1"))
+	assert.False(t, IsUnstablePackage("foo.v1gamma1"))
+}
+
 func TestMajorBetaVersion(t *testing.T) {
 	testMajorBetaVersionValid(t, "foo.v1", 1, 0)
 	testMajorBetaVersionValid(t, "foo.bar.v1", 1, 0)
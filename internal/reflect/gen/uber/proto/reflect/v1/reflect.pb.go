@@ -32,18 +32,14 @@
 // A non-comprehensive list of excluded items:
 //
 // - Source code information.
-// - All options.
-// - Reserved ranges and names.
+// - Custom options, and any options not listed in the Options messages.
 // - Message field default values.
 // - Message field oneof indexes.
-// - Message field JSON names.
-// - Anything to do with extensions.
 //
 // Excluded items that should not be relevant at a package level:
 //
 // - Public vs non-public dependencies are all grouped into the same list.
 // - Syntax information.
-// - Fields vs extenstions.
 // - Extension ranges.
 
 package reflectv1
@@ -65,6 +61,40 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// OptimizeMode is the optimization mode of a file.
+//
+// The numbers match the FileOptions.OptimizeMode numbers.
+type FileOptions_OptimizeMode int32
+
+const (
+	FileOptions_OPTIMIZE_MODE_INVALID      FileOptions_OptimizeMode = 0
+	FileOptions_OPTIMIZE_MODE_SPEED        FileOptions_OptimizeMode = 1
+	FileOptions_OPTIMIZE_MODE_CODE_SIZE    FileOptions_OptimizeMode = 2
+	FileOptions_OPTIMIZE_MODE_LITE_RUNTIME FileOptions_OptimizeMode = 3
+)
+
+var FileOptions_OptimizeMode_name = map[int32]string{
+	0: "OPTIMIZE_MODE_INVALID",
+	1: "OPTIMIZE_MODE_SPEED",
+	2: "OPTIMIZE_MODE_CODE_SIZE",
+	3: "OPTIMIZE_MODE_LITE_RUNTIME",
+}
+
+var FileOptions_OptimizeMode_value = map[string]int32{
+	"OPTIMIZE_MODE_INVALID":      0,
+	"OPTIMIZE_MODE_SPEED":        1,
+	"OPTIMIZE_MODE_CODE_SIZE":    2,
+	"OPTIMIZE_MODE_LITE_RUNTIME": 3,
+}
+
+func (x FileOptions_OptimizeMode) String() string {
+	return proto.EnumName(FileOptions_OptimizeMode_name, int32(x))
+}
+
+func (FileOptions_OptimizeMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{3, 0}
+}
+
 // Label is the label of the message field.
 //
 // The numbers match the FieldDescriptorProto.Label numbers.
@@ -100,7 +130,7 @@ func (x MessageField_Label) String() string {
 }
 
 func (MessageField_Label) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{5, 0}
+	return fileDescriptor_4826d1b778a478a3, []int{11, 0}
 }
 
 // Type is the type of the message field.
@@ -179,7 +209,7 @@ func (x MessageField_Type) String() string {
 }
 
 func (MessageField_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{5, 1}
+	return fileDescriptor_4826d1b778a478a3, []int{11, 1}
 }
 
 // PackageSet is a set of Packages.
@@ -256,10 +286,19 @@ type Package struct {
 	// services contains the services within this package.
 	//
 	// These will be sorted by name.
-	Services             []*Service `protobuf:"bytes,5,rep,name=services,proto3" json:"services,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Services []*Service `protobuf:"bytes,5,rep,name=services,proto3" json:"services,omitempty"`
+	// files contains the files that make up this package.
+	//
+	// These will be sorted by name.
+	Files []*File `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
+	// extensions contains the top-level extensions within this package.
+	//
+	// These will be sorted by name.
+	// Nested extensions will be within Messages.
+	Extensions           []*Extension `protobuf:"bytes,7,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Package) Reset()         { *m = Package{} }
@@ -322,6 +361,197 @@ func (m *Package) GetServices() []*Service {
 	return nil
 }
 
+func (m *Package) GetFiles() []*File {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *Package) GetExtensions() []*Extension {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+// File describes a Protobuf file within a package.
+type File struct {
+	// name is the name of the file.
+	//
+	// This is the name relative to the include path, for example
+	// "uber/proto/reflect/v1/reflect.proto".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// options contains the file options.
+	Options              *FileOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *File) Reset()         { *m = File{} }
+func (m *File) String() string { return proto.CompactTextString(m) }
+func (*File) ProtoMessage()    {}
+func (*File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{2}
+}
+
+func (m *File) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_File.Unmarshal(m, b)
+}
+func (m *File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_File.Marshal(b, m, deterministic)
+}
+func (m *File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_File.Merge(m, src)
+}
+func (m *File) XXX_Size() int {
+	return xxx_messageInfo_File.Size(m)
+}
+func (m *File) XXX_DiscardUnknown() {
+	xxx_messageInfo_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_File proto.InternalMessageInfo
+
+func (m *File) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *File) GetOptions() *FileOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// FileOptions describes the options of a Protobuf file.
+type FileOptions struct {
+	// csharp_namespace is the value of the csharp_namespace option.
+	CsharpNamespace string `protobuf:"bytes,1,opt,name=csharp_namespace,json=csharpNamespace,proto3" json:"csharp_namespace,omitempty"`
+	// go_package is the value of the go_package option.
+	GoPackage string `protobuf:"bytes,2,opt,name=go_package,json=goPackage,proto3" json:"go_package,omitempty"`
+	// java_multiple_files is the value of the java_multiple_files option.
+	JavaMultipleFiles bool `protobuf:"varint,3,opt,name=java_multiple_files,json=javaMultipleFiles,proto3" json:"java_multiple_files,omitempty"`
+	// java_outer_classname is the value of the java_outer_classname option.
+	JavaOuterClassname string `protobuf:"bytes,4,opt,name=java_outer_classname,json=javaOuterClassname,proto3" json:"java_outer_classname,omitempty"`
+	// java_package is the value of the java_package option.
+	JavaPackage string `protobuf:"bytes,5,opt,name=java_package,json=javaPackage,proto3" json:"java_package,omitempty"`
+	// objc_class_prefix is the value of the objc_class_prefix option.
+	ObjcClassPrefix string `protobuf:"bytes,6,opt,name=objc_class_prefix,json=objcClassPrefix,proto3" json:"objc_class_prefix,omitempty"`
+	// php_namespace is the value of the php_namespace option.
+	PhpNamespace string `protobuf:"bytes,7,opt,name=php_namespace,json=phpNamespace,proto3" json:"php_namespace,omitempty"`
+	// optimize_for is the value of the optimize_for option.
+	//
+	// This will be OPTIMIZE_MODE_SPEED if the option is not set.
+	OptimizeFor FileOptions_OptimizeMode `protobuf:"varint,8,opt,name=optimize_for,json=optimizeFor,proto3,enum=uber.proto.reflect.v1.FileOptions_OptimizeMode" json:"optimize_for,omitempty"`
+	// cc_enable_arenas is the value of the cc_enable_arenas option.
+	CcEnableArenas bool `protobuf:"varint,9,opt,name=cc_enable_arenas,json=ccEnableArenas,proto3" json:"cc_enable_arenas,omitempty"`
+	// deprecated is the value of the deprecated option.
+	Deprecated           bool     `protobuf:"varint,10,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileOptions) Reset()         { *m = FileOptions{} }
+func (m *FileOptions) String() string { return proto.CompactTextString(m) }
+func (*FileOptions) ProtoMessage()    {}
+func (*FileOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{3}
+}
+
+func (m *FileOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileOptions.Unmarshal(m, b)
+}
+func (m *FileOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileOptions.Marshal(b, m, deterministic)
+}
+func (m *FileOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileOptions.Merge(m, src)
+}
+func (m *FileOptions) XXX_Size() int {
+	return xxx_messageInfo_FileOptions.Size(m)
+}
+func (m *FileOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileOptions proto.InternalMessageInfo
+
+func (m *FileOptions) GetCsharpNamespace() string {
+	if m != nil {
+		return m.CsharpNamespace
+	}
+	return ""
+}
+
+func (m *FileOptions) GetGoPackage() string {
+	if m != nil {
+		return m.GoPackage
+	}
+	return ""
+}
+
+func (m *FileOptions) GetJavaMultipleFiles() bool {
+	if m != nil {
+		return m.JavaMultipleFiles
+	}
+	return false
+}
+
+func (m *FileOptions) GetJavaOuterClassname() string {
+	if m != nil {
+		return m.JavaOuterClassname
+	}
+	return ""
+}
+
+func (m *FileOptions) GetJavaPackage() string {
+	if m != nil {
+		return m.JavaPackage
+	}
+	return ""
+}
+
+func (m *FileOptions) GetObjcClassPrefix() string {
+	if m != nil {
+		return m.ObjcClassPrefix
+	}
+	return ""
+}
+
+func (m *FileOptions) GetPhpNamespace() string {
+	if m != nil {
+		return m.PhpNamespace
+	}
+	return ""
+}
+
+func (m *FileOptions) GetOptimizeFor() FileOptions_OptimizeMode {
+	if m != nil {
+		return m.OptimizeFor
+	}
+	return FileOptions_OPTIMIZE_MODE_INVALID
+}
+
+func (m *FileOptions) GetCcEnableArenas() bool {
+	if m != nil {
+		return m.CcEnableArenas
+	}
+	return false
+}
+
+func (m *FileOptions) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
 // Enum describes a Protobuf enum.
 type Enum struct {
 	// name is the name of the enum.
@@ -332,7 +562,17 @@ type Enum struct {
 	// enum_values contains the enum values.
 	//
 	// These will be sorted by number.
-	EnumValues           []*EnumValue `protobuf:"bytes,2,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"`
+	EnumValues []*EnumValue `protobuf:"bytes,2,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"`
+	// reserved_ranges contains the reserved number ranges.
+	//
+	// These will be sorted by start.
+	ReservedRanges []*ReservedRange `protobuf:"bytes,3,rep,name=reserved_ranges,json=reservedRanges,proto3" json:"reserved_ranges,omitempty"`
+	// reserved_names contains the reserved value names.
+	//
+	// These will be sorted.
+	ReservedNames []string `protobuf:"bytes,4,rep,name=reserved_names,json=reservedNames,proto3" json:"reserved_names,omitempty"`
+	// options contains the enum options.
+	Options              *EnumOptions `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -342,7 +582,7 @@ func (m *Enum) Reset()         { *m = Enum{} }
 func (m *Enum) String() string { return proto.CompactTextString(m) }
 func (*Enum) ProtoMessage()    {}
 func (*Enum) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{2}
+	return fileDescriptor_4826d1b778a478a3, []int{4}
 }
 
 func (m *Enum) XXX_Unmarshal(b []byte) error {
@@ -377,22 +617,95 @@ func (m *Enum) GetEnumValues() []*EnumValue {
 	return nil
 }
 
+func (m *Enum) GetReservedRanges() []*ReservedRange {
+	if m != nil {
+		return m.ReservedRanges
+	}
+	return nil
+}
+
+func (m *Enum) GetReservedNames() []string {
+	if m != nil {
+		return m.ReservedNames
+	}
+	return nil
+}
+
+func (m *Enum) GetOptions() *EnumOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// EnumOptions describes the options of a Protobuf enum.
+type EnumOptions struct {
+	// allow_alias is the value of the allow_alias option.
+	AllowAlias bool `protobuf:"varint,1,opt,name=allow_alias,json=allowAlias,proto3" json:"allow_alias,omitempty"`
+	// deprecated is the value of the deprecated option.
+	Deprecated           bool     `protobuf:"varint,2,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnumOptions) Reset()         { *m = EnumOptions{} }
+func (m *EnumOptions) String() string { return proto.CompactTextString(m) }
+func (*EnumOptions) ProtoMessage()    {}
+func (*EnumOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{5}
+}
+
+func (m *EnumOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnumOptions.Unmarshal(m, b)
+}
+func (m *EnumOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnumOptions.Marshal(b, m, deterministic)
+}
+func (m *EnumOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnumOptions.Merge(m, src)
+}
+func (m *EnumOptions) XXX_Size() int {
+	return xxx_messageInfo_EnumOptions.Size(m)
+}
+func (m *EnumOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnumOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnumOptions proto.InternalMessageInfo
+
+func (m *EnumOptions) GetAllowAlias() bool {
+	if m != nil {
+		return m.AllowAlias
+	}
+	return false
+}
+
+func (m *EnumOptions) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
 // EnumValue describes a Protobuf enum value.
 type EnumValue struct {
 	// name contains the value name.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// number contains the value number.
-	Number               int32    `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Number int32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// options contains the enum value options.
+	Options              *EnumValueOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *EnumValue) Reset()         { *m = EnumValue{} }
 func (m *EnumValue) String() string { return proto.CompactTextString(m) }
 func (*EnumValue) ProtoMessage()    {}
 func (*EnumValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{3}
+	return fileDescriptor_4826d1b778a478a3, []int{6}
 }
 
 func (m *EnumValue) XXX_Unmarshal(b []byte) error {
@@ -427,6 +740,108 @@ func (m *EnumValue) GetNumber() int32 {
 	return 0
 }
 
+func (m *EnumValue) GetOptions() *EnumValueOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// EnumValueOptions describes the options of a Protobuf enum value.
+type EnumValueOptions struct {
+	// deprecated is the value of the deprecated option.
+	Deprecated           bool     `protobuf:"varint,1,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnumValueOptions) Reset()         { *m = EnumValueOptions{} }
+func (m *EnumValueOptions) String() string { return proto.CompactTextString(m) }
+func (*EnumValueOptions) ProtoMessage()    {}
+func (*EnumValueOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{7}
+}
+
+func (m *EnumValueOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnumValueOptions.Unmarshal(m, b)
+}
+func (m *EnumValueOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnumValueOptions.Marshal(b, m, deterministic)
+}
+func (m *EnumValueOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnumValueOptions.Merge(m, src)
+}
+func (m *EnumValueOptions) XXX_Size() int {
+	return xxx_messageInfo_EnumValueOptions.Size(m)
+}
+func (m *EnumValueOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnumValueOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnumValueOptions proto.InternalMessageInfo
+
+func (m *EnumValueOptions) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
+// ReservedRange describes a reserved range of numbers for a Protobuf
+// message or enum.
+type ReservedRange struct {
+	// start is the first number in the range.
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the last number in the range.
+	//
+	// This is inclusive for both messages and enums, which differs from
+	// DescriptorProto.ReservedRange where end is exclusive.
+	End                  int32    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReservedRange) Reset()         { *m = ReservedRange{} }
+func (m *ReservedRange) String() string { return proto.CompactTextString(m) }
+func (*ReservedRange) ProtoMessage()    {}
+func (*ReservedRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{8}
+}
+
+func (m *ReservedRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReservedRange.Unmarshal(m, b)
+}
+func (m *ReservedRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReservedRange.Marshal(b, m, deterministic)
+}
+func (m *ReservedRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReservedRange.Merge(m, src)
+}
+func (m *ReservedRange) XXX_Size() int {
+	return xxx_messageInfo_ReservedRange.Size(m)
+}
+func (m *ReservedRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReservedRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReservedRange proto.InternalMessageInfo
+
+func (m *ReservedRange) GetStart() int32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ReservedRange) GetEnd() int32 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
 // Message describes a Protobuf message.
 type Message struct {
 	// name is the name of the message.
@@ -450,17 +865,33 @@ type Message struct {
 	// nested_enums contains the enums directed nested on this message.
 	//
 	// These will be sorted by name.
-	NestedEnums          []*Enum  `protobuf:"bytes,5,rep,name=nested_enums,json=nestedEnums,proto3" json:"nested_enums,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	NestedEnums []*Enum `protobuf:"bytes,5,rep,name=nested_enums,json=nestedEnums,proto3" json:"nested_enums,omitempty"`
+	// reserved_ranges contains the reserved field number ranges.
+	//
+	// These will be sorted by start.
+	ReservedRanges []*ReservedRange `protobuf:"bytes,6,rep,name=reserved_ranges,json=reservedRanges,proto3" json:"reserved_ranges,omitempty"`
+	// reserved_names contains the reserved field names.
+	//
+	// These will be sorted.
+	ReservedNames []string `protobuf:"bytes,7,rep,name=reserved_names,json=reservedNames,proto3" json:"reserved_names,omitempty"`
+	// options contains the message options.
+	Options *MessageOptions `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
+	// nested_extensions contains the extensions declared directly within
+	// this message.
+	//
+	// These are not necessarily extensions of this message.
+	// These will be sorted by name.
+	NestedExtensions     []*Extension `protobuf:"bytes,9,rep,name=nested_extensions,json=nestedExtensions,proto3" json:"nested_extensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{4}
+	return fileDescriptor_4826d1b778a478a3, []int{9}
 }
 
 func (m *Message) XXX_Unmarshal(b []byte) error {
@@ -516,6 +947,93 @@ func (m *Message) GetNestedEnums() []*Enum {
 	return nil
 }
 
+func (m *Message) GetReservedRanges() []*ReservedRange {
+	if m != nil {
+		return m.ReservedRanges
+	}
+	return nil
+}
+
+func (m *Message) GetReservedNames() []string {
+	if m != nil {
+		return m.ReservedNames
+	}
+	return nil
+}
+
+func (m *Message) GetOptions() *MessageOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *Message) GetNestedExtensions() []*Extension {
+	if m != nil {
+		return m.NestedExtensions
+	}
+	return nil
+}
+
+// MessageOptions describes the options of a Protobuf message.
+type MessageOptions struct {
+	// message_set_wire_format is the value of the message_set_wire_format option.
+	MessageSetWireFormat bool `protobuf:"varint,1,opt,name=message_set_wire_format,json=messageSetWireFormat,proto3" json:"message_set_wire_format,omitempty"`
+	// deprecated is the value of the deprecated option.
+	Deprecated bool `protobuf:"varint,2,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// map_entry is the value of the map_entry option.
+	MapEntry             bool     `protobuf:"varint,3,opt,name=map_entry,json=mapEntry,proto3" json:"map_entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MessageOptions) Reset()         { *m = MessageOptions{} }
+func (m *MessageOptions) String() string { return proto.CompactTextString(m) }
+func (*MessageOptions) ProtoMessage()    {}
+func (*MessageOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{10}
+}
+
+func (m *MessageOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MessageOptions.Unmarshal(m, b)
+}
+func (m *MessageOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MessageOptions.Marshal(b, m, deterministic)
+}
+func (m *MessageOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageOptions.Merge(m, src)
+}
+func (m *MessageOptions) XXX_Size() int {
+	return xxx_messageInfo_MessageOptions.Size(m)
+}
+func (m *MessageOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_MessageOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MessageOptions proto.InternalMessageInfo
+
+func (m *MessageOptions) GetMessageSetWireFormat() bool {
+	if m != nil {
+		return m.MessageSetWireFormat
+	}
+	return false
+}
+
+func (m *MessageOptions) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
+func (m *MessageOptions) GetMapEntry() bool {
+	if m != nil {
+		return m.MapEntry
+	}
+	return false
+}
+
 // MessageField describes a Protobuf message field.
 type MessageField struct {
 	// name is the name of the message field.
@@ -532,17 +1050,30 @@ type MessageField struct {
 	// This does not include the prefix '.' found in the traditional package
 	// fully-qualified name. If this is a nested message, the parent messages
 	// will be part of the name.
-	TypeName             string   `protobuf:"bytes,5,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TypeName string `protobuf:"bytes,5,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	// json_name is the JSON name of the message field.
+	//
+	// This will be the value of the json_name option if set, otherwise the
+	// lowerCamelCase name that protoc computes from the field name.
+	JsonName string `protobuf:"bytes,6,opt,name=json_name,json=jsonName,proto3" json:"json_name,omitempty"`
+	// packed is whether or not the message field is encoded as packed.
+	//
+	// This takes into account the syntax of the file, that is repeated
+	// scalar numeric fields in proto3 files are packed unless the packed
+	// option is set to false.
+	Packed bool `protobuf:"varint,7,opt,name=packed,proto3" json:"packed,omitempty"`
+	// options contains the message field options.
+	Options              *MessageFieldOptions `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *MessageField) Reset()         { *m = MessageField{} }
 func (m *MessageField) String() string { return proto.CompactTextString(m) }
 func (*MessageField) ProtoMessage()    {}
 func (*MessageField) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{5}
+	return fileDescriptor_4826d1b778a478a3, []int{11}
 }
 
 func (m *MessageField) XXX_Unmarshal(b []byte) error {
@@ -598,6 +1129,185 @@ func (m *MessageField) GetTypeName() string {
 	return ""
 }
 
+func (m *MessageField) GetJsonName() string {
+	if m != nil {
+		return m.JsonName
+	}
+	return ""
+}
+
+func (m *MessageField) GetPacked() bool {
+	if m != nil {
+		return m.Packed
+	}
+	return false
+}
+
+func (m *MessageField) GetOptions() *MessageFieldOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// MessageFieldOptions describes the options of a Protobuf message field.
+type MessageFieldOptions struct {
+	// deprecated is the value of the deprecated option.
+	Deprecated bool `protobuf:"varint,1,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// lazy is the value of the lazy option.
+	Lazy                 bool     `protobuf:"varint,2,opt,name=lazy,proto3" json:"lazy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MessageFieldOptions) Reset()         { *m = MessageFieldOptions{} }
+func (m *MessageFieldOptions) String() string { return proto.CompactTextString(m) }
+func (*MessageFieldOptions) ProtoMessage()    {}
+func (*MessageFieldOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{12}
+}
+
+func (m *MessageFieldOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MessageFieldOptions.Unmarshal(m, b)
+}
+func (m *MessageFieldOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MessageFieldOptions.Marshal(b, m, deterministic)
+}
+func (m *MessageFieldOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MessageFieldOptions.Merge(m, src)
+}
+func (m *MessageFieldOptions) XXX_Size() int {
+	return xxx_messageInfo_MessageFieldOptions.Size(m)
+}
+func (m *MessageFieldOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_MessageFieldOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MessageFieldOptions proto.InternalMessageInfo
+
+func (m *MessageFieldOptions) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
+func (m *MessageFieldOptions) GetLazy() bool {
+	if m != nil {
+		return m.Lazy
+	}
+	return false
+}
+
+// Extension describes a Protobuf extension.
+type Extension struct {
+	// name is the name of the extension.
+	//
+	// If this is a nested extension, this will not contain the name of the
+	// encapsulating message.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// number is the number of the extension.
+	Number int32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// extendee_type_name is the fully-qualified name of the message type
+	// that is extended, for example "google.protobuf.FieldOptions".
+	//
+	// This does not include the prefix '.' found in the traditional package
+	// fully-qualified name. If this is a nested message, the parent messages
+	// will be part of the name.
+	ExtendeeTypeName string `protobuf:"bytes,3,opt,name=extendee_type_name,json=extendeeTypeName,proto3" json:"extendee_type_name,omitempty"`
+	// label is the label of the extension.
+	Label MessageField_Label `protobuf:"varint,4,opt,name=label,proto3,enum=uber.proto.reflect.v1.MessageField_Label" json:"label,omitempty"`
+	// type is the type of the extension.
+	Type MessageField_Type `protobuf:"varint,5,opt,name=type,proto3,enum=uber.proto.reflect.v1.MessageField_Type" json:"type,omitempty"`
+	// type_name is the fully-qualified name of the type for message and enum
+	// extensions.
+	//
+	// This does not include the prefix '.' found in the traditional package
+	// fully-qualified name. If this is a nested message or enum, the parent
+	// messages will be part of the name.
+	TypeName string `protobuf:"bytes,6,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	// options contains the extension options.
+	Options              *MessageFieldOptions `protobuf:"bytes,7,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Extension) Reset()         { *m = Extension{} }
+func (m *Extension) String() string { return proto.CompactTextString(m) }
+func (*Extension) ProtoMessage()    {}
+func (*Extension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{13}
+}
+
+func (m *Extension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Extension.Unmarshal(m, b)
+}
+func (m *Extension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Extension.Marshal(b, m, deterministic)
+}
+func (m *Extension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Extension.Merge(m, src)
+}
+func (m *Extension) XXX_Size() int {
+	return xxx_messageInfo_Extension.Size(m)
+}
+func (m *Extension) XXX_DiscardUnknown() {
+	xxx_messageInfo_Extension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Extension proto.InternalMessageInfo
+
+func (m *Extension) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Extension) GetNumber() int32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Extension) GetExtendeeTypeName() string {
+	if m != nil {
+		return m.ExtendeeTypeName
+	}
+	return ""
+}
+
+func (m *Extension) GetLabel() MessageField_Label {
+	if m != nil {
+		return m.Label
+	}
+	return MessageField_LABEL_INVALID
+}
+
+func (m *Extension) GetType() MessageField_Type {
+	if m != nil {
+		return m.Type
+	}
+	return MessageField_TYPE_INVALID
+}
+
+func (m *Extension) GetTypeName() string {
+	if m != nil {
+		return m.TypeName
+	}
+	return ""
+}
+
+func (m *Extension) GetOptions() *MessageFieldOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 // MessageOneof describes a Protobuf message oneof.
 type MessageOneof struct {
 	// name is the name of the message oneof.
@@ -616,7 +1326,7 @@ func (m *MessageOneof) Reset()         { *m = MessageOneof{} }
 func (m *MessageOneof) String() string { return proto.CompactTextString(m) }
 func (*MessageOneof) ProtoMessage()    {}
 func (*MessageOneof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{6}
+	return fileDescriptor_4826d1b778a478a3, []int{14}
 }
 
 func (m *MessageOneof) XXX_Unmarshal(b []byte) error {
@@ -658,17 +1368,19 @@ type Service struct {
 	// service_methods are the service methods.
 	//
 	// These will be sorted by name.
-	ServiceMethods       []*ServiceMethod `protobuf:"bytes,2,rep,name=service_methods,json=serviceMethods,proto3" json:"service_methods,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	ServiceMethods []*ServiceMethod `protobuf:"bytes,2,rep,name=service_methods,json=serviceMethods,proto3" json:"service_methods,omitempty"`
+	// options contains the service options.
+	Options              *ServiceOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *Service) Reset()         { *m = Service{} }
 func (m *Service) String() string { return proto.CompactTextString(m) }
 func (*Service) ProtoMessage()    {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{7}
+	return fileDescriptor_4826d1b778a478a3, []int{15}
 }
 
 func (m *Service) XXX_Unmarshal(b []byte) error {
@@ -703,6 +1415,54 @@ func (m *Service) GetServiceMethods() []*ServiceMethod {
 	return nil
 }
 
+func (m *Service) GetOptions() *ServiceOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// ServiceOptions describes the options of a Protobuf service.
+type ServiceOptions struct {
+	// deprecated is the value of the deprecated option.
+	Deprecated           bool     `protobuf:"varint,1,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceOptions) Reset()         { *m = ServiceOptions{} }
+func (m *ServiceOptions) String() string { return proto.CompactTextString(m) }
+func (*ServiceOptions) ProtoMessage()    {}
+func (*ServiceOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{16}
+}
+
+func (m *ServiceOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceOptions.Unmarshal(m, b)
+}
+func (m *ServiceOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceOptions.Marshal(b, m, deterministic)
+}
+func (m *ServiceOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceOptions.Merge(m, src)
+}
+func (m *ServiceOptions) XXX_Size() int {
+	return xxx_messageInfo_ServiceOptions.Size(m)
+}
+func (m *ServiceOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceOptions proto.InternalMessageInfo
+
+func (m *ServiceOptions) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
 // ServiceMethod describes a Protobuf service method.
 type ServiceMethod struct {
 	// name is the name of the service method.
@@ -724,17 +1484,19 @@ type ServiceMethod struct {
 	ClientStreaming bool `protobuf:"varint,4,opt,name=client_streaming,json=clientStreaming,proto3" json:"client_streaming,omitempty"`
 	// server_streaming representing whether this is a server-side streaming
 	// method
-	ServerStreaming      bool     `protobuf:"varint,5,opt,name=server_streaming,json=serverStreaming,proto3" json:"server_streaming,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ServerStreaming bool `protobuf:"varint,5,opt,name=server_streaming,json=serverStreaming,proto3" json:"server_streaming,omitempty"`
+	// options contains the service method options.
+	Options              *ServiceMethodOptions `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ServiceMethod) Reset()         { *m = ServiceMethod{} }
 func (m *ServiceMethod) String() string { return proto.CompactTextString(m) }
 func (*ServiceMethod) ProtoMessage()    {}
 func (*ServiceMethod) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4826d1b778a478a3, []int{8}
+	return fileDescriptor_4826d1b778a478a3, []int{17}
 }
 
 func (m *ServiceMethod) XXX_Unmarshal(b []byte) error {
@@ -790,18 +1552,77 @@ func (m *ServiceMethod) GetServerStreaming() bool {
 	return false
 }
 
+func (m *ServiceMethod) GetOptions() *ServiceMethodOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// ServiceMethodOptions describes the options of a Protobuf service method.
+type ServiceMethodOptions struct {
+	// deprecated is the value of the deprecated option.
+	Deprecated           bool     `protobuf:"varint,1,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceMethodOptions) Reset()         { *m = ServiceMethodOptions{} }
+func (m *ServiceMethodOptions) String() string { return proto.CompactTextString(m) }
+func (*ServiceMethodOptions) ProtoMessage()    {}
+func (*ServiceMethodOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4826d1b778a478a3, []int{18}
+}
+
+func (m *ServiceMethodOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceMethodOptions.Unmarshal(m, b)
+}
+func (m *ServiceMethodOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceMethodOptions.Marshal(b, m, deterministic)
+}
+func (m *ServiceMethodOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceMethodOptions.Merge(m, src)
+}
+func (m *ServiceMethodOptions) XXX_Size() int {
+	return xxx_messageInfo_ServiceMethodOptions.Size(m)
+}
+func (m *ServiceMethodOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceMethodOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceMethodOptions proto.InternalMessageInfo
+
+func (m *ServiceMethodOptions) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterEnum("uber.proto.reflect.v1.FileOptions_OptimizeMode", FileOptions_OptimizeMode_name, FileOptions_OptimizeMode_value)
 	proto.RegisterEnum("uber.proto.reflect.v1.MessageField_Label", MessageField_Label_name, MessageField_Label_value)
 	proto.RegisterEnum("uber.proto.reflect.v1.MessageField_Type", MessageField_Type_name, MessageField_Type_value)
 	proto.RegisterType((*PackageSet)(nil), "uber.proto.reflect.v1.PackageSet")
 	proto.RegisterType((*Package)(nil), "uber.proto.reflect.v1.Package")
+	proto.RegisterType((*File)(nil), "uber.proto.reflect.v1.File")
+	proto.RegisterType((*FileOptions)(nil), "uber.proto.reflect.v1.FileOptions")
 	proto.RegisterType((*Enum)(nil), "uber.proto.reflect.v1.Enum")
+	proto.RegisterType((*EnumOptions)(nil), "uber.proto.reflect.v1.EnumOptions")
 	proto.RegisterType((*EnumValue)(nil), "uber.proto.reflect.v1.EnumValue")
+	proto.RegisterType((*EnumValueOptions)(nil), "uber.proto.reflect.v1.EnumValueOptions")
+	proto.RegisterType((*ReservedRange)(nil), "uber.proto.reflect.v1.ReservedRange")
 	proto.RegisterType((*Message)(nil), "uber.proto.reflect.v1.Message")
+	proto.RegisterType((*MessageOptions)(nil), "uber.proto.reflect.v1.MessageOptions")
 	proto.RegisterType((*MessageField)(nil), "uber.proto.reflect.v1.MessageField")
+	proto.RegisterType((*MessageFieldOptions)(nil), "uber.proto.reflect.v1.MessageFieldOptions")
+	proto.RegisterType((*Extension)(nil), "uber.proto.reflect.v1.Extension")
 	proto.RegisterType((*MessageOneof)(nil), "uber.proto.reflect.v1.MessageOneof")
 	proto.RegisterType((*Service)(nil), "uber.proto.reflect.v1.Service")
+	proto.RegisterType((*ServiceOptions)(nil), "uber.proto.reflect.v1.ServiceOptions")
 	proto.RegisterType((*ServiceMethod)(nil), "uber.proto.reflect.v1.ServiceMethod")
+	proto.RegisterType((*ServiceMethodOptions)(nil), "uber.proto.reflect.v1.ServiceMethodOptions")
 }
 
 func init() {
@@ -809,58 +1630,102 @@ func init() {
 }
 
 var fileDescriptor_4826d1b778a478a3 = []byte{
-	// 833 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x6f, 0xeb, 0x44,
-	0x14, 0xc5, 0x4e, 0xdc, 0x36, 0x37, 0x5f, 0xd3, 0x91, 0x1e, 0x32, 0x7a, 0x12, 0x8a, 0x5c, 0x16,
-	0x29, 0x42, 0xa9, 0x92, 0x56, 0x45, 0x42, 0x08, 0x94, 0x28, 0x6e, 0x08, 0xca, 0x87, 0x99, 0x38,
-	0x11, 0x0f, 0x15, 0x59, 0x69, 0x3a, 0x7d, 0x54, 0xd8, 0x4e, 0xf0, 0x38, 0x91, 0xfa, 0x77, 0x58,
-	0xf2, 0x23, 0x58, 0x23, 0xb6, 0xfc, 0x14, 0x56, 0xec, 0xd0, 0xcc, 0xd8, 0x53, 0x07, 0x99, 0xbc,
-	0xd7, 0x95, 0x67, 0xce, 0x3d, 0xe7, 0xde, 0x33, 0xe3, 0x7b, 0x07, 0xce, 0xb6, 0x77, 0x34, 0xba,
-	0xd8, 0x44, 0xeb, 0x78, 0x7d, 0x11, 0xd1, 0x07, 0x9f, 0xae, 0xe2, 0x8b, 0x5d, 0x3b, 0x5d, 0xb6,
-	0x44, 0x00, 0xbf, 0xe2, 0x24, 0xb9, 0x6e, 0xa5, 0x91, 0x5d, 0xdb, 0xfa, 0x06, 0xc0, 0x59, 0xae,
-	0x7e, 0x5e, 0xbe, 0xa5, 0x33, 0x1a, 0xe3, 0x2f, 0xe0, 0x64, 0x23, 0x77, 0xcc, 0xd4, 0x1a, 0x85,
-	0x66, 0xb9, 0xf3, 0x71, 0x2b, 0x57, 0xd7, 0x4a, 0x44, 0x44, 0xf1, 0xad, 0xbf, 0x35, 0x38, 0x4e,
-	0x50, 0x8c, 0xa1, 0x18, 0x2e, 0x03, 0x6a, 0x6a, 0x0d, 0xad, 0x59, 0x22, 0x62, 0x8d, 0xcf, 0x01,
-	0xdd, 0xd3, 0x0d, 0x0d, 0xef, 0x69, 0xb8, 0x7a, 0xf2, 0x38, 0xc4, 0x4c, 0xbd, 0x51, 0x68, 0x96,
-	0x48, 0xfd, 0x19, 0x9f, 0x70, 0x18, 0xb7, 0xc1, 0xa0, 0xe1, 0x36, 0x60, 0x66, 0x41, 0x78, 0x78,
-	0xfd, 0x3f, 0x1e, 0xec, 0x70, 0x1b, 0x10, 0xc9, 0xe4, 0xce, 0x03, 0xca, 0x98, 0x70, 0x5e, 0x3c,
-	0xe8, 0x7c, 0x2c, 0x69, 0x44, 0xf1, 0xb9, 0x96, 0xd1, 0x68, 0xf7, 0xb8, 0xa2, 0xcc, 0x34, 0x0e,
-	0x6a, 0x67, 0x92, 0x46, 0x14, 0xdf, 0xfa, 0x11, 0x8a, 0xdc, 0x46, 0xee, 0x89, 0xbb, 0x50, 0xe6,
-	0xe6, 0xbc, 0xdd, 0xd2, 0xdf, 0x26, 0x87, 0x2d, 0x77, 0x1a, 0x07, 0x0e, 0xb3, 0xe0, 0x44, 0x02,
-	0x34, 0x5d, 0x32, 0xeb, 0x73, 0x28, 0xa9, 0x40, 0x6e, 0x8d, 0x0f, 0xe1, 0x28, 0xdc, 0x06, 0x77,
-	0x34, 0x32, 0xf5, 0x86, 0xd6, 0x34, 0x48, 0xb2, 0xb3, 0x7e, 0xd7, 0xe1, 0x38, 0x39, 0x69, 0xae,
-	0xee, 0x5b, 0xa8, 0x25, 0xe7, 0xf7, 0x1e, 0x1e, 0xa9, 0x7f, 0x9f, 0xda, 0x3b, 0x3b, 0x7c, 0x6b,
-	0x37, 0x9c, 0x4b, 0xaa, 0x41, 0x66, 0xc7, 0xb2, 0xb9, 0xd6, 0x21, 0x5d, 0x3f, 0xa4, 0xff, 0xed,
-	0x1d, 0xb9, 0xa6, 0x9c, 0xab, 0x72, 0x89, 0x1d, 0xc3, 0x03, 0xa8, 0x87, 0x94, 0xc5, 0xf4, 0xde,
-	0x7b, 0xe1, 0xef, 0xac, 0x49, 0xd9, 0x38, 0xfd, 0xa9, 0x5f, 0x41, 0x25, 0x49, 0x24, 0x5b, 0xc9,
-	0x78, 0x77, 0x2b, 0x95, 0xa5, 0x80, 0xaf, 0x99, 0xf5, 0x4f, 0x11, 0x2a, 0xd9, 0x43, 0xbf, 0xe4,
-	0xf6, 0xf1, 0xd7, 0x60, 0xf8, 0xcb, 0x3b, 0xea, 0x9b, 0x85, 0x86, 0xd6, 0xac, 0x75, 0xce, 0xdf,
-	0xe3, 0x52, 0x5b, 0x23, 0x2e, 0x20, 0x52, 0x87, 0xbf, 0x84, 0x62, 0xfc, 0xb4, 0xa1, 0x66, 0x51,
-	0xe8, 0x9b, 0xef, 0xa3, 0x77, 0x9f, 0x36, 0x94, 0x08, 0x15, 0x7e, 0x0d, 0x25, 0xfe, 0x15, 0x43,
-	0x66, 0x1a, 0xc2, 0xef, 0x09, 0x07, 0xf8, 0x74, 0x59, 0x0b, 0x30, 0x44, 0x29, 0x7c, 0x0a, 0xd5,
-	0x51, 0xb7, 0x67, 0x8f, 0xbc, 0xe1, 0x64, 0xd1, 0x1d, 0x0d, 0xfb, 0xe8, 0x03, 0x8c, 0xa1, 0x26,
-	0xa1, 0xa9, 0xe3, 0x0e, 0xa7, 0x93, 0xee, 0x08, 0x69, 0xcf, 0x18, 0xb1, 0xbf, 0x9b, 0x0f, 0x89,
-	0xdd, 0x47, 0x7a, 0x16, 0x73, 0xec, 0xae, 0x6b, 0xf7, 0x51, 0xc1, 0xfa, 0x43, 0x87, 0x22, 0xf7,
-	0x80, 0x11, 0x54, 0xdc, 0x37, 0x8e, 0x9d, 0x49, 0x5b, 0x87, 0xb2, 0x40, 0xfa, 0xd3, 0x79, 0x6f,
-	0x64, 0x23, 0x0d, 0xd7, 0x00, 0x04, 0x70, 0x33, 0x9a, 0x76, 0x5d, 0xa4, 0xab, 0xfd, 0x70, 0xe2,
-	0x5e, 0x5f, 0xa1, 0x82, 0x12, 0xcc, 0x25, 0x50, 0xcc, 0x12, 0x2e, 0x3b, 0xc8, 0x50, 0x35, 0x6e,
-	0x86, 0xdf, 0xdb, 0xfd, 0xeb, 0x2b, 0x74, 0xb4, 0x8f, 0x5c, 0x76, 0xd0, 0x31, 0xae, 0x42, 0x49,
-	0x20, 0xbd, 0xe9, 0x74, 0x84, 0x4e, 0x54, 0xce, 0x99, 0x4b, 0x86, 0x93, 0x01, 0x2a, 0xa9, 0x9c,
-	0x03, 0x32, 0x9d, 0x3b, 0x08, 0x54, 0x86, 0xb1, 0x3d, 0x9b, 0x75, 0x07, 0x36, 0x2a, 0x2b, 0x46,
-	0xef, 0x8d, 0x6b, 0xcf, 0x50, 0x65, 0xcf, 0xd6, 0x65, 0x07, 0x55, 0x55, 0x09, 0x7b, 0x32, 0x1f,
-	0xa3, 0x1a, 0xbf, 0x51, 0x59, 0x22, 0x35, 0x51, 0xff, 0x0f, 0x74, 0x7d, 0x85, 0xd0, 0xb3, 0x11,
-	0x99, 0xe5, 0x74, 0x0f, 0xb8, 0xbe, 0x42, 0xd8, 0x1a, 0xa8, 0xd6, 0x13, 0x53, 0x91, 0xdb, 0x7a,
-	0x67, 0x50, 0x15, 0x83, 0xeb, 0xc9, 0x96, 0x93, 0xf3, 0x6b, 0x90, 0x8a, 0x00, 0x27, 0x12, 0xb3,
-	0x7c, 0x38, 0x4e, 0x9e, 0xac, 0xdc, 0x1c, 0x63, 0xa8, 0x27, 0x0f, 0x99, 0x17, 0xd0, 0xf8, 0xa7,
-	0xb5, 0x7a, 0x05, 0x3e, 0x39, 0xfc, 0xfe, 0x8d, 0x05, 0x99, 0xd4, 0x58, 0x76, 0xcb, 0xac, 0xbf,
-	0x34, 0xa8, 0xee, 0x31, 0x72, 0x8b, 0x7e, 0x0a, 0xa7, 0x11, 0xfd, 0x65, 0x4b, 0x59, 0xec, 0x3d,
-	0x37, 0xa9, 0x2e, 0x08, 0xf5, 0x24, 0xe0, 0x26, 0xbd, 0x8a, 0x3f, 0x03, 0x1c, 0x51, 0xb6, 0x59,
-	0x87, 0x8c, 0x66, 0xc8, 0x05, 0x41, 0x46, 0x69, 0x44, 0xb1, 0xcf, 0x01, 0xad, 0xfc, 0x47, 0x1a,
-	0xc6, 0x1e, 0x8b, 0x23, 0xba, 0x0c, 0x1e, 0xc3, 0xb7, 0x62, 0x80, 0x4e, 0x48, 0x5d, 0xe2, 0xb3,
-	0x14, 0xe6, 0x54, 0x6e, 0x9e, 0x46, 0x19, 0xaa, 0x21, 0xa9, 0x12, 0x57, 0xd4, 0x9e, 0x0f, 0x1f,
-	0xad, 0xd6, 0x41, 0xfe, 0x85, 0xf4, 0x2a, 0x44, 0xae, 0x1d, 0x1e, 0x70, 0xb4, 0x1f, 0x4a, 0x49,
-	0x6c, 0xd7, 0xfe, 0x55, 0x2f, 0xcc, 0x1d, 0xf2, 0x9b, 0xfe, 0x6a, 0xce, 0x85, 0x22, 0xde, 0x4a,
-	0xc8, 0xad, 0x45, 0xfb, 0x4f, 0x89, 0xdf, 0x0a, 0xfc, 0x36, 0xc1, 0x6f, 0x17, 0xed, 0xbb, 0x23,
-	0x51, 0xe2, 0xf2, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4a, 0x32, 0x37, 0xc1, 0xd4, 0x07, 0x00,
-	0x00,
+	// 1547 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x6e, 0x1b, 0x47,
+	0x12, 0x5d, 0x5e, 0x86, 0x97, 0xe2, 0xad, 0xd5, 0x96, 0xd7, 0xf4, 0x1a, 0xeb, 0xd5, 0x52, 0x36,
+	0x96, 0xf6, 0x1a, 0x94, 0x45, 0x29, 0x0a, 0x10, 0x18, 0x71, 0x28, 0x73, 0xa4, 0x30, 0xe0, 0x2d,
+	0x4d, 0x52, 0xb1, 0x0d, 0x03, 0x83, 0x11, 0xd9, 0x92, 0xe8, 0xcc, 0x2d, 0x33, 0x43, 0xd9, 0xf2,
+	0x4b, 0x5e, 0xf2, 0x03, 0x79, 0xcd, 0x63, 0x1e, 0x03, 0xe4, 0x2f, 0x02, 0x24, 0x08, 0xf2, 0x21,
+	0xf9, 0x80, 0x7c, 0x40, 0xd0, 0xdd, 0x33, 0xc3, 0xa1, 0x48, 0x93, 0x72, 0x9c, 0x27, 0x76, 0x9f,
+	0x3a, 0x55, 0x5d, 0x55, 0x5d, 0xd5, 0xc5, 0x81, 0xcd, 0xc9, 0x31, 0xb5, 0xb7, 0x2c, 0xdb, 0x74,
+	0xcd, 0x2d, 0x9b, 0x9e, 0x68, 0x74, 0xe8, 0x6e, 0x9d, 0x6f, 0xfb, 0xcb, 0x0a, 0x17, 0xe0, 0xeb,
+	0x8c, 0x24, 0xd6, 0x15, 0x5f, 0x72, 0xbe, 0x5d, 0xfa, 0x14, 0xa0, 0xab, 0x0e, 0xbf, 0x54, 0x4f,
+	0x69, 0x8f, 0xba, 0xf8, 0x23, 0x48, 0x59, 0x62, 0xe7, 0x14, 0x23, 0x1b, 0xb1, 0x72, 0xa6, 0x7a,
+	0xbb, 0xb2, 0x50, 0xaf, 0xe2, 0x29, 0x91, 0x80, 0x5f, 0xfa, 0x3d, 0x0a, 0x49, 0x0f, 0xc5, 0x18,
+	0xe2, 0x86, 0xaa, 0xd3, 0x62, 0x64, 0x23, 0x52, 0x4e, 0x13, 0xbe, 0xc6, 0xf7, 0x00, 0x8d, 0xa8,
+	0x45, 0x8d, 0x11, 0x35, 0x86, 0x17, 0x0a, 0x83, 0x9c, 0x62, 0x74, 0x23, 0x56, 0x4e, 0x93, 0xc2,
+	0x14, 0x6f, 0x33, 0x18, 0x6f, 0x83, 0x44, 0x8d, 0x89, 0xee, 0x14, 0x63, 0xdc, 0x87, 0x5b, 0x6f,
+	0xf1, 0x41, 0x36, 0x26, 0x3a, 0x11, 0x4c, 0xe6, 0xb9, 0x4e, 0x1d, 0x87, 0x7b, 0x1e, 0x5f, 0xea,
+	0x79, 0x4b, 0xd0, 0x48, 0xc0, 0x67, 0xba, 0x0e, 0xb5, 0xcf, 0xc7, 0x43, 0xea, 0x14, 0xa5, 0xa5,
+	0xba, 0x3d, 0x41, 0x23, 0x01, 0x9f, 0xb9, 0x7a, 0x32, 0xd6, 0xa8, 0x53, 0x4c, 0x2c, 0x75, 0xf5,
+	0x60, 0xac, 0x51, 0x22, 0x98, 0xf8, 0x13, 0x00, 0xfa, 0xda, 0xa5, 0x86, 0x33, 0x36, 0x0d, 0xa7,
+	0x98, 0xe4, 0x7a, 0x1b, 0x6f, 0x0b, 0xd1, 0x27, 0x92, 0x90, 0x4e, 0xe9, 0x29, 0xc4, 0x99, 0xc1,
+	0x85, 0x69, 0x7e, 0x04, 0x49, 0xd3, 0x72, 0xb9, 0xe9, 0xe8, 0x46, 0xa4, 0x9c, 0xa9, 0x96, 0x96,
+	0xb8, 0xd4, 0x11, 0x4c, 0xe2, 0xab, 0x94, 0x7e, 0x8b, 0x43, 0x26, 0x24, 0x60, 0x97, 0x36, 0x74,
+	0xce, 0x54, 0xdb, 0x12, 0x17, 0x66, 0xa9, 0x43, 0xff, 0xb4, 0x82, 0xc0, 0xdb, 0x3e, 0x8c, 0xff,
+	0x0d, 0x70, 0x6a, 0x2a, 0x5e, 0x39, 0xf0, 0xb3, 0xd3, 0x24, 0x7d, 0x6a, 0xfa, 0x25, 0x51, 0x81,
+	0x6b, 0x2f, 0xd5, 0x73, 0x55, 0xd1, 0x27, 0x9a, 0x3b, 0xb6, 0x34, 0xaa, 0x88, 0xb4, 0xc5, 0x36,
+	0x22, 0xe5, 0x14, 0x59, 0x63, 0xa2, 0x96, 0x27, 0x39, 0xe0, 0x59, 0x7a, 0x08, 0xeb, 0x9c, 0x6f,
+	0x4e, 0x5c, 0x6a, 0x2b, 0x43, 0x4d, 0x75, 0x1c, 0x1e, 0x6b, 0x9c, 0x1b, 0xc6, 0x4c, 0xd6, 0x61,
+	0xa2, 0x27, 0xbe, 0x04, 0xff, 0x17, 0xb2, 0x5c, 0xc3, 0x77, 0x41, 0xe2, 0xcc, 0x0c, 0xc3, 0x7c,
+	0x27, 0xee, 0xc3, 0x9a, 0x79, 0xfc, 0x72, 0x28, 0xcc, 0x29, 0x96, 0x4d, 0x4f, 0xc6, 0xaf, 0x8b,
+	0x09, 0x11, 0x0f, 0x13, 0x70, 0x63, 0x5d, 0x0e, 0xe3, 0x4d, 0xc8, 0x59, 0x67, 0xe1, 0xb8, 0x93,
+	0x9c, 0x97, 0xb5, 0xce, 0x42, 0x41, 0x13, 0xc8, 0xb2, 0xd4, 0xe9, 0xe3, 0x37, 0x54, 0x39, 0x31,
+	0xed, 0x62, 0x6a, 0x23, 0x52, 0xce, 0x57, 0xb7, 0x56, 0xa7, 0xbc, 0xd2, 0xf1, 0xd4, 0x5a, 0xe6,
+	0x88, 0x92, 0x8c, 0x6f, 0xe4, 0xc0, 0xb4, 0x71, 0x19, 0xd0, 0x70, 0xa8, 0x50, 0x43, 0x3d, 0xd6,
+	0xa8, 0xa2, 0xda, 0xd4, 0x50, 0x9d, 0x62, 0x9a, 0xa7, 0x29, 0x3f, 0x1c, 0xca, 0x1c, 0xae, 0x71,
+	0x14, 0xdf, 0x06, 0x18, 0x51, 0xcb, 0xa6, 0x43, 0xd5, 0xa5, 0xa3, 0x22, 0x70, 0x4e, 0x08, 0x29,
+	0x7d, 0x0d, 0xd9, 0xf0, 0x31, 0xf8, 0x26, 0x5c, 0xef, 0x74, 0xfb, 0x8d, 0x56, 0xe3, 0xb9, 0xac,
+	0xb4, 0x3a, 0x75, 0x59, 0x69, 0xb4, 0x8f, 0x6a, 0xcd, 0x46, 0x1d, 0xfd, 0x03, 0xdf, 0x80, 0x6b,
+	0xb3, 0xa2, 0x5e, 0x57, 0x96, 0xeb, 0x28, 0x82, 0x6f, 0xc1, 0x8d, 0x59, 0xc1, 0x13, 0x2e, 0x6d,
+	0x3c, 0x97, 0x51, 0x14, 0xdf, 0x86, 0x7f, 0xcd, 0x0a, 0x9b, 0x8d, 0xbe, 0xac, 0x90, 0x41, 0xbb,
+	0xdf, 0x68, 0xc9, 0x28, 0x56, 0xfa, 0x36, 0x0a, 0x71, 0xd6, 0xa5, 0x0b, 0x2b, 0xb5, 0x06, 0x19,
+	0xd6, 0xbb, 0xca, 0xb9, 0xaa, 0x4d, 0xbc, 0xb7, 0x60, 0x49, 0x23, 0x18, 0x13, 0xfd, 0x88, 0x11,
+	0x09, 0x50, 0x7f, 0xe9, 0xe0, 0x16, 0x14, 0x6c, 0xca, 0x7a, 0x91, 0x8e, 0x14, 0x5b, 0x35, 0x4e,
+	0xa9, 0xff, 0x64, 0xdc, 0x79, 0x8b, 0x19, 0xe2, 0xb1, 0x09, 0x23, 0x93, 0xbc, 0x1d, 0xde, 0x3a,
+	0xf8, 0x2e, 0x04, 0x88, 0xf7, 0x40, 0xc5, 0xf9, 0x03, 0x95, 0xf3, 0x51, 0xf1, 0x3c, 0x85, 0x5a,
+	0x4c, 0x5a, 0xda, 0x62, 0xcc, 0xe9, 0xb9, 0x16, 0x6b, 0x43, 0x26, 0x84, 0xe3, 0xff, 0x40, 0x46,
+	0xd5, 0x34, 0xf3, 0x95, 0xa2, 0x6a, 0x63, 0xd5, 0xe1, 0x09, 0x4a, 0x11, 0xe0, 0x50, 0x4d, 0x1b,
+	0xcf, 0x5d, 0x72, 0x74, 0xee, 0x92, 0xdf, 0x40, 0x3a, 0x48, 0xce, 0xc2, 0x3c, 0xff, 0x13, 0x12,
+	0xc6, 0x44, 0x3f, 0xa6, 0x36, 0x57, 0x96, 0x88, 0xb7, 0xc3, 0xb5, 0x69, 0x18, 0x31, 0x1e, 0xc6,
+	0xff, 0x56, 0xe5, 0x7e, 0x2e, 0x96, 0x2a, 0xa0, 0xcb, 0xc2, 0x4b, 0xfe, 0x46, 0xe6, 0xfc, 0xfd,
+	0x10, 0x72, 0x33, 0xb7, 0x80, 0xd7, 0x41, 0x72, 0x5c, 0xd5, 0x76, 0x39, 0x57, 0x22, 0x62, 0x83,
+	0x11, 0xc4, 0xa8, 0x31, 0xf2, 0x5c, 0x66, 0xcb, 0xd2, 0x4f, 0x71, 0x48, 0x7a, 0x8f, 0xf7, 0xc2,
+	0x38, 0x3f, 0x83, 0xbc, 0xf7, 0xa4, 0x2b, 0x27, 0x63, 0xaa, 0x8d, 0xfc, 0x92, 0xda, 0x5c, 0x3e,
+	0x08, 0x0e, 0x18, 0x97, 0xe4, 0xf4, 0xd0, 0xce, 0x09, 0xdb, 0x32, 0x0d, 0x6a, 0x9e, 0xf8, 0x75,
+	0xb5, 0xc2, 0x56, 0x87, 0x71, 0x03, 0x5b, 0x7c, 0xe7, 0xe0, 0x43, 0x28, 0x18, 0xd4, 0x71, 0xe9,
+	0x48, 0x79, 0xc7, 0x09, 0x95, 0x17, 0x6a, 0xde, 0xd6, 0xc1, 0x1f, 0x43, 0xd6, 0x33, 0x24, 0xa6,
+	0xa3, 0xb4, 0x7a, 0x3a, 0x66, 0x84, 0x02, 0x5b, 0x2f, 0xec, 0x96, 0xc4, 0xdf, 0xda, 0x2d, 0xc9,
+	0x45, 0xdd, 0xf2, 0x78, 0x5a, 0x66, 0x29, 0x5e, 0x66, 0x77, 0x57, 0xe4, 0xf0, 0x52, 0x91, 0xe1,
+	0x16, 0xac, 0xf9, 0x61, 0x4f, 0xc7, 0x66, 0xfa, 0x8a, 0x63, 0x13, 0x79, 0x09, 0x98, 0x0e, 0xcf,
+	0x6f, 0x22, 0x90, 0x9f, 0x3d, 0x0a, 0x7f, 0x00, 0x37, 0xfc, 0xdb, 0x76, 0xa8, 0xab, 0xbc, 0x1a,
+	0xdb, 0xfc, 0x35, 0xd7, 0x55, 0xd7, 0xab, 0xdf, 0x75, 0x4f, 0xdc, 0xa3, 0xee, 0x17, 0x63, 0x9b,
+	0xbd, 0xd2, 0xba, 0xea, 0xae, 0xea, 0x4c, 0x7c, 0x0b, 0xd2, 0xba, 0x6a, 0x29, 0xd4, 0x70, 0xed,
+	0x0b, 0x6f, 0xd0, 0xa5, 0x74, 0xd5, 0x92, 0xd9, 0xbe, 0xf4, 0x87, 0x04, 0xd9, 0x70, 0x05, 0xbe,
+	0x53, 0xeb, 0x3e, 0x06, 0x49, 0x53, 0x8f, 0xa9, 0xc6, 0xad, 0xe6, 0xab, 0xf7, 0xae, 0x50, 0xe1,
+	0x95, 0x26, 0x53, 0x20, 0x42, 0x0f, 0x3f, 0x82, 0xb8, 0x7b, 0x61, 0x89, 0x69, 0x9a, 0xaf, 0x96,
+	0xaf, 0xa2, 0xdf, 0xbf, 0xb0, 0x28, 0xe1, 0x5a, 0x2c, 0x30, 0xf6, 0xcb, 0x6f, 0xdd, 0x1b, 0xb3,
+	0x29, 0x06, 0xb0, 0x0b, 0x67, 0xc2, 0x97, 0x8e, 0x69, 0x08, 0xa1, 0x98, 0xad, 0x29, 0x06, 0xb4,
+	0xbd, 0x80, 0xd8, 0x78, 0xa6, 0x23, 0x3e, 0x4d, 0x53, 0xc4, 0xdb, 0xe1, 0xfa, 0xe5, 0x22, 0xb9,
+	0x7f, 0x05, 0x97, 0xe6, 0x9e, 0xa3, 0x23, 0x90, 0x78, 0x94, 0x78, 0x0d, 0x72, 0xcd, 0xda, 0xbe,
+	0xdc, 0x0c, 0x0d, 0x38, 0x0c, 0x79, 0x01, 0xb1, 0x81, 0xd5, 0x69, 0xd7, 0x9a, 0x28, 0x32, 0xc5,
+	0x88, 0xfc, 0xf9, 0xa0, 0x41, 0xe4, 0x3a, 0x8a, 0x86, 0xb1, 0xae, 0x5c, 0xeb, 0xcb, 0x75, 0x14,
+	0x2b, 0xfd, 0x12, 0x85, 0x38, 0x0b, 0x1f, 0x23, 0xc8, 0xf6, 0x9f, 0x75, 0xc3, 0x73, 0xb3, 0x00,
+	0x19, 0x8e, 0xd4, 0x3b, 0x83, 0xfd, 0xa6, 0x8c, 0x22, 0x38, 0x0f, 0xc0, 0x81, 0x83, 0x66, 0xa7,
+	0xd6, 0x47, 0xd1, 0x60, 0xdf, 0x68, 0xf7, 0xf7, 0x76, 0x51, 0x2c, 0x50, 0x18, 0x08, 0x20, 0x1e,
+	0x26, 0xec, 0x54, 0x91, 0x14, 0x9c, 0x71, 0xd0, 0x78, 0x2a, 0xd7, 0xf7, 0x76, 0x51, 0x62, 0x16,
+	0xd9, 0xa9, 0xa2, 0x24, 0xce, 0x41, 0x9a, 0x23, 0xfb, 0x9d, 0x4e, 0x13, 0xa5, 0x02, 0x9b, 0xbd,
+	0x3e, 0x69, 0xb4, 0x0f, 0x51, 0x3a, 0xb0, 0x79, 0x48, 0x3a, 0x83, 0x2e, 0x82, 0xc0, 0x42, 0x4b,
+	0xee, 0xf5, 0x6a, 0x87, 0x32, 0xca, 0x04, 0x8c, 0xfd, 0x67, 0x7d, 0xb9, 0x87, 0xb2, 0x33, 0x6e,
+	0xed, 0x54, 0x51, 0x2e, 0x38, 0x42, 0x6e, 0x0f, 0x5a, 0x28, 0xcf, 0x32, 0x2a, 0x8e, 0xf0, 0x9d,
+	0x28, 0x5c, 0x82, 0xf6, 0x76, 0x11, 0x9a, 0x3a, 0x22, 0xac, 0xac, 0xcd, 0x00, 0x7b, 0xbb, 0x08,
+	0x97, 0x1a, 0x70, 0x6d, 0xc1, 0x15, 0xae, 0x1a, 0x1a, 0xac, 0x39, 0x34, 0xf5, 0xcd, 0x85, 0xd7,
+	0x64, 0x7c, 0x5d, 0xfa, 0x39, 0x0a, 0xe9, 0xa0, 0xaf, 0xdf, 0xa9, 0x7d, 0x1e, 0x00, 0xe6, 0x4f,
+	0xc9, 0x88, 0x52, 0x65, 0x5a, 0xc8, 0x31, 0xae, 0x89, 0x7c, 0x49, 0xdf, 0x2f, 0xe8, 0xa0, 0xd9,
+	0xe2, 0xef, 0xd9, 0x6c, 0xd2, 0xfb, 0x37, 0x5b, 0xe2, 0x52, 0xb3, 0x85, 0xfa, 0x26, 0xf9, 0xd7,
+	0xfb, 0xe6, 0x30, 0x78, 0x8a, 0xf8, 0xc8, 0x5a, 0x98, 0xcb, 0x4d, 0xc8, 0xf1, 0xa9, 0xaa, 0x88,
+	0x1c, 0x8a, 0xe1, 0x2a, 0x91, 0x2c, 0x07, 0xdb, 0x02, 0x2b, 0xfd, 0x18, 0x81, 0xa4, 0xf7, 0x8d,
+	0xb4, 0xd0, 0x48, 0x0b, 0x0a, 0xde, 0x97, 0x93, 0xa2, 0x53, 0xf7, 0xcc, 0x0c, 0x66, 0xf4, 0x9d,
+	0xe5, 0x1f, 0x5c, 0x2d, 0x4e, 0x26, 0x79, 0x27, 0xbc, 0x9d, 0x19, 0x2d, 0xb1, 0xa5, 0xa3, 0xc5,
+	0x33, 0x33, 0x17, 0xf8, 0x43, 0xc8, 0xcf, 0x8a, 0x56, 0xfe, 0x7b, 0xf9, 0x2e, 0x0a, 0xb9, 0x19,
+	0xa7, 0x16, 0xc6, 0x79, 0x1f, 0xd6, 0x6c, 0xfa, 0xd5, 0x84, 0x3a, 0x6e, 0xa8, 0xbe, 0xc4, 0x27,
+	0x51, 0xc1, 0x13, 0x04, 0xe5, 0xf5, 0x00, 0xb0, 0x4d, 0x1d, 0xcb, 0x34, 0x9c, 0x05, 0xc5, 0xe8,
+	0x4b, 0x02, 0x36, 0xfb, 0x20, 0xd3, 0xc6, 0xd4, 0x70, 0x15, 0xc7, 0xb5, 0xa9, 0xaa, 0x8f, 0x8d,
+	0x53, 0x5e, 0x97, 0x29, 0x52, 0x10, 0x78, 0xcf, 0x87, 0x19, 0x95, 0xcf, 0x61, 0x3b, 0x44, 0x95,
+	0x04, 0x55, 0xe0, 0x53, 0xaa, 0x3c, 0x4d, 0x64, 0x82, 0x27, 0xf2, 0xff, 0x57, 0xb9, 0x8f, 0xb9,
+	0x74, 0xee, 0xc1, 0xfa, 0x22, 0xc2, 0xaa, 0xa4, 0xee, 0x6b, 0x70, 0x73, 0x68, 0xea, 0x8b, 0x8f,
+	0xdc, 0xcf, 0x12, 0xb1, 0xee, 0x32, 0x41, 0x37, 0xf2, 0x3c, 0xed, 0xc9, 0xce, 0xb7, 0xbf, 0x8f,
+	0xc6, 0x06, 0x5d, 0xf2, 0x43, 0xf4, 0xfa, 0x80, 0x29, 0x72, 0x79, 0xc5, 0x23, 0x57, 0x8e, 0xb6,
+	0x7f, 0x15, 0xf8, 0x0b, 0x8e, 0xbf, 0xf0, 0xf0, 0x17, 0x47, 0xdb, 0xc7, 0x09, 0x7e, 0xc4, 0xce,
+	0x9f, 0x03, 0x00, 0xe3, 0x2b, 0x2e, 0x48, 0x37, 0x11, 0x00, 0x00,
 }
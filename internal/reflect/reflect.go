@@ -27,6 +27,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/protostrs"
 	reflectv1 "github.com/uber/prototool/internal/reflect/gen/uber/proto/reflect/v1"
 	"github.com/uber/prototool/internal/strs"
 )
@@ -65,6 +66,12 @@ func NewPackageSet(fileDescriptorSets ...*descriptor.FileDescriptorSet) (*reflec
 		if err := populateServices(pkg, fileNameToFileDescriptorProto); err != nil {
 			return nil, err
 		}
+		if err := populateFiles(pkg, fileNameToFileDescriptorProto); err != nil {
+			return nil, err
+		}
+		if err := populateExtensions(pkg, fileNameToFileDescriptorProto); err != nil {
+			return nil, err
+		}
 	}
 	return getPackageSet(packageNameToPackage)
 }
@@ -178,7 +185,7 @@ func populateMessages(
 	fileNameToFileDescriptorProto map[string]*descriptor.FileDescriptorProto,
 ) error {
 	for _, fileDescriptorProto := range fileNameToFileDescriptorProto {
-		messages, err := getMessages(fileDescriptorProto.GetMessageType())
+		messages, err := getMessages(fileDescriptorProto.GetMessageType(), isProto3(fileDescriptorProto))
 		if err != nil {
 			return err
 		}
@@ -208,6 +215,39 @@ func populateServices(
 	return nil
 }
 
+// helper for NewPackageSet
+func populateFiles(
+	pkg *reflectv1.Package,
+	fileNameToFileDescriptorProto map[string]*descriptor.FileDescriptorProto,
+) error {
+	for fileName, fileDescriptorProto := range fileNameToFileDescriptorProto {
+		pkg.Files = append(pkg.Files, &reflectv1.File{
+			Name:    fileName,
+			Options: newFileOptions(fileDescriptorProto.GetOptions()),
+		})
+	}
+	sort.Slice(pkg.Files, func(i int, j int) bool { return pkg.Files[i].Name < pkg.Files[j].Name })
+	return nil
+}
+
+// helper for NewPackageSet
+func populateExtensions(
+	pkg *reflectv1.Package,
+	fileNameToFileDescriptorProto map[string]*descriptor.FileDescriptorProto,
+) error {
+	for _, fileDescriptorProto := range fileNameToFileDescriptorProto {
+		extensions, err := getExtensions(fileDescriptorProto.GetExtension())
+		if err != nil {
+			return err
+		}
+		if len(extensions) > 0 {
+			pkg.Extensions = append(pkg.Extensions, extensions...)
+		}
+	}
+	sort.Slice(pkg.Extensions, func(i int, j int) bool { return pkg.Extensions[i].Name < pkg.Extensions[j].Name })
+	return nil
+}
+
 // helper for NewPackageSet
 func getPackageSet(packageNameToPackage map[string]*reflectv1.Package) (*reflectv1.PackageSet, error) {
 	if len(packageNameToPackage) == 0 {
@@ -259,25 +299,36 @@ func getEnums(enumDescriptorProtos []*descriptor.EnumDescriptorProto) ([]*reflec
 
 func newEnum(enumDescriptorProto *descriptor.EnumDescriptorProto) (*reflectv1.Enum, error) {
 	enum := &reflectv1.Enum{
-		Name: enumDescriptorProto.GetName(),
+		Name:          enumDescriptorProto.GetName(),
+		ReservedNames: getReservedNames(enumDescriptorProto.GetReservedName()),
+		Options:       newEnumOptions(enumDescriptorProto.GetOptions()),
 	}
 	for _, enumValueDescriptorProto := range enumDescriptorProto.GetValue() {
 		enum.EnumValues = append(enum.EnumValues, &reflectv1.EnumValue{
-			Name:   enumValueDescriptorProto.GetName(),
-			Number: enumValueDescriptorProto.GetNumber(),
+			Name:    enumValueDescriptorProto.GetName(),
+			Number:  enumValueDescriptorProto.GetNumber(),
+			Options: newEnumValueOptions(enumValueDescriptorProto.GetOptions()),
 		})
 	}
-	sort.Slice(enum.EnumValues, func(i int, j int) bool { return enum.EnumValues[i].Number < enum.EnumValues[j].Number })
+	for _, enumReservedRange := range enumDescriptorProto.GetReservedRange() {
+		// the end of an enum reserved range is inclusive
+		enum.ReservedRanges = append(enum.ReservedRanges, &reflectv1.ReservedRange{
+			Start: enumReservedRange.GetStart(),
+			End:   enumReservedRange.GetEnd(),
+		})
+	}
+	sort.SliceStable(enum.EnumValues, func(i int, j int) bool { return enum.EnumValues[i].Number < enum.EnumValues[j].Number })
+	sortReservedRanges(enum.ReservedRanges)
 	return enum, nil
 }
 
-func getMessages(descriptorProtos []*descriptor.DescriptorProto) ([]*reflectv1.Message, error) {
+func getMessages(descriptorProtos []*descriptor.DescriptorProto, proto3 bool) ([]*reflectv1.Message, error) {
 	if len(descriptorProtos) == 0 {
 		return nil, nil
 	}
 	messages := make([]*reflectv1.Message, 0, len(descriptorProtos))
 	for _, descriptorProto := range descriptorProtos {
-		message, err := newMessage(descriptorProto)
+		message, err := newMessage(descriptorProto, proto3)
 		if err != nil {
 			return nil, err
 		}
@@ -287,8 +338,8 @@ func getMessages(descriptorProtos []*descriptor.DescriptorProto) ([]*reflectv1.M
 	return messages, nil
 }
 
-func newMessage(descriptorProto *descriptor.DescriptorProto) (*reflectv1.Message, error) {
-	nestedMessages, err := getMessages(descriptorProto.GetNestedType())
+func newMessage(descriptorProto *descriptor.DescriptorProto, proto3 bool) (*reflectv1.Message, error) {
+	nestedMessages, err := getMessages(descriptorProto.GetNestedType(), proto3)
 	if err != nil {
 		return nil, err
 	}
@@ -296,11 +347,26 @@ func newMessage(descriptorProto *descriptor.DescriptorProto) (*reflectv1.Message
 	if err != nil {
 		return nil, err
 	}
+	nestedExtensions, err := getExtensions(descriptorProto.GetExtension())
+	if err != nil {
+		return nil, err
+	}
 	message := &reflectv1.Message{
-		Name:           descriptorProto.GetName(),
-		NestedMessages: nestedMessages,
-		NestedEnums:    nestedEnums,
+		Name:             descriptorProto.GetName(),
+		NestedMessages:   nestedMessages,
+		NestedEnums:      nestedEnums,
+		ReservedNames:    getReservedNames(descriptorProto.GetReservedName()),
+		Options:          newMessageOptions(descriptorProto.GetOptions()),
+		NestedExtensions: nestedExtensions,
+	}
+	for _, reservedRange := range descriptorProto.GetReservedRange() {
+		// the end of a message reserved range is exclusive
+		message.ReservedRanges = append(message.ReservedRanges, &reflectv1.ReservedRange{
+			Start: reservedRange.GetStart(),
+			End:   reservedRange.GetEnd() - 1,
+		})
 	}
+	sortReservedRanges(message.ReservedRanges)
 	nameToMessageOneof := make(map[string]*reflectv1.MessageOneof, len(descriptorProto.GetOneofDecl()))
 	for _, oneofDescriptorProto := range descriptorProto.GetOneofDecl() {
 		nameToMessageOneof[oneofDescriptorProto.GetName()] = &reflectv1.MessageOneof{
@@ -325,6 +391,9 @@ func newMessage(descriptorProto *descriptor.DescriptorProto) (*reflectv1.Message
 			Type:     reflectv1.MessageField_Type(fieldDescriptorProto.GetType()),
 			Label:    reflectv1.MessageField_Label(fieldDescriptorProto.GetLabel()),
 			TypeName: typeName,
+			JsonName: getJSONName(fieldDescriptorProto),
+			Packed:   isPacked(fieldDescriptorProto, proto3),
+			Options:  newMessageFieldOptions(fieldDescriptorProto.GetOptions()),
 		})
 		if fieldDescriptorProto.OneofIndex != nil {
 			// TODO: super unsafe
@@ -341,6 +410,46 @@ func newMessage(descriptorProto *descriptor.DescriptorProto) (*reflectv1.Message
 	return message, nil
 }
 
+func getExtensions(fieldDescriptorProtos []*descriptor.FieldDescriptorProto) ([]*reflectv1.Extension, error) {
+	if len(fieldDescriptorProtos) == 0 {
+		return nil, nil
+	}
+	extensions := make([]*reflectv1.Extension, 0, len(fieldDescriptorProtos))
+	for _, fieldDescriptorProto := range fieldDescriptorProtos {
+		extension, err := newExtension(fieldDescriptorProto)
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, extension)
+	}
+	sort.Slice(extensions, func(i int, j int) bool { return extensions[i].Name < extensions[j].Name })
+	return extensions, nil
+}
+
+func newExtension(fieldDescriptorProto *descriptor.FieldDescriptorProto) (*reflectv1.Extension, error) {
+	extendeeTypeName, err := verifyFullyQualifiedNameAndStrip(fieldDescriptorProto.GetExtendee())
+	if err != nil {
+		return nil, err
+	}
+	typeName := fieldDescriptorProto.GetTypeName()
+	if typeName != "" {
+		typeName, err = verifyFullyQualifiedNameAndStrip(typeName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &reflectv1.Extension{
+		Name:             fieldDescriptorProto.GetName(),
+		Number:           fieldDescriptorProto.GetNumber(),
+		ExtendeeTypeName: extendeeTypeName,
+		// see the TODO in newMessage
+		Label:    reflectv1.MessageField_Label(fieldDescriptorProto.GetLabel()),
+		Type:     reflectv1.MessageField_Type(fieldDescriptorProto.GetType()),
+		TypeName: typeName,
+		Options:  newMessageFieldOptions(fieldDescriptorProto.GetOptions()),
+	}, nil
+}
+
 func getServices(serviceDescriptorProtos []*descriptor.ServiceDescriptorProto) ([]*reflectv1.Service, error) {
 	if len(serviceDescriptorProtos) == 0 {
 		return nil, nil
@@ -359,7 +468,8 @@ func getServices(serviceDescriptorProtos []*descriptor.ServiceDescriptorProto) (
 
 func newService(serviceDescriptorProto *descriptor.ServiceDescriptorProto) (*reflectv1.Service, error) {
 	service := &reflectv1.Service{
-		Name: serviceDescriptorProto.GetName(),
+		Name:    serviceDescriptorProto.GetName(),
+		Options: newServiceOptions(serviceDescriptorProto.GetOptions()),
 	}
 	for _, methodDescriptorProto := range serviceDescriptorProto.GetMethod() {
 		serviceMethod, err := newServiceMethod(methodDescriptorProto)
@@ -387,9 +497,133 @@ func newServiceMethod(methodDescriptorProto *descriptor.MethodDescriptorProto) (
 		ResponseTypeName: responseTypeName,
 		ClientStreaming:  methodDescriptorProto.GetClientStreaming(),
 		ServerStreaming:  methodDescriptorProto.GetServerStreaming(),
+		Options:          newServiceMethodOptions(methodDescriptorProto.GetOptions()),
 	}, nil
 }
 
+func newFileOptions(fileOptions *descriptor.FileOptions) *reflectv1.FileOptions {
+	if fileOptions == nil {
+		return nil
+	}
+	return &reflectv1.FileOptions{
+		CsharpNamespace:    fileOptions.GetCsharpNamespace(),
+		GoPackage:          fileOptions.GetGoPackage(),
+		JavaMultipleFiles:  fileOptions.GetJavaMultipleFiles(),
+		JavaOuterClassname: fileOptions.GetJavaOuterClassname(),
+		JavaPackage:        fileOptions.GetJavaPackage(),
+		ObjcClassPrefix:    fileOptions.GetObjcClassPrefix(),
+		PhpNamespace:       fileOptions.GetPhpNamespace(),
+		// GetOptimizeFor returns SPEED if not set
+		// the numbers match up, see the TODO in newMessage
+		OptimizeFor:    reflectv1.FileOptions_OptimizeMode(fileOptions.GetOptimizeFor()),
+		CcEnableArenas: fileOptions.GetCcEnableArenas(),
+		Deprecated:     fileOptions.GetDeprecated(),
+	}
+}
+
+func newEnumOptions(enumOptions *descriptor.EnumOptions) *reflectv1.EnumOptions {
+	if enumOptions == nil {
+		return nil
+	}
+	return &reflectv1.EnumOptions{
+		AllowAlias: enumOptions.GetAllowAlias(),
+		Deprecated: enumOptions.GetDeprecated(),
+	}
+}
+
+func newEnumValueOptions(enumValueOptions *descriptor.EnumValueOptions) *reflectv1.EnumValueOptions {
+	if enumValueOptions == nil {
+		return nil
+	}
+	return &reflectv1.EnumValueOptions{
+		Deprecated: enumValueOptions.GetDeprecated(),
+	}
+}
+
+func newMessageOptions(messageOptions *descriptor.MessageOptions) *reflectv1.MessageOptions {
+	if messageOptions == nil {
+		return nil
+	}
+	return &reflectv1.MessageOptions{
+		MessageSetWireFormat: messageOptions.GetMessageSetWireFormat(),
+		Deprecated:           messageOptions.GetDeprecated(),
+		MapEntry:             messageOptions.GetMapEntry(),
+	}
+}
+
+func newMessageFieldOptions(fieldOptions *descriptor.FieldOptions) *reflectv1.MessageFieldOptions {
+	if fieldOptions == nil {
+		return nil
+	}
+	return &reflectv1.MessageFieldOptions{
+		Deprecated: fieldOptions.GetDeprecated(),
+		Lazy:       fieldOptions.GetLazy(),
+	}
+}
+
+func newServiceOptions(serviceOptions *descriptor.ServiceOptions) *reflectv1.ServiceOptions {
+	if serviceOptions == nil {
+		return nil
+	}
+	return &reflectv1.ServiceOptions{
+		Deprecated: serviceOptions.GetDeprecated(),
+	}
+}
+
+func newServiceMethodOptions(methodOptions *descriptor.MethodOptions) *reflectv1.ServiceMethodOptions {
+	if methodOptions == nil {
+		return nil
+	}
+	return &reflectv1.ServiceMethodOptions{
+		Deprecated: methodOptions.GetDeprecated(),
+	}
+}
+
+func getReservedNames(reservedNames []string) []string {
+	if len(reservedNames) == 0 {
+		return nil
+	}
+	return strs.SortUniq(reservedNames)
+}
+
+func sortReservedRanges(reservedRanges []*reflectv1.ReservedRange) {
+	sort.Slice(reservedRanges, func(i int, j int) bool { return reservedRanges[i].Start < reservedRanges[j].Start })
+}
+
+// getJSONName returns the json_name of the field, which protoc will
+// populate, or the name protoc would compute if it is not populated.
+func getJSONName(fieldDescriptorProto *descriptor.FieldDescriptorProto) string {
+	if jsonName := fieldDescriptorProto.GetJsonName(); jsonName != "" {
+		return jsonName
+	}
+	return protostrs.JSONName(fieldDescriptorProto.GetName())
+}
+
+// isPacked returns true if the field is encoded as packed.
+//
+// Only repeated scalar numeric fields can be packed, and these are packed
+// by default in proto3.
+func isPacked(fieldDescriptorProto *descriptor.FieldDescriptorProto, proto3 bool) bool {
+	if fieldDescriptorProto.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return false
+	}
+	switch fieldDescriptorProto.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_STRING,
+		descriptor.FieldDescriptorProto_TYPE_BYTES,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		return false
+	}
+	if options := fieldDescriptorProto.GetOptions(); options != nil && options.Packed != nil {
+		return options.GetPacked()
+	}
+	return proto3
+}
+
+func isProto3(fileDescriptorProto *descriptor.FileDescriptorProto) bool {
+	return fileDescriptorProto.GetSyntax() == "proto3"
+}
+
 func verifyFullyQualifiedNameAndStrip(s string) (string, error) {
 	if s == "" {
 		return "", fmt.Errorf("name empty")
@@ -46,13 +46,15 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             }
           ]
         },
@@ -64,28 +66,32 @@ func TestOne(t *testing.T) {
               "number": 1,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.OneFoo"
+              "typeName": "uber.proto.bar.v1.OneFoo",
+              "jsonName": "oneFoo"
             },
             {
               "name": "one_bar",
               "number": 2,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.OneBar"
+              "typeName": "uber.proto.bar.v1.OneBar",
+              "jsonName": "oneBar"
             },
             {
               "name": "two_foo",
               "number": 3,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.TwoFoo"
+              "typeName": "uber.proto.bar.v1.TwoFoo",
+              "jsonName": "twoFoo"
             },
             {
               "name": "two_bar",
               "number": 4,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.TwoBar"
+              "typeName": "uber.proto.bar.v1.TwoBar",
+              "jsonName": "twoBar"
             }
           ]
         },
@@ -96,20 +102,23 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             },
             {
               "name": "one_bar",
               "number": 3,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.OneBar"
+              "typeName": "uber.proto.bar.v1.OneBar",
+              "jsonName": "oneBar"
             }
           ]
         },
@@ -120,13 +129,15 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             }
           ]
         },
@@ -137,23 +148,54 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             },
             {
               "name": "two_bar",
               "number": 3,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.TwoBar"
+              "typeName": "uber.proto.bar.v1.TwoBar",
+              "jsonName": "twoBar"
             }
           ]
         }
+      ],
+      "files": [
+        {
+          "name": "uber/proto/bar/v1/one.proto",
+          "options": {
+            "csharpNamespace": "Uber.Proto.Bar.V1",
+            "goPackage": "barv1",
+            "javaMultipleFiles": true,
+            "javaOuterClassname": "OneProto",
+            "javaPackage": "com.uber.proto.bar.v1",
+            "objcClassPrefix": "UPB",
+            "phpNamespace": "Uber\\Proto\\Bar\\V1",
+            "optimizeFor": "OPTIMIZE_MODE_SPEED"
+          }
+        },
+        {
+          "name": "uber/proto/bar/v1/two.proto",
+          "options": {
+            "csharpNamespace": "Uber.Proto.Bar.V1",
+            "goPackage": "barv1",
+            "javaMultipleFiles": true,
+            "javaOuterClassname": "TwoProto",
+            "javaPackage": "com.uber.proto.bar.v1",
+            "objcClassPrefix": "UPB",
+            "phpNamespace": "Uber\\Proto\\Bar\\V1",
+            "optimizeFor": "OPTIMIZE_MODE_SPEED"
+          }
+        }
       ]
     },
     {
@@ -177,6 +219,37 @@ func TestOne(t *testing.T) {
               "number": 2
             }
           ]
+        },
+        {
+          "name": "TwoEnum",
+          "enumValues": [
+            {
+              "name": "TWO_ENUM_INVALID"
+            },
+            {
+              "name": "TWO_ENUM_ONE",
+              "number": 1
+            },
+            {
+              "name": "TWO_ENUM_UNO",
+              "number": 1,
+              "options": {
+                "deprecated": true
+              }
+            }
+          ],
+          "reservedRanges": [
+            {
+              "start": 2,
+              "end": 3
+            }
+          ],
+          "reservedNames": [
+            "TWO_ENUM_TWO"
+          ],
+          "options": {
+            "allowAlias": true
+          }
         }
       ],
       "messages": [
@@ -187,7 +260,8 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             }
           ]
         },
@@ -198,7 +272,8 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             }
           ]
         },
@@ -209,7 +284,8 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             }
           ]
         },
@@ -220,7 +296,8 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             }
           ]
         },
@@ -231,13 +308,15 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             }
           ]
         },
@@ -249,28 +328,32 @@ func TestOne(t *testing.T) {
               "number": 1,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.OneFoo"
+              "typeName": "uber.proto.bar.v1.OneFoo",
+              "jsonName": "oneFoo"
             },
             {
               "name": "one_bar",
               "number": 2,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.OneBar"
+              "typeName": "uber.proto.bar.v1.OneBar",
+              "jsonName": "oneBar"
             },
             {
               "name": "two_foo",
               "number": 3,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.TwoFoo"
+              "typeName": "uber.proto.bar.v1.TwoFoo",
+              "jsonName": "twoFoo"
             },
             {
               "name": "two_bar",
               "number": 4,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.bar.v1.TwoBar"
+              "typeName": "uber.proto.bar.v1.TwoBar",
+              "jsonName": "twoBar"
             }
           ]
         },
@@ -282,28 +365,32 @@ func TestOne(t *testing.T) {
               "number": 1,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.OneFoo"
+              "typeName": "uber.proto.foo.v1.OneFoo",
+              "jsonName": "oneFoo"
             },
             {
               "name": "one_bar",
               "number": 2,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.OneBar"
+              "typeName": "uber.proto.foo.v1.OneBar",
+              "jsonName": "oneBar"
             },
             {
               "name": "two_foo",
               "number": 3,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.TwoFoo"
+              "typeName": "uber.proto.foo.v1.TwoFoo",
+              "jsonName": "twoFoo"
             },
             {
               "name": "two_bar",
               "number": 4,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.TwoBar"
+              "typeName": "uber.proto.foo.v1.TwoBar",
+              "jsonName": "twoBar"
             }
           ]
         },
@@ -314,20 +401,23 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             },
             {
               "name": "one_bar",
               "number": 3,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.OneBar"
+              "typeName": "uber.proto.foo.v1.OneBar",
+              "jsonName": "oneBar"
             }
           ]
         },
@@ -338,39 +428,46 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             },
             {
               "name": "three",
               "number": 3,
               "label": "LABEL_REPEATED",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "three",
+              "packed": true
             },
             {
               "name": "four",
               "number": 4,
               "label": "LABEL_REPEATED",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.Simple.FourEntry"
+              "typeName": "uber.proto.foo.v1.Simple.FourEntry",
+              "jsonName": "four"
             },
             {
               "name": "five",
               "number": 5,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "five"
             },
             {
               "name": "one_bat",
               "number": 6,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.OneBat"
+              "typeName": "uber.proto.foo.v1.OneBat",
+              "jsonName": "oneBat"
             }
           ],
           "messageOneofs": [
@@ -390,15 +487,20 @@ func TestOne(t *testing.T) {
                   "name": "key",
                   "number": 1,
                   "label": "LABEL_OPTIONAL",
-                  "type": "TYPE_INT64"
+                  "type": "TYPE_INT64",
+                  "jsonName": "key"
                 },
                 {
                   "name": "value",
                   "number": 2,
                   "label": "LABEL_OPTIONAL",
-                  "type": "TYPE_STRING"
+                  "type": "TYPE_STRING",
+                  "jsonName": "value"
                 }
-              ]
+              ],
+              "options": {
+                "mapEntry": true
+              }
             },
             {
               "name": "Nested",
@@ -407,13 +509,15 @@ func TestOne(t *testing.T) {
                   "name": "one",
                   "number": 1,
                   "label": "LABEL_OPTIONAL",
-                  "type": "TYPE_INT64"
+                  "type": "TYPE_INT64",
+                  "jsonName": "one"
                 },
                 {
                   "name": "two",
                   "number": 2,
                   "label": "LABEL_OPTIONAL",
-                  "type": "TYPE_STRING"
+                  "type": "TYPE_STRING",
+                  "jsonName": "two"
                 }
               ]
             }
@@ -437,6 +541,27 @@ func TestOne(t *testing.T) {
             }
           ]
         },
+        {
+          "name": "ThreeFoo",
+          "messageFields": [
+            {
+              "name": "one",
+              "number": 1,
+              "label": "LABEL_OPTIONAL",
+              "type": "TYPE_INT64",
+              "jsonName": "one"
+            }
+          ],
+          "nestedExtensions": [
+            {
+              "name": "three_nested",
+              "number": 101,
+              "extendeeTypeName": "uber.proto.foo.v1.ThreeFoo",
+              "label": "LABEL_OPTIONAL",
+              "type": "TYPE_STRING"
+            }
+          ]
+        },
         {
           "name": "TwoBar",
           "messageFields": [
@@ -444,14 +569,49 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two",
+              "options": {
+                "deprecated": true
+              }
+            },
+            {
+              "name": "six",
+              "number": 6,
+              "label": "LABEL_REPEATED",
+              "type": "TYPE_INT32",
+              "jsonName": "six",
+              "options": {}
+            },
+            {
+              "name": "seven",
+              "number": 7,
+              "label": "LABEL_REPEATED",
+              "type": "TYPE_INT32",
+              "jsonName": "sevenValues",
+              "packed": true
             }
+          ],
+          "reservedRanges": [
+            {
+              "start": 3,
+              "end": 5
+            },
+            {
+              "start": 8,
+              "end": 8
+            }
+          ],
+          "reservedNames": [
+            "eight",
+            "three"
           ]
         },
         {
@@ -461,20 +621,23 @@ func TestOne(t *testing.T) {
               "name": "one",
               "number": 1,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_INT64"
+              "type": "TYPE_INT64",
+              "jsonName": "one"
             },
             {
               "name": "two",
               "number": 2,
               "label": "LABEL_OPTIONAL",
-              "type": "TYPE_STRING"
+              "type": "TYPE_STRING",
+              "jsonName": "two"
             },
             {
               "name": "two_bar",
               "number": 3,
               "label": "LABEL_OPTIONAL",
               "type": "TYPE_MESSAGE",
-              "typeName": "uber.proto.foo.v1.TwoBar"
+              "typeName": "uber.proto.foo.v1.TwoBar",
+              "jsonName": "twoBar"
             }
           ]
         }
@@ -495,6 +658,67 @@ func TestOne(t *testing.T) {
             }
           ]
         }
+      ],
+      "files": [
+        {
+          "name": "uber/proto/foo/v1/one.proto",
+          "options": {
+            "csharpNamespace": "Uber.Proto.Foo.V1",
+            "goPackage": "foov1",
+            "javaMultipleFiles": true,
+            "javaOuterClassname": "OneProto",
+            "javaPackage": "com.uber.proto.foo.v1",
+            "objcClassPrefix": "UPF",
+            "phpNamespace": "Uber\\Proto\\Foo\\V1",
+            "optimizeFor": "OPTIMIZE_MODE_SPEED"
+          }
+        },
+        {
+          "name": "uber/proto/foo/v1/three.proto",
+          "options": {
+            "csharpNamespace": "Uber.Proto.Foo.V1",
+            "goPackage": "foov1",
+            "javaMultipleFiles": true,
+            "javaOuterClassname": "ThreeProto",
+            "javaPackage": "com.uber.proto.foo.v1",
+            "objcClassPrefix": "UPF",
+            "phpNamespace": "Uber\\Proto\\Foo\\V1",
+            "optimizeFor": "OPTIMIZE_MODE_SPEED"
+          }
+        },
+        {
+          "name": "uber/proto/foo/v1/two.proto",
+          "options": {
+            "csharpNamespace": "Uber.Proto.Foo.V1",
+            "goPackage": "foov1",
+            "javaMultipleFiles": true,
+            "javaOuterClassname": "TwoProto",
+            "javaPackage": "com.uber.proto.foo.v1",
+            "objcClassPrefix": "UPF",
+            "phpNamespace": "Uber\\Proto\\Foo\\V1",
+            "optimizeFor": "OPTIMIZE_MODE_SPEED"
+          }
+        }
+      ],
+      "extensions": [
+        {
+          "name": "three_one",
+          "number": 100,
+          "extendeeTypeName": "uber.proto.foo.v1.ThreeFoo",
+          "label": "LABEL_OPTIONAL",
+          "type": "TYPE_INT64",
+          "options": {
+            "deprecated": true
+          }
+        },
+        {
+          "name": "three_two",
+          "number": 102,
+          "extendeeTypeName": "uber.proto.foo.v1.ThreeFoo",
+          "label": "LABEL_REPEATED",
+          "type": "TYPE_MESSAGE",
+          "typeName": "uber.proto.foo.v1.TwoBar"
+        }
       ]
     }
   ]
@@ -0,0 +1,406 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package semver parses semantic versions and ranges of semantic versions.
+//
+// See https://semver.org for semantic versions. Ranges follow the common
+// syntax used by npm and Cargo, see ParseRange.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LevelPatch is a change that only fixes bugs.
+	LevelPatch Level = iota
+	// LevelMinor is a change that adds functionality in a backwards compatible manner.
+	LevelMinor
+	// LevelMajor is a change that is not backwards compatible.
+	LevelMajor
+)
+
+var (
+	_levelToString = map[Level]string{
+		LevelPatch: "patch",
+		LevelMinor: "minor",
+		LevelMajor: "major",
+	}
+)
+
+// Level is the level of a change, which says which part of
+// a version is incremented.
+type Level int
+
+// String implements fmt.Stringer.
+func (l Level) String() string {
+	if s, ok := _levelToString[l]; ok {
+		return s
+	}
+	return strconv.Itoa(int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// Version is a semantic version.
+type Version struct {
+	Major uint64
+	Minor uint64
+	Patch uint64
+	// The pre-release version without the leading '-', or empty.
+	Prerelease string
+}
+
+// ParseVersion parses a semantic version.
+//
+// A leading 'v' is allowed, for example "v1.2.3". Build metadata after a '+' is ignored.
+func ParseVersion(s string) (Version, error) {
+	version, numParts, err := parsePartialVersion(s)
+	if err != nil {
+		return Version{}, err
+	}
+	if numParts != 3 {
+		return Version{}, fmt.Errorf("invalid semantic version %q: must have major, minor and patch versions", s)
+	}
+	return version, nil
+}
+
+// String returns the version without a leading 'v'.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Increment returns the next version after v for a change of the given level.
+//
+// If v is a pre-release version, the pre-release version is removed, and the
+// version is only incremented if the pre-release is not already for the
+// given level, for example the next minor version after "1.3.0-rc.1" is "1.3.0",
+// and the next major version after "1.3.0-rc.1" is "2.0.0".
+func (v Version) Increment(level Level) Version {
+	isPrerelease := v.Prerelease != ""
+	switch level {
+	case LevelMajor:
+		if isPrerelease && v.Minor == 0 && v.Patch == 0 {
+			return Version{Major: v.Major}
+		}
+		return Version{Major: v.Major + 1}
+	case LevelMinor:
+		if isPrerelease && v.Patch == 0 {
+			return Version{Major: v.Major, Minor: v.Minor}
+		}
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	default:
+		if isPrerelease {
+			return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		}
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// Compare returns -1 if v is lower than other, 1 if v is higher
+// than other, and 0 if they are equal.
+func (v Version) Compare(other Version) int {
+	if c := compareUint64(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint64(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint64(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// Range is a range of semantic versions.
+type Range struct {
+	// the range matches if all comparators in any of the sets match
+	comparatorSets [][]*comparator
+}
+
+// ParseRange parses a range of semantic versions.
+//
+// A range is a set of comparators separated by spaces, all of which must match,
+// and multiple sets can be separated by "||", any of which must match. Comparators
+// are one of:
+//
+//   - "=1.2.3", ">1.2.3", ">=1.2.3", "<1.2.3" and "<=1.2.3".
+//   - "1.2.3", which is the same as "=1.2.3".
+//   - "1.2" or "1.2.x", which is the same as ">=1.2.0 <1.3.0", and "1" or "1.x",
+//     which is the same as ">=1.0.0 <2.0.0". Partial versions can also be used
+//     with the other operators, for example "=1.2" is the same as "1.2", ">1.2" is
+//     the same as ">=1.3.0", and "<=1.2" is the same as "<1.3.0".
+//   - "~1.2.3", which is the same as ">=1.2.3 <1.3.0".
+//   - "^1.2.3", which is the same as ">=1.2.3 <2.0.0", or ">=0.2.3 <0.3.0" for "^0.2.3".
+//   - "*" or "x", which matches all versions.
+//
+// An empty range matches all versions, but each set of a range that is not
+// empty must have at least one comparator.
+func ParseRange(s string) (*Range, error) {
+	r := &Range{}
+	if strings.TrimSpace(s) == "" {
+		r.comparatorSets = append(r.comparatorSets, nil)
+		return r, nil
+	}
+	for _, comparatorSetString := range strings.Split(s, "||") {
+		comparatorStrings := strings.Fields(comparatorSetString)
+		if len(comparatorStrings) == 0 {
+			return nil, fmt.Errorf("invalid semantic version range %q: empty comparator set", s)
+		}
+		var comparatorSet []*comparator
+		for _, comparatorString := range comparatorStrings {
+			comparators, err := parseComparators(comparatorString)
+			if err != nil {
+				return nil, fmt.Errorf("invalid semantic version range %q: %v", s, err)
+			}
+			comparatorSet = append(comparatorSet, comparators...)
+		}
+		r.comparatorSets = append(r.comparatorSets, comparatorSet)
+	}
+	return r, nil
+}
+
+// Contains returns true if the version is within the range.
+func (r *Range) Contains(version Version) bool {
+	for _, comparatorSet := range r.comparatorSets {
+		if comparatorSetContains(comparatorSet, version) {
+			return true
+		}
+	}
+	return false
+}
+
+type comparator struct {
+	op      string
+	version Version
+}
+
+func (c *comparator) contains(version Version) bool {
+	compare := version.Compare(c.version)
+	switch c.op {
+	case "=":
+		return compare == 0
+	case ">":
+		return compare > 0
+	case ">=":
+		return compare >= 0
+	case "<":
+		return compare < 0
+	case "<=":
+		return compare <= 0
+	default:
+		return false
+	}
+}
+
+func comparatorSetContains(comparatorSet []*comparator, version Version) bool {
+	for _, comparator := range comparatorSet {
+		if !comparator.contains(version) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseComparators(s string) ([]*comparator, error) {
+	if s == "*" || s == "x" || s == "X" {
+		return nil, nil
+	}
+	switch s[0] {
+	case '~':
+		version, numParts, err := parsePartialVersion(s[1:])
+		if err != nil {
+			return nil, err
+		}
+		return newBetweenComparators(version, getPartialUpper(version, numParts)), nil
+	case '^':
+		version, numParts, err := parsePartialVersion(s[1:])
+		if err != nil {
+			return nil, err
+		}
+		var upper Version
+		switch {
+		case version.Major > 0 || numParts == 1:
+			upper = Version{Major: version.Major + 1}
+		case version.Minor > 0 || numParts == 2:
+			upper = Version{Minor: version.Minor + 1}
+		default:
+			upper = Version{Patch: version.Patch + 1}
+		}
+		return newBetweenComparators(version, upper), nil
+	}
+	op := "="
+	for _, prefix := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, prefix) {
+			op = prefix
+			s = strings.TrimPrefix(s, prefix)
+			break
+		}
+	}
+	version, numParts, err := parsePartialVersion(s)
+	if err != nil {
+		return nil, err
+	}
+	if numParts == 3 {
+		return []*comparator{{op: op, version: version}}, nil
+	}
+	// a partial version is the range of versions with the given parts,
+	// for example "1.2" is ">=1.2.0 <1.3.0"
+	upper := getPartialUpper(version, numParts)
+	switch op {
+	case "=":
+		return newBetweenComparators(version, upper), nil
+	case ">":
+		return []*comparator{{op: ">=", version: upper}}, nil
+	case "<=":
+		return []*comparator{{op: "<", version: upper}}, nil
+	default:
+		return []*comparator{{op: op, version: version}}, nil
+	}
+}
+
+// getPartialUpper returns the lowest version above all versions with the
+// major version of version if numParts is 1, or with its major and minor
+// versions otherwise.
+func getPartialUpper(version Version, numParts int) Version {
+	if numParts == 1 {
+		return Version{Major: version.Major + 1}
+	}
+	return Version{Major: version.Major, Minor: version.Minor + 1}
+}
+
+func newBetweenComparators(lower Version, upper Version) []*comparator {
+	return []*comparator{
+		{op: ">=", version: lower},
+		{op: "<", version: upper},
+	}
+}
+
+// parsePartialVersion parses a version that may be missing the minor or patch
+// version, or have "x" in their place, and returns the number of parts that
+// were present.
+func parsePartialVersion(s string) (Version, int, error) {
+	original := s
+	s = strings.TrimPrefix(s, "v")
+	if index := strings.IndexByte(s, '+'); index >= 0 {
+		s = s[:index]
+	}
+	var prerelease string
+	if index := strings.IndexByte(s, '-'); index >= 0 {
+		prerelease = s[index+1:]
+		s = s[:index]
+		if prerelease == "" {
+			return Version{}, 0, fmt.Errorf("invalid semantic version %q: empty pre-release version", original)
+		}
+	}
+	split := strings.Split(s, ".")
+	if len(split) > 3 {
+		return Version{}, 0, fmt.Errorf("invalid semantic version %q", original)
+	}
+	var numbers []uint64
+	wildcard := false
+	for _, part := range split {
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return Version{}, 0, fmt.Errorf("invalid semantic version %q: version after wildcard", original)
+		}
+		number, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Version{}, 0, fmt.Errorf("invalid semantic version %q", original)
+		}
+		numbers = append(numbers, number)
+	}
+	if len(numbers) == 0 {
+		return Version{}, 0, fmt.Errorf("invalid semantic version %q", original)
+	}
+	if prerelease != "" && len(numbers) != 3 {
+		return Version{}, 0, fmt.Errorf("invalid semantic version %q: pre-release version without patch version", original)
+	}
+	version := Version{Major: numbers[0], Prerelease: prerelease}
+	if len(numbers) > 1 {
+		version.Minor = numbers[1]
+	}
+	if len(numbers) > 2 {
+		version.Patch = numbers[2]
+	}
+	return version, len(numbers), nil
+}
+
+func compareUint64(a uint64, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares pre-release versions, where a version
+// without a pre-release version has higher precedence.
+func comparePrerelease(a string, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		if c := compareIdentifier(aIdentifiers[i], bIdentifiers[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint64(uint64(len(aIdentifiers)), uint64(len(bIdentifiers)))
+}
+
+// compareIdentifier compares pre-release identifiers, where numeric identifiers
+// are compared numerically and have lower precedence than other identifiers.
+func compareIdentifier(a string, b string) int {
+	aNumber, aErr := strconv.ParseUint(a, 10, 64)
+	bNumber, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareUint64(aNumber, bNumber)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
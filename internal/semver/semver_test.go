@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	testParseVersion(t, "1.2.3", Version{Major: 1, Minor: 2, Patch: 3})
+	testParseVersion(t, "v1.2.3", Version{Major: 1, Minor: 2, Patch: 3})
+	testParseVersion(t, "v0.0.0", Version{})
+	testParseVersion(t, "v1.2.3-rc.1", Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"})
+	testParseVersion(t, "v1.2.3+build.5", Version{Major: 1, Minor: 2, Patch: 3})
+	testParseVersionError(t, "")
+	testParseVersionError(t, "v")
+	testParseVersionError(t, "1")
+	testParseVersionError(t, "1.2")
+	testParseVersionError(t, "1.2.x")
+	testParseVersionError(t, "1.2.3.4")
+	testParseVersionError(t, "1.2.3-")
+	testParseVersionError(t, "1.-2.3")
+	testParseVersionError(t, "foo")
+}
+
+func TestVersionString(t *testing.T) {
+	assert.Equal(t, "1.2.3", Version{Major: 1, Minor: 2, Patch: 3}.String())
+	assert.Equal(t, "1.2.3-rc.1", Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}.String())
+}
+
+func TestVersionCompare(t *testing.T) {
+	testVersionCompare(t, "1.2.3", "1.2.3", 0)
+	testVersionCompare(t, "1.2.3", "1.2.4", -1)
+	testVersionCompare(t, "1.3.0", "1.2.4", 1)
+	testVersionCompare(t, "2.0.0", "1.20.0", 1)
+	testVersionCompare(t, "1.0.0-rc.1", "1.0.0", -1)
+	testVersionCompare(t, "1.0.0-alpha", "1.0.0-alpha.1", -1)
+	testVersionCompare(t, "1.0.0-alpha.1", "1.0.0-alpha.beta", -1)
+	testVersionCompare(t, "1.0.0-beta.2", "1.0.0-beta.11", -1)
+	testVersionCompare(t, "1.0.0-rc.1", "1.0.0-beta.11", 1)
+}
+
+func TestVersionIncrement(t *testing.T) {
+	testVersionIncrement(t, "1.2.3", LevelPatch, "1.2.4")
+	testVersionIncrement(t, "1.2.3", LevelMinor, "1.3.0")
+	testVersionIncrement(t, "1.2.3", LevelMajor, "2.0.0")
+	testVersionIncrement(t, "1.2.3-rc.1", LevelPatch, "1.2.3")
+	testVersionIncrement(t, "1.2.3-rc.1", LevelMinor, "1.3.0")
+	testVersionIncrement(t, "1.2.3-rc.1", LevelMajor, "2.0.0")
+	testVersionIncrement(t, "1.3.0-rc.1", LevelMinor, "1.3.0")
+	testVersionIncrement(t, "1.3.0-rc.1", LevelMajor, "2.0.0")
+	testVersionIncrement(t, "2.0.0-rc.1", LevelMajor, "2.0.0")
+	testVersionIncrement(t, "0.1.0", LevelMajor, "1.0.0")
+}
+
+func TestRange(t *testing.T) {
+	testRange(t, "", []string{"0.0.1", "1.2.3", "10.0.0"}, nil)
+	testRange(t, "*", []string{"0.0.1", "1.2.3"}, nil)
+	testRange(t, "1.2.3", []string{"1.2.3"}, []string{"1.2.4", "1.2.2"})
+	testRange(t, "=v1.2.3", []string{"1.2.3"}, []string{"1.2.4"})
+	testRange(t, ">1.2.3", []string{"1.2.4", "2.0.0"}, []string{"1.2.3", "1.0.0"})
+	testRange(t, ">=1.2", []string{"1.2.0", "2.0.0"}, []string{"1.1.9"})
+	testRange(t, "<1.2.3", []string{"1.2.2", "0.1.0"}, []string{"1.2.3"})
+	testRange(t, "<=1.2.3", []string{"1.2.3"}, []string{"1.2.4"})
+	testRange(t, ">=1.0.0 <2.0.0", []string{"1.0.0", "1.9.9"}, []string{"0.9.0", "2.0.0"})
+	testRange(t, "1", []string{"1.0.0", "1.9.9"}, []string{"2.0.0"})
+	testRange(t, "1.x", []string{"1.0.0", "1.9.9"}, []string{"2.0.0"})
+	testRange(t, "1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0", "1.1.0"})
+	testRange(t, "1.2.x", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"})
+	testRange(t, "~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.2.2", "1.3.0"})
+	testRange(t, "~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"})
+	testRange(t, "^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0"})
+	testRange(t, "^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"})
+	testRange(t, "^0.0.3", []string{"0.0.3"}, []string{"0.0.4"})
+	testRange(t, "^0.2", []string{"0.2.0", "0.2.9"}, []string{"0.3.0"})
+	testRange(t, "^0", []string{"0.0.0", "0.9.9"}, []string{"1.0.0"})
+	testRange(t, "1.x || >=3.1", []string{"1.5.0", "3.1.0"}, []string{"2.0.0", "3.0.0"})
+	testRange(t, "  ", []string{"0.0.1", "1.2.3"}, nil)
+	testRange(t, "=1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0", "1.1.9"})
+	testRange(t, "=1", []string{"1.0.0", "1.9.9"}, []string{"2.0.0", "0.9.9"})
+	testRange(t, ">1.2", []string{"1.3.0", "2.0.0"}, []string{"1.2.9", "1.2.0"})
+	testRange(t, "<=1.2", []string{"1.2.9", "1.0.0"}, []string{"1.3.0"})
+	testRange(t, "<1.2", []string{"1.1.9"}, []string{"1.2.0"})
+	testRangeError(t, "1.x ||")
+	testRangeError(t, "|| 1.x")
+	testRangeError(t, "1.x || || 3.x")
+	testRangeError(t, "1.x.3")
+	testRangeError(t, "1.*.0")
+	testRangeError(t, ">=foo")
+	testRangeError(t, "^")
+	testRangeError(t, "1.2.3.4")
+}
+
+func testParseVersion(t *testing.T, s string, expected Version) {
+	version, err := ParseVersion(s)
+	require.NoError(t, err)
+	assert.Equal(t, expected, version)
+}
+
+func testParseVersionError(t *testing.T, s string) {
+	_, err := ParseVersion(s)
+	assert.Error(t, err, s)
+}
+
+func testVersionCompare(t *testing.T, a string, b string, expected int) {
+	aVersion, err := ParseVersion(a)
+	require.NoError(t, err)
+	bVersion, err := ParseVersion(b)
+	require.NoError(t, err)
+	assert.Equal(t, expected, aVersion.Compare(bVersion), "%s %s", a, b)
+	assert.Equal(t, -expected, bVersion.Compare(aVersion), "%s %s", b, a)
+}
+
+func testVersionIncrement(t *testing.T, version string, level Level, expected string) {
+	parsedVersion, err := ParseVersion(version)
+	require.NoError(t, err)
+	assert.Equal(t, expected, parsedVersion.Increment(level).String(), "%s %s", version, level)
+}
+
+func testRange(t *testing.T, s string, contains []string, notContains []string) {
+	r, err := ParseRange(s)
+	require.NoError(t, err)
+	for _, versionString := range contains {
+		version, err := ParseVersion(versionString)
+		require.NoError(t, err)
+		assert.True(t, r.Contains(version), "%q should contain %s", s, versionString)
+	}
+	for _, versionString := range notContains {
+		version, err := ParseVersion(versionString)
+		require.NoError(t, err)
+		assert.False(t, r.Contains(version), "%q should not contain %s", s, versionString)
+	}
+}
+
+func testRangeError(t *testing.T, s string) {
+	_, err := ParseRange(s)
+	assert.Error(t, err, s)
+}
@@ -28,9 +28,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/uber/prototool/internal/protostrs"
 	"github.com/uber/prototool/internal/strs"
 	"go.uber.org/zap"
 	yaml "gopkg.in/yaml.v2"
@@ -210,6 +212,22 @@ func externalConfigToConfig(develMode bool, e ExternalConfig, dirPath string) (C
 		includePath = filepath.Clean(includePath)
 		includePaths = append(includePaths, includePath)
 	}
+	compileBackend, err := ParseCompileBackend(e.Compile.Backend)
+	if err != nil {
+		return Config{}, err
+	}
+	breakMode, err := ParseBreakMode(e.Break.Mode)
+	if err != nil {
+		return Config{}, err
+	}
+	breakIgnoreIDToPackages, breakIgnoreIDToFileNames, err := getBreakIgnores(e, dirPath, includePaths)
+	if err != nil {
+		return Config{}, err
+	}
+	breakExcludeStabilities, err := getBreakExcludeStabilities(e)
+	if err != nil {
+		return Config{}, err
+	}
 	ignoreIDToFilePaths := make(map[string][]string)
 	for _, ignore := range e.Lint.Ignores {
 		id := strings.ToUpper(ignore.ID)
@@ -224,6 +242,21 @@ func externalConfigToConfig(develMode bool, e ExternalConfig, dirPath string) (C
 			ignoreIDToFilePaths[id] = append(ignoreIDToFilePaths[id], protoFilePath)
 		}
 	}
+	lintCustomRules, err := getLintCustomRules(e, dirPath)
+	if err != nil {
+		return Config{}, err
+	}
+	lintPlugins, err := getLintPlugins(e, dirPath)
+	if err != nil {
+		return Config{}, err
+	}
+	for _, lintPlugin := range lintPlugins {
+		for _, lintCustomRule := range lintCustomRules {
+			if lintPlugin.ID == lintCustomRule.ID {
+				return Config{}, fmt.Errorf("lint plugin %s has the same id as a custom lint rule", lintPlugin.ID)
+			}
+		}
+	}
 
 	genPlugins := make([]GenPlugin, len(e.Generate.Plugins))
 	for i, plugin := range e.Generate.Plugins {
@@ -330,6 +363,11 @@ func externalConfigToConfig(develMode bool, e ExternalConfig, dirPath string) (C
 		if e.Lint.AllowSuppression {
 			return Config{}, fmt.Errorf("allow_suppression is not allowed outside of internal prototool tests")
 		}
+		for _, plugin := range e.Lint.Plugins {
+			if plugin.SuppressableAnnotation != "" {
+				return Config{}, fmt.Errorf("suppressable_annotation is not allowed outside of internal prototool tests")
+			}
+		}
 	}
 
 	config := Config{
@@ -340,6 +378,8 @@ func externalConfigToConfig(develMode bool, e ExternalConfig, dirPath string) (C
 			IncludePaths:          includePaths,
 			IncludeWellKnownTypes: true, // Always include the well-known types.
 			AllowUnusedImports:    e.Protoc.AllowUnusedImports,
+			Backend:               compileBackend,
+			DisableCache:          e.Compile.DisableCache,
 		},
 		Create: CreateConfig{
 			DirPathToBasePackage: createDirPathToBasePackage,
@@ -353,10 +393,19 @@ func externalConfigToConfig(develMode bool, e ExternalConfig, dirPath string) (C
 			FileHeader:          fileHeader,
 			JavaPackagePrefix:   e.Lint.JavaPackagePrefix,
 			AllowSuppression:    e.Lint.AllowSuppression,
+			CustomRules:         lintCustomRules,
+			Plugins:             lintPlugins,
 		},
 		Break: BreakConfig{
-			IncludeBeta:   e.Break.IncludeBeta,
-			AllowBetaDeps: e.Break.AllowBetaDeps,
+			IncludeBeta:            e.Break.IncludeBeta,
+			AllowBetaDeps:          e.Break.AllowBetaDeps,
+			Mode:                   breakMode,
+			IncludeIDs:             strs.SortUniqModify(e.Break.Rules.Add, strings.ToUpper),
+			ExcludeIDs:             strs.SortUniqModify(e.Break.Rules.Remove, strings.ToUpper),
+			IgnoreIDToPackages:     breakIgnoreIDToPackages,
+			IgnoreIDToFileNames:    breakIgnoreIDToFileNames,
+			IgnoreUnstablePackages: e.Break.IgnoreUnstablePackages,
+			ExcludeStabilities:     breakExcludeStabilities,
 		},
 		Gen: GenConfig{
 			GoPluginOptions: GenGoPluginOptions{
@@ -388,9 +437,187 @@ func externalConfigToConfig(develMode bool, e ExternalConfig, dirPath string) (C
 	if intersection := strs.Intersection(config.Lint.IncludeIDs, config.Lint.ExcludeIDs); len(intersection) > 0 {
 		return Config{}, fmt.Errorf("config had intersection of %v between lint_include and lint_exclude", intersection)
 	}
+	if intersection := strs.Intersection(config.Break.IncludeIDs, config.Break.ExcludeIDs); len(intersection) > 0 {
+		return Config{}, fmt.Errorf("config had intersection of %v between break.rules.add and break.rules.remove", intersection)
+	}
 	return config, nil
 }
 
+// getBreakIgnores returns the maps of ID to package names and ID to file names
+// to ignore for breaking change detection.
+//
+// File names are made relative to dirPath.
+func getBreakIgnores(e ExternalConfig, dirPath string, includePaths []string) (map[string][]string, map[string][]string, error) {
+	ignoreIDToPackages := make(map[string][]string)
+	ignoreIDToFileNames := make(map[string][]string)
+	for _, ignore := range e.Break.Ignores {
+		id := strings.ToUpper(ignore.ID)
+		if id == "" {
+			return nil, nil, fmt.Errorf("id for break ignore is empty")
+		}
+		if len(ignore.Packages) == 0 && len(ignore.Files) == 0 {
+			return nil, nil, fmt.Errorf("break ignore %s must have packages or files", id)
+		}
+		ignoreIDToPackages[id] = append(ignoreIDToPackages[id], ignore.Packages...)
+		for _, filePath := range ignore.Files {
+			if !filepath.IsAbs(filePath) {
+				filePath = filepath.Join(dirPath, filePath)
+			}
+			// the files are matched against the names of files in FileDescriptorSets
+			fileName, ok := GetFileDescriptorProtoName(includePaths, dirPath, filepath.Clean(filePath))
+			if !ok {
+				return nil, nil, fmt.Errorf("break ignore %s has file %s that is not within the directory of the config file or an include path", id, filePath)
+			}
+			ignoreIDToFileNames[id] = append(ignoreIDToFileNames[id], fileName)
+		}
+	}
+	for id, packages := range ignoreIDToPackages {
+		ignoreIDToPackages[id] = strs.SortUniq(packages)
+	}
+	for id, fileNames := range ignoreIDToFileNames {
+		ignoreIDToFileNames[id] = strs.SortUniq(fileNames)
+	}
+	return ignoreIDToPackages, ignoreIDToFileNames, nil
+}
+
+// getBreakExcludeStabilities parses the stability levels to exclude from
+// breaking change detection.
+//
+// Returns nil if there are no stability levels to exclude.
+func getBreakExcludeStabilities(e ExternalConfig) ([]protostrs.Stability, error) {
+	if len(e.Break.ExcludeStabilityLevels) == 0 {
+		return nil, nil
+	}
+	stabilities := make([]protostrs.Stability, 0, len(e.Break.ExcludeStabilityLevels))
+	seen := make(map[protostrs.Stability]struct{}, len(e.Break.ExcludeStabilityLevels))
+	for _, level := range e.Break.ExcludeStabilityLevels {
+		stability, err := protostrs.ParseStability(level)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[stability]; ok {
+			continue
+		}
+		seen[stability] = struct{}{}
+		stabilities = append(stabilities, stability)
+	}
+	sort.Slice(stabilities, func(i int, j int) bool { return stabilities[i] < stabilities[j] })
+	return stabilities, nil
+}
+
+// getLintCustomRules validates the custom lint rules and compiles their regexps.
+//
+// Returns nil if there are no custom lint rules.
+func getLintCustomRules(e ExternalConfig, dirPath string) ([]LintCustomRule, error) {
+	if len(e.Lint.CustomRules) == 0 {
+		return nil, nil
+	}
+	lintCustomRules := make([]LintCustomRule, 0, len(e.Lint.CustomRules))
+	seenIDs := make(map[string]struct{}, len(e.Lint.CustomRules))
+	for _, rule := range e.Lint.CustomRules {
+		id := strings.ToUpper(rule.ID)
+		if id == "" {
+			return nil, fmt.Errorf("id for custom lint rule is empty")
+		}
+		if _, ok := seenIDs[id]; ok {
+			return nil, fmt.Errorf("duplicate custom lint rule id: %s", id)
+		}
+		seenIDs[id] = struct{}{}
+		lintCustomRuleType, err := ParseLintCustomRuleType(rule.Type)
+		if err != nil {
+			return nil, fmt.Errorf("custom lint rule %s: %v", id, err)
+		}
+		lintCustomRule := LintCustomRule{
+			ID:         id,
+			Purpose:    rule.Purpose,
+			Type:       lintCustomRuleType,
+			FieldName:  rule.FieldName,
+			OptionName: rule.OptionName,
+		}
+		for _, directory := range strs.SortUniq(rule.Directories) {
+			if filepath.IsAbs(directory) {
+				return nil, fmt.Errorf("custom lint rule %s: directory must be relative: %s", id, directory)
+			}
+			lintCustomRule.DirPaths = append(lintCustomRule.DirPaths, filepath.Clean(filepath.Join(dirPath, directory)))
+		}
+		if rule.MessageNameRegexp != "" {
+			if lintCustomRule.MessageNameRegexp, err = regexp.Compile(rule.MessageNameRegexp); err != nil {
+				return nil, fmt.Errorf("custom lint rule %s: invalid message_name_regexp: %v", id, err)
+			}
+		}
+		if rule.FieldNameRegexp != "" {
+			if lintCustomRule.FieldNameRegexp, err = regexp.Compile(rule.FieldNameRegexp); err != nil {
+				return nil, fmt.Errorf("custom lint rule %s: invalid field_name_regexp: %v", id, err)
+			}
+		}
+		switch lintCustomRuleType {
+		case LintCustomRuleTypeMessageFieldRequired:
+			if rule.FieldName == "" {
+				return nil, fmt.Errorf("custom lint rule %s: field_name is required for type %s", id, lintCustomRuleType)
+			}
+			if rule.FieldNameRegexp != "" || rule.OptionName != "" {
+				return nil, fmt.Errorf("custom lint rule %s: only directories, message_name_regexp and field_name can be set for type %s", id, lintCustomRuleType)
+			}
+		case LintCustomRuleTypeFieldNameForbidden:
+			if rule.FieldNameRegexp == "" {
+				return nil, fmt.Errorf("custom lint rule %s: field_name_regexp is required for type %s", id, lintCustomRuleType)
+			}
+			if rule.FieldName != "" || rule.OptionName != "" {
+				return nil, fmt.Errorf("custom lint rule %s: only directories, message_name_regexp and field_name_regexp can be set for type %s", id, lintCustomRuleType)
+			}
+		case LintCustomRuleTypeFileOptionRequired:
+			if rule.OptionName == "" {
+				return nil, fmt.Errorf("custom lint rule %s: option_name is required for type %s", id, lintCustomRuleType)
+			}
+			if rule.MessageNameRegexp != "" || rule.FieldName != "" || rule.FieldNameRegexp != "" {
+				return nil, fmt.Errorf("custom lint rule %s: only directories and option_name can be set for type %s", id, lintCustomRuleType)
+			}
+		}
+		lintCustomRules = append(lintCustomRules, lintCustomRule)
+	}
+	sort.Slice(lintCustomRules, func(i int, j int) bool { return lintCustomRules[i].ID < lintCustomRules[j].ID })
+	return lintCustomRules, nil
+}
+
+// getLintPlugins validates the lint plugins.
+//
+// Returns nil if there are no lint plugins.
+func getLintPlugins(e ExternalConfig, dirPath string) ([]LintPlugin, error) {
+	if len(e.Lint.Plugins) == 0 {
+		return nil, nil
+	}
+	lintPlugins := make([]LintPlugin, 0, len(e.Lint.Plugins))
+	seenIDs := make(map[string]struct{}, len(e.Lint.Plugins))
+	for _, plugin := range e.Lint.Plugins {
+		id := strings.ToUpper(plugin.ID)
+		if id == "" {
+			return nil, fmt.Errorf("id for lint plugin is empty")
+		}
+		if _, ok := seenIDs[id]; ok {
+			return nil, fmt.Errorf("duplicate lint plugin id: %s", id)
+		}
+		seenIDs[id] = struct{}{}
+		if plugin.Path == "" {
+			return nil, fmt.Errorf("path for lint plugin %s is empty", id)
+		}
+		path := plugin.Path
+		// relative paths with a directory are relative to the config file,
+		// otherwise the executable is looked up on the PATH
+		if !filepath.IsAbs(path) && strings.ContainsRune(path, filepath.Separator) {
+			path = filepath.Join(dirPath, path)
+		}
+		lintPlugins = append(lintPlugins, LintPlugin{
+			ID:                     id,
+			Purpose:                plugin.Purpose,
+			GetPath:                getPluginPathFunc(path),
+			Args:                   plugin.Args,
+			SuppressableAnnotation: plugin.SuppressableAnnotation,
+		})
+	}
+	sort.Slice(lintPlugins, func(i int, j int) bool { return lintPlugins[i].ID < lintPlugins[j].ID })
+	return lintPlugins, nil
+}
+
 func getExcludePrefixesForDir(dirPath string) ([]string, error) {
 	filePath, err := getSingleFilePathForDir(dirPath)
 	if err != nil {
@@ -22,9 +22,12 @@ package settings
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/uber/prototool/internal/protostrs"
 	"go.uber.org/zap"
 )
 
@@ -44,6 +47,39 @@ const (
 	GenPluginTypeGogo
 )
 
+const (
+	// CompileBackendProtoc says to compile by shelling out to protoc.
+	// This is the default.
+	CompileBackendProtoc CompileBackend = iota
+	// CompileBackendGo says to compile in-process with a pure Golang
+	// Protobuf parser and linker.
+	CompileBackendGo
+)
+
+const (
+	// BreakModeSource says to check for changes that break generated
+	// source code, JSON or the wire format.
+	// This is the default.
+	BreakModeSource BreakMode = iota
+	// BreakModeWireJSON says to check for changes that break JSON
+	// or the wire format.
+	BreakModeWireJSON
+	// BreakModeWire says to only check for changes that break the wire format.
+	BreakModeWire
+)
+
+const (
+	// LintCustomRuleTypeMessageFieldRequired says that all messages
+	// with names matching MessageNameRegexp must have a field named FieldName.
+	LintCustomRuleTypeMessageFieldRequired LintCustomRuleType = iota + 1
+	// LintCustomRuleTypeFieldNameForbidden says that no field names
+	// in messages with names matching MessageNameRegexp may match FieldNameRegexp.
+	LintCustomRuleTypeFieldNameForbidden
+	// LintCustomRuleTypeFileOptionRequired says that all files must
+	// set the file option OptionName.
+	LintCustomRuleTypeFileOptionRequired
+)
+
 var (
 	// ConfigFilenames are all possible config filenames.
 	ConfigFilenames = []string{
@@ -72,6 +108,39 @@ var (
 		GenPluginTypeGo:   false,
 		GenPluginTypeGogo: true,
 	}
+
+	_compileBackendToString = map[CompileBackend]string{
+		CompileBackendProtoc: "protoc",
+		CompileBackendGo:     "go",
+	}
+	_stringToCompileBackend = map[string]CompileBackend{
+		"":       CompileBackendProtoc,
+		"protoc": CompileBackendProtoc,
+		"go":     CompileBackendGo,
+	}
+
+	_breakModeToString = map[BreakMode]string{
+		BreakModeSource:   "source",
+		BreakModeWireJSON: "wire_json",
+		BreakModeWire:     "wire",
+	}
+	_stringToBreakMode = map[string]BreakMode{
+		"":          BreakModeSource,
+		"source":    BreakModeSource,
+		"wire_json": BreakModeWireJSON,
+		"wire":      BreakModeWire,
+	}
+
+	_lintCustomRuleTypeToString = map[LintCustomRuleType]string{
+		LintCustomRuleTypeMessageFieldRequired: "message_field_required",
+		LintCustomRuleTypeFieldNameForbidden:   "field_name_forbidden",
+		LintCustomRuleTypeFileOptionRequired:   "file_option_required",
+	}
+	_stringToLintCustomRuleType = map[string]LintCustomRuleType{
+		"message_field_required": LintCustomRuleTypeMessageFieldRequired,
+		"field_name_forbidden":   LintCustomRuleTypeFieldNameForbidden,
+		"file_option_required":   LintCustomRuleTypeFileOptionRequired,
+	}
 )
 
 // GenPluginType is a type of protoc plugin.
@@ -112,6 +181,72 @@ func ParseGenPluginType(s string) (GenPluginType, error) {
 	return genPluginType, nil
 }
 
+// CompileBackend is the backend used to compile Protobuf files.
+type CompileBackend int
+
+// String implements fmt.Stringer.
+func (c CompileBackend) String() string {
+	if s, ok := _compileBackendToString[c]; ok {
+		return s
+	}
+	return strconv.Itoa(int(c))
+}
+
+// ParseCompileBackend parses the CompileBackend from the given string.
+//
+// Input is case-insensitive. The empty string parses to CompileBackendProtoc.
+func ParseCompileBackend(s string) (CompileBackend, error) {
+	compileBackend, ok := _stringToCompileBackend[strings.ToLower(s)]
+	if !ok {
+		return CompileBackendProtoc, fmt.Errorf("could not parse %s to a CompileBackend", s)
+	}
+	return compileBackend, nil
+}
+
+// BreakMode is the kind of compatibility that is checked for breaking changes.
+type BreakMode int
+
+// String implements fmt.Stringer.
+func (b BreakMode) String() string {
+	if s, ok := _breakModeToString[b]; ok {
+		return s
+	}
+	return strconv.Itoa(int(b))
+}
+
+// ParseBreakMode parses the BreakMode from the given string.
+//
+// Input is case-insensitive. The empty string parses to BreakModeSource.
+func ParseBreakMode(s string) (BreakMode, error) {
+	breakMode, ok := _stringToBreakMode[strings.ToLower(s)]
+	if !ok {
+		return BreakModeSource, fmt.Errorf("could not parse %s to a BreakMode", s)
+	}
+	return breakMode, nil
+}
+
+// LintCustomRuleType is the type of a custom lint rule.
+type LintCustomRuleType int
+
+// String implements fmt.Stringer.
+func (l LintCustomRuleType) String() string {
+	if s, ok := _lintCustomRuleTypeToString[l]; ok {
+		return s
+	}
+	return strconv.Itoa(int(l))
+}
+
+// ParseLintCustomRuleType parses the LintCustomRuleType from the given string.
+//
+// Input is case-insensitive.
+func ParseLintCustomRuleType(s string) (LintCustomRuleType, error) {
+	lintCustomRuleType, ok := _stringToLintCustomRuleType[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("could not parse %s to a LintCustomRuleType", s)
+	}
+	return lintCustomRuleType, nil
+}
+
 // Config is the main config.
 //
 // Configs are derived from ExternalConfigs, which represent the Config
@@ -161,6 +296,11 @@ type CompileConfig struct {
 	IncludeWellKnownTypes bool
 	// AllowUnusedImports says to not error when an import is not used.
 	AllowUnusedImports bool
+	// Backend is the backend to compile with.
+	// The default is CompileBackendProtoc.
+	Backend CompileBackend
+	// DisableCache says to never use or write to the compile cache.
+	DisableCache bool
 }
 
 // CreateConfig is the create config.
@@ -209,6 +349,72 @@ type LintConfig struct {
 	JavaPackagePrefix string
 	// AllowSuppression says to honor @suppresswarnings annotations.
 	AllowSuppression bool
+	// CustomRules are the lint rules declared in the config file.
+	// These are always run in addition to the configured linters
+	// unless their ID is in ExcludeIDs.
+	// Expected to be sorted by ID.
+	CustomRules []LintCustomRule
+	// Plugins are the external lint plugins declared in the config file.
+	// These are always run in addition to the configured linters
+	// unless their ID is in ExcludeIDs.
+	// Expected to be sorted by ID.
+	Plugins []LintPlugin
+}
+
+// LintCustomRule is a lint rule declared in the config file.
+type LintCustomRule struct {
+	// ID is the ID of the rule.
+	// Expected to be all upper-case.
+	// Expected to be unique.
+	ID string
+	// Purpose is the human-readable purpose of the rule.
+	// If empty, a purpose is derived from the rule.
+	Purpose string
+	// Type is the type of the rule.
+	Type LintCustomRuleType
+	// DirPaths are the directories the rule applies to, including
+	// all their subdirectories. If empty, the rule applies to all files.
+	// Expected to be absolute paths.
+	DirPaths []string
+	// MessageNameRegexp matches the names of the messages the rule applies to.
+	// If nil, the rule applies to all messages.
+	// Only used for LintCustomRuleTypeMessageFieldRequired and
+	// LintCustomRuleTypeFieldNameForbidden.
+	MessageNameRegexp *regexp.Regexp
+	// FieldName is the name of the required field.
+	// Only used for LintCustomRuleTypeMessageFieldRequired.
+	FieldName string
+	// FieldNameRegexp matches the forbidden field names.
+	// Only used for LintCustomRuleTypeFieldNameForbidden.
+	FieldNameRegexp *regexp.Regexp
+	// OptionName is the name of the required file option.
+	// Only used for LintCustomRuleTypeFileOptionRequired.
+	OptionName string
+}
+
+// LintPlugin is an external executable that checks Protobuf files.
+//
+// The plugin is given the compiled FileDescriptorSet with imports and
+// source info on stdin, and the names of the files to check as arguments
+// after Args. It prints failures as JSON objects matching text.Failure
+// to stdout.
+type LintPlugin struct {
+	// ID is the ID of the failures of the plugin.
+	// Expected to be all upper-case.
+	// Expected to be unique.
+	ID string
+	// Purpose is the human-readable purpose of the plugin.
+	// If empty, a purpose is derived from the plugin path.
+	Purpose string
+	// The path to the executable.
+	// This is a function so that we defer path lookups, see GenPlugin.
+	GetPath func() (string, error) `json:"-"`
+	// Args are the arguments to pass before the file names.
+	Args []string
+	// SuppressableAnnotation is the annotation that suppresses failures
+	// of the plugin when AllowSuppression is set. If empty, failures
+	// cannot be suppressed.
+	SuppressableAnnotation string
 }
 
 // BreakConfig is the break config.
@@ -218,6 +424,40 @@ type BreakConfig struct {
 	// Allow stable packages to depend on beta packages.
 	// This is implicitly set if IncludeBeta is set.
 	AllowBetaDeps bool
+	// Mode is the kind of compatibility to check.
+	// The default is BreakModeSource.
+	Mode BreakMode
+	// IncludeIDs are the list of checker IDs to use in addition to the
+	// checkers for the Mode.
+	// Expected to be all uppercase.
+	// Expected to be unique.
+	// Expected to have no overlap with ExcludeIDs.
+	IncludeIDs []string
+	// ExcludeIDs are the list of checker IDs to exclude.
+	// Expected to be all uppercase.
+	// Expected to be unique.
+	// Expected to have no overlap with IncludeIDs.
+	ExcludeIDs []string
+	// IgnoreIDToPackages is the map of ID to package names to ignore.
+	// IDs expected to be all upper-case.
+	IgnoreIDToPackages map[string][]string
+	// IgnoreIDToFileNames is the map of ID to file names to ignore.
+	// IDs expected to be all upper-case.
+	// File names are the names of files in FileDescriptorSets, see
+	// GetFileDescriptorProtoName.
+	// The top-level enums, messages, services and extensions declared
+	// in these files are ignored.
+	IgnoreIDToFileNames map[string][]string
+	// IgnoreUnstablePackages says to ignore alpha, beta and test packages
+	// in breaking change detection regardless of IncludeBeta.
+	IgnoreUnstablePackages bool
+	// ExcludeStabilities are the stability levels of the packages to
+	// exclude from breaking change detection.
+	// If set, this takes precedence over IncludeBeta.
+	// If empty, alpha, beta and test packages are excluded unless
+	// IncludeBeta is set.
+	// Expected to have no duplicates.
+	ExcludeStabilities []protostrs.Stability
 }
 
 // GenConfig is the gen config.
@@ -298,6 +538,10 @@ type ExternalConfig struct {
 		Version            string   `json:"version,omitempty" yaml:"version,omitempty"`
 		Includes           []string `json:"includes,omitempty" yaml:"includes,omitempty"`
 	} `json:"protoc,omitempty" yaml:"protoc,omitempty"`
+	Compile struct {
+		Backend      string `json:"backend,omitempty" yaml:"backend,omitempty"`
+		DisableCache bool   `json:"disable_cache,omitempty" yaml:"disable_cache,omitempty"`
+	} `json:"compile,omitempty" yaml:"compile,omitempty"`
 	Create struct {
 		Packages []struct {
 			Directory string `json:"directory,omitempty" yaml:"directory,omitempty"`
@@ -323,10 +567,40 @@ type ExternalConfig struct {
 		JavaPackagePrefix string `json:"java_package_prefix,omitempty" yaml:"java_package_prefix,omitempty"`
 		// devel-mode only
 		AllowSuppression bool `json:"allow_suppression,omitempty" yaml:"allow_suppression,omitempty"`
+		CustomRules      []struct {
+			ID                string   `json:"id,omitempty" yaml:"id,omitempty"`
+			Purpose           string   `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+			Type              string   `json:"type,omitempty" yaml:"type,omitempty"`
+			Directories       []string `json:"directories,omitempty" yaml:"directories,omitempty"`
+			MessageNameRegexp string   `json:"message_name_regexp,omitempty" yaml:"message_name_regexp,omitempty"`
+			FieldName         string   `json:"field_name,omitempty" yaml:"field_name,omitempty"`
+			FieldNameRegexp   string   `json:"field_name_regexp,omitempty" yaml:"field_name_regexp,omitempty"`
+			OptionName        string   `json:"option_name,omitempty" yaml:"option_name,omitempty"`
+		} `json:"custom_rules,omitempty" yaml:"custom_rules,omitempty"`
+		Plugins []struct {
+			ID      string   `json:"id,omitempty" yaml:"id,omitempty"`
+			Purpose string   `json:"purpose,omitempty" yaml:"purpose,omitempty"`
+			Path    string   `json:"path,omitempty" yaml:"path,omitempty"`
+			Args    []string `json:"args,omitempty" yaml:"args,omitempty"`
+			// devel-mode only
+			SuppressableAnnotation string `json:"suppressable_annotation,omitempty" yaml:"suppressable_annotation,omitempty"`
+		} `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 	} `json:"lint,omitempty" yaml:"lint,omitempty"`
 	Break struct {
-		IncludeBeta   bool `json:"include_beta,omitempty" yaml:"include_beta,omitempty"`
-		AllowBetaDeps bool `json:"allow_beta_deps,omitempty" yaml:"allow_beta_deps,omitempty"`
+		IncludeBeta   bool   `json:"include_beta,omitempty" yaml:"include_beta,omitempty"`
+		AllowBetaDeps bool   `json:"allow_beta_deps,omitempty" yaml:"allow_beta_deps,omitempty"`
+		Mode          string `json:"mode,omitempty" yaml:"mode,omitempty"`
+		Rules         struct {
+			Add    []string `json:"add,omitempty" yaml:"add,omitempty"`
+			Remove []string `json:"remove,omitempty" yaml:"remove,omitempty"`
+		} `json:"rules,omitempty" yaml:"rules,omitempty"`
+		Ignores []struct {
+			ID       string   `json:"id,omitempty" yaml:"id,omitempty"`
+			Packages []string `json:"packages,omitempty" yaml:"packages,omitempty"`
+			Files    []string `json:"files,omitempty" yaml:"files,omitempty"`
+		} `json:"ignores,omitempty" yaml:"ignores,omitempty"`
+		IgnoreUnstablePackages bool     `json:"ignore_unstable_packages,omitempty" yaml:"ignore_unstable_packages,omitempty"`
+		ExcludeStabilityLevels []string `json:"exclude_stability_levels,omitempty" yaml:"exclude_stability_levels,omitempty"`
 	} `json:"break,omitempty" yaml:"break,omitempty"`
 	Generate struct {
 		GoOptions struct {
@@ -346,6 +620,32 @@ type ExternalConfig struct {
 	} `json:"generate,omitempty" yaml:"generate,omitempty"`
 }
 
+// GetFileDescriptorProtoName returns the name that the file has in compiled
+// FileDescriptorSets, as protoc names it.
+//
+// This is the path of the file relative to the first of the include paths
+// that contains it, or otherwise relative to dirPath, the directory of the
+// config file, which the compiler adds as an include path in that case.
+// The name uses forward slashes. If the file is within none of these
+// directories, this returns false.
+func GetFileDescriptorProtoName(includePaths []string, dirPath string, filePath string) (string, bool) {
+	return GetIncludeRelPath(append(append([]string{}, includePaths...), dirPath), filePath)
+}
+
+// GetIncludeRelPath returns the path of the file relative to the first of
+// the include paths that contains it, using forward slashes. If the file is
+// within none of the include paths, this returns false.
+func GetIncludeRelPath(includePaths []string, filePath string) (string, bool) {
+	for _, includePath := range includePaths {
+		relPath, err := filepath.Rel(includePath, filePath)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return filepath.ToSlash(relPath), true
+	}
+	return "", false
+}
+
 // ConfigProvider provides Configs.
 type ConfigProvider interface {
 	// GetForDir tries to find a file named by one of the ConfigFilenames starting in the
@@ -0,0 +1,253 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jhump/protoreflect/desc"
+)
+
+// completion is the result of completing the text before the cursor.
+type completion struct {
+	// Start is the byte index of the start of the completed word.
+	Start int
+	// Candidates are the sorted replacements for the completed word.
+	Candidates []string
+	// Suffix is appended to the replacement if there is a single candidate.
+	Suffix string
+}
+
+// complete completes the last word of the given text before the cursor.
+//
+// Commands, the services and methods given to commands, header keys, and the
+// field names of the JSON request of the call command are completed.
+// Returns nil if there is nothing to complete.
+func (s *shell) complete(text string) *completion {
+	command, rest, ok := splitWord(text)
+	if !ok {
+		return newCompletion(len(text)-len(command), command, getCommandNames(), " ")
+	}
+	restStart := len(text) - len(rest)
+	switch command {
+	case "help":
+		return newCompletion(restStart, rest, getCommandNames(), "")
+	case "methods":
+		return newCompletion(restStart, rest, s.index.serviceNames, "")
+	case "unheader":
+		keys := make([]string, 0, len(s.headers))
+		for key := range s.headers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return newCompletion(restStart, rest, keys, "")
+	case "template":
+		return newCompletion(restStart, rest, s.index.methodNames, "")
+	case "call":
+		methodName, data, ok := splitWord(rest)
+		if !ok {
+			return newCompletion(restStart, rest, s.index.methodNames, " ")
+		}
+		methodDescriptor, ok := s.index.methodNameToMethodDescriptor[methodName]
+		if !ok {
+			return nil
+		}
+		completion := completeJSONKey(data, methodDescriptor.GetInputType())
+		if completion != nil {
+			completion.Start += len(text) - len(data)
+		}
+		return completion
+	default:
+		return nil
+	}
+}
+
+// completeJSONKey completes the key of a JSON object if the text ends
+// in a key or where a key is expected.
+//
+// The fields of the message of the innermost object are the candidates.
+func completeJSONKey(text string, messageDescriptor *desc.MessageDescriptor) *completion {
+	type container struct {
+		isObject bool
+		// nil if unknown
+		messageDescriptor *desc.MessageDescriptor
+	}
+	var stack []*container
+	inString := false
+	stringIsKey := false
+	stringStart := 0
+	escape := false
+	expectKey := false
+	lastKey := ""
+	for i, c := range text {
+		if inString {
+			switch {
+			case escape:
+				escape = false
+			case c == '\\':
+				escape = true
+			case c == '"':
+				inString = false
+				if stringIsKey {
+					lastKey = text[stringStart:i]
+				}
+			}
+			continue
+		}
+		var top *container
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		switch c {
+		case '"':
+			inString = true
+			stringIsKey = expectKey
+			stringStart = i + 1
+			expectKey = false
+		case '{', '[':
+			var iMessageDescriptor *desc.MessageDescriptor
+			switch {
+			case top == nil:
+				// each message of a stream is a top-level object
+				iMessageDescriptor = messageDescriptor
+			case top.isObject:
+				iMessageDescriptor = getFieldMessageDescriptor(top.messageDescriptor, lastKey)
+			default:
+				// the elements of an array have the message of the array
+				iMessageDescriptor = top.messageDescriptor
+			}
+			stack = append(stack, &container{
+				isObject:          c == '{',
+				messageDescriptor: iMessageDescriptor,
+			})
+			expectKey = c == '{'
+		case '}', ']':
+			if top != nil {
+				stack = stack[:len(stack)-1]
+			}
+			expectKey = false
+		case ',':
+			expectKey = top != nil && top.isObject
+		case ':':
+			expectKey = false
+		}
+	}
+	if len(stack) == 0 {
+		return nil
+	}
+	top := stack[len(stack)-1]
+	if top.messageDescriptor == nil {
+		return nil
+	}
+	fieldNames := getFieldNames(top.messageDescriptor)
+	switch {
+	case inString && stringIsKey:
+		return newCompletion(stringStart, text[stringStart:], fieldNames, `": `)
+	case !inString && expectKey:
+		quotedFieldNames := make([]string, len(fieldNames))
+		for i, fieldName := range fieldNames {
+			quotedFieldNames[i] = `"` + fieldName
+		}
+		return newCompletion(len(text), "", quotedFieldNames, `": `)
+	default:
+		return nil
+	}
+}
+
+// getFieldMessageDescriptor returns the message of the field with the given
+// JSON or Protobuf name, or nil if the field is not a message that is
+// represented by a JSON object or an array of JSON objects.
+func getFieldMessageDescriptor(messageDescriptor *desc.MessageDescriptor, fieldName string) *desc.MessageDescriptor {
+	if messageDescriptor == nil {
+		return nil
+	}
+	for _, fieldDescriptor := range messageDescriptor.GetFields() {
+		if fieldDescriptor.GetJSONName() != fieldName && fieldDescriptor.GetName() != fieldName {
+			continue
+		}
+		fieldMessageDescriptor := fieldDescriptor.GetMessageType()
+		if fieldMessageDescriptor == nil || fieldDescriptor.IsMap() {
+			return nil
+		}
+		if _, ok := _wellKnownTypeToTemplate[fieldMessageDescriptor.GetFullyQualifiedName()]; ok {
+			return nil
+		}
+		return fieldMessageDescriptor
+	}
+	return nil
+}
+
+func getFieldNames(messageDescriptor *desc.MessageDescriptor) []string {
+	fieldNames := make([]string, 0, len(messageDescriptor.GetFields()))
+	for _, fieldDescriptor := range messageDescriptor.GetFields() {
+		fieldNames = append(fieldNames, fieldDescriptor.GetJSONName())
+	}
+	sort.Strings(fieldNames)
+	return fieldNames
+}
+
+// newCompletion returns a completion for the word at start with the
+// candidates that have the word as a prefix, or nil if there are none.
+func newCompletion(start int, word string, candidates []string, suffix string) *completion {
+	var matchingCandidates []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matchingCandidates = append(matchingCandidates, candidate)
+		}
+	}
+	if len(matchingCandidates) == 0 {
+		return nil
+	}
+	return &completion{
+		Start:      start,
+		Candidates: matchingCandidates,
+		Suffix:     suffix,
+	}
+}
+
+// splitWord splits the first word from the rest of the text.
+//
+// Returns false if the text does not have whitespace after the first
+// word, that is the first word is not complete.
+func splitWord(text string) (string, string, bool) {
+	text = strings.TrimLeftFunc(text, unicode.IsSpace)
+	i := strings.IndexFunc(text, unicode.IsSpace)
+	if i < 0 {
+		return text, "", false
+	}
+	return text[:i], strings.TrimLeftFunc(text[i:], unicode.IsSpace), true
+}
+
+// getLongestCommonPrefix returns the longest common prefix of the strings.
+func getLongestCommonPrefix(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	prefix := s[0]
+	for _, e := range s[1:] {
+		for !strings.HasPrefix(e, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
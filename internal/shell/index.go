@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+)
+
+// index is an index of the services and methods in FileDescriptorSets.
+type index struct {
+	// sorted
+	serviceNames []string
+	// sorted, in the form package.Service/Method
+	methodNames                  []string
+	serviceNameToMethodNames     map[string][]string
+	methodNameToMethodDescriptor map[string]*desc.MethodDescriptor
+}
+
+func newIndex(fileDescriptorSets []*descriptor.FileDescriptorSet) (*index, error) {
+	index := &index{
+		serviceNameToMethodNames:     make(map[string][]string),
+		methodNameToMethodDescriptor: make(map[string]*desc.MethodDescriptor),
+	}
+	for _, fileDescriptorSet := range fileDescriptorSets {
+		if len(fileDescriptorSet.File) == 0 {
+			continue
+		}
+		fileNameToFileDescriptor, err := desc.CreateFileDescriptorsFromSet(fileDescriptorSet)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileDescriptor := range fileNameToFileDescriptor {
+			for _, serviceDescriptor := range fileDescriptor.GetServices() {
+				serviceName := serviceDescriptor.GetFullyQualifiedName()
+				// the same file can be in multiple FileDescriptorSets as an import
+				if _, ok := index.serviceNameToMethodNames[serviceName]; ok {
+					continue
+				}
+				index.serviceNames = append(index.serviceNames, serviceName)
+				methodNames := make([]string, 0, len(serviceDescriptor.GetMethods()))
+				for _, methodDescriptor := range serviceDescriptor.GetMethods() {
+					methodName := getMethodName(methodDescriptor)
+					methodNames = append(methodNames, methodName)
+					index.methodNameToMethodDescriptor[methodName] = methodDescriptor
+				}
+				sort.Strings(methodNames)
+				index.serviceNameToMethodNames[serviceName] = methodNames
+				index.methodNames = append(index.methodNames, methodNames...)
+			}
+		}
+	}
+	sort.Strings(index.serviceNames)
+	sort.Strings(index.methodNames)
+	return index, nil
+}
+
+func (i *index) getMethodDescriptor(methodName string) (*desc.MethodDescriptor, error) {
+	methodDescriptor, ok := i.methodNameToMethodDescriptor[methodName]
+	if !ok {
+		return nil, fmt.Errorf("unknown method %q, methods must be in the form package.Service/Method", methodName)
+	}
+	return methodDescriptor, nil
+}
+
+func getMethodName(methodDescriptor *desc.MethodDescriptor) string {
+	return methodDescriptor.GetService().GetFullyQualifiedName() + "/" + methodDescriptor.GetName()
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// lineReader reads lines of commands.
+type lineReader interface {
+	// ReadLine returns the next line without the trailing newline,
+	// or io.EOF if there are no more lines.
+	//
+	// If interactive, the line is initially set to the prefill.
+	ReadLine(prefill string) (string, error)
+	// Interactive returns true if lines are edited in a terminal.
+	Interactive() bool
+	Close() error
+}
+
+// newLineReader returns an interactive lineReader if the reader is a terminal.
+func newLineReader(
+	reader io.Reader,
+	writer io.Writer,
+	prompt string,
+	getHistory func() []string,
+	complete func(string) *completion,
+) (lineReader, error) {
+	if file, ok := reader.(*os.File); ok {
+		// if this fails, the file is not a terminal
+		if restore, err := makeRaw(int(file.Fd())); err == nil {
+			return newTerminalLineReader(file, writer, prompt, getHistory, complete, restore), nil
+		}
+	}
+	return newBufferedLineReader(reader), nil
+}
+
+type bufferedLineReader struct {
+	reader *bufio.Reader
+}
+
+func newBufferedLineReader(reader io.Reader) *bufferedLineReader {
+	return &bufferedLineReader{
+		reader: bufio.NewReader(reader),
+	}
+}
+
+func (b *bufferedLineReader) ReadLine(string) (string, error) {
+	line, err := b.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (b *bufferedLineReader) Interactive() bool {
+	return false
+}
+
+func (b *bufferedLineReader) Close() error {
+	return nil
+}
+
+// terminalLineReader edits lines in a terminal in raw mode.
+//
+// This supports the common Emacs key bindings, the arrow, home, end and
+// delete keys, tab completion, and going through the history with the
+// up and down keys. Lines are assumed to fit on the terminal.
+type terminalLineReader struct {
+	reader     *bufio.Reader
+	writer     io.Writer
+	prompt     string
+	getHistory func() []string
+	complete   func(string) *completion
+	restore    func() error
+
+	// the state of the current line
+	line []rune
+	pos  int
+	// historyIndex is len(history) if not going through the history
+	historyIndex int
+	// the line before going through the history
+	savedLine []rune
+}
+
+func newTerminalLineReader(
+	reader io.Reader,
+	writer io.Writer,
+	prompt string,
+	getHistory func() []string,
+	complete func(string) *completion,
+	restore func() error,
+) *terminalLineReader {
+	return &terminalLineReader{
+		reader:     bufio.NewReader(reader),
+		writer:     writer,
+		prompt:     prompt,
+		getHistory: getHistory,
+		complete:   complete,
+		restore:    restore,
+	}
+}
+
+func (t *terminalLineReader) ReadLine(prefill string) (string, error) {
+	t.line = []rune(prefill)
+	t.pos = len(t.line)
+	t.historyIndex = len(t.getHistory())
+	t.savedLine = nil
+	t.redraw()
+	for {
+		r, _, err := t.reader.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			t.write("\n")
+			return string(t.line), nil
+		case 0x01: // Ctrl-A
+			t.pos = 0
+		case 0x02: // Ctrl-B
+			t.moveLeft()
+		case 0x03: // Ctrl-C
+			t.write("^C\n")
+			t.line = nil
+			t.pos = 0
+		case 0x04: // Ctrl-D
+			if len(t.line) == 0 {
+				t.write("\n")
+				return "", io.EOF
+			}
+			t.deleteRight()
+		case 0x05: // Ctrl-E
+			t.pos = len(t.line)
+		case 0x06: // Ctrl-F
+			t.moveRight()
+		case 0x08, 0x7f: // Ctrl-H, Backspace
+			t.deleteLeft()
+		case '\t':
+			t.doComplete()
+		case 0x0b: // Ctrl-K
+			t.line = t.line[:t.pos]
+		case 0x0c: // Ctrl-L
+			t.write("\x1b[H\x1b[2J")
+		case 0x0e: // Ctrl-N
+			t.historyNext()
+		case 0x10: // Ctrl-P
+			t.historyPrevious()
+		case 0x15: // Ctrl-U
+			t.line = t.line[t.pos:]
+			t.pos = 0
+		case 0x17: // Ctrl-W
+			t.deleteWordLeft()
+		case 0x1b: // Escape
+			if err := t.readEscapeSequence(); err != nil {
+				return "", err
+			}
+		default:
+			if r >= ' ' {
+				t.insert([]rune{r})
+			}
+		}
+		t.redraw()
+	}
+}
+
+func (t *terminalLineReader) Interactive() bool {
+	return true
+}
+
+func (t *terminalLineReader) Close() error {
+	return t.restore()
+}
+
+func (t *terminalLineReader) readEscapeSequence() error {
+	r, _, err := t.reader.ReadRune()
+	if err != nil {
+		return err
+	}
+	if r != '[' && r != 'O' {
+		return nil
+	}
+	var param []rune
+	for {
+		r, _, err = t.reader.ReadRune()
+		if err != nil {
+			return err
+		}
+		if r < '0' || r > '9' {
+			break
+		}
+		param = append(param, r)
+	}
+	switch r {
+	case 'A':
+		t.historyPrevious()
+	case 'B':
+		t.historyNext()
+	case 'C':
+		t.moveRight()
+	case 'D':
+		t.moveLeft()
+	case 'H':
+		t.pos = 0
+	case 'F':
+		t.pos = len(t.line)
+	case '~':
+		switch string(param) {
+		case "1", "7":
+			t.pos = 0
+		case "4", "8":
+			t.pos = len(t.line)
+		case "3":
+			t.deleteRight()
+		}
+	}
+	return nil
+}
+
+func (t *terminalLineReader) doComplete() {
+	text := string(t.line[:t.pos])
+	completion := t.complete(text)
+	if completion == nil {
+		t.write("\a")
+		return
+	}
+	word := text[completion.Start:]
+	var replacement string
+	switch {
+	case len(completion.Candidates) == 1:
+		replacement = completion.Candidates[0] + completion.Suffix
+	case len(getLongestCommonPrefix(completion.Candidates)) > len(word):
+		replacement = getLongestCommonPrefix(completion.Candidates)
+	default:
+		t.write("\n" + strings.Join(completion.Candidates, "  ") + "\n")
+		return
+	}
+	wordLen := utf8.RuneCountInString(word)
+	t.line = append(t.line[:t.pos-wordLen], t.line[t.pos:]...)
+	t.pos -= wordLen
+	t.insert([]rune(replacement))
+}
+
+func (t *terminalLineReader) historyPrevious() {
+	if t.historyIndex == 0 {
+		return
+	}
+	history := t.getHistory()
+	if t.historyIndex == len(history) {
+		t.savedLine = t.line
+	}
+	t.historyIndex--
+	t.setLine([]rune(history[t.historyIndex]))
+}
+
+func (t *terminalLineReader) historyNext() {
+	history := t.getHistory()
+	if t.historyIndex >= len(history) {
+		return
+	}
+	t.historyIndex++
+	if t.historyIndex == len(history) {
+		t.setLine(t.savedLine)
+		return
+	}
+	t.setLine([]rune(history[t.historyIndex]))
+}
+
+func (t *terminalLineReader) setLine(line []rune) {
+	t.line = append([]rune(nil), line...)
+	t.pos = len(t.line)
+}
+
+func (t *terminalLineReader) insert(runes []rune) {
+	line := make([]rune, 0, len(t.line)+len(runes))
+	line = append(line, t.line[:t.pos]...)
+	line = append(line, runes...)
+	t.line = append(line, t.line[t.pos:]...)
+	t.pos += len(runes)
+}
+
+func (t *terminalLineReader) deleteLeft() {
+	if t.pos == 0 {
+		return
+	}
+	t.line = append(t.line[:t.pos-1], t.line[t.pos:]...)
+	t.pos--
+}
+
+func (t *terminalLineReader) deleteRight() {
+	if t.pos == len(t.line) {
+		return
+	}
+	t.line = append(t.line[:t.pos], t.line[t.pos+1:]...)
+}
+
+func (t *terminalLineReader) deleteWordLeft() {
+	start := t.pos
+	for start > 0 && t.line[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && t.line[start-1] != ' ' {
+		start--
+	}
+	t.line = append(t.line[:start], t.line[t.pos:]...)
+	t.pos = start
+}
+
+func (t *terminalLineReader) moveLeft() {
+	if t.pos > 0 {
+		t.pos--
+	}
+}
+
+func (t *terminalLineReader) moveRight() {
+	if t.pos < len(t.line) {
+		t.pos++
+	}
+}
+
+// redraw writes the prompt and the line over the current terminal line,
+// and moves the cursor to the position in the line.
+func (t *terminalLineReader) redraw() {
+	s := "\r" + t.prompt + string(t.line) + "\x1b[K"
+	if n := len(t.line) - t.pos; n > 0 {
+		s += fmt.Sprintf("\x1b[%dD", n)
+	}
+	t.write(s)
+}
+
+func (t *terminalLineReader) write(s string) {
+	// there is nothing to do about errors writing to the terminal
+	_, _ = io.WriteString(t.writer, s)
+}
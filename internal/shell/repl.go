@@ -0,0 +1,354 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"go.uber.org/zap"
+)
+
+type command struct {
+	Name        string
+	Usage       string
+	Description string
+	// Run runs the command with the text after the command name.
+	Run func(s *shell, args string) error
+}
+
+// _commands is set in init as the commands refer to the help command.
+var _commands []*command
+
+func init() {
+	_commands = []*command{
+		{
+			Name:        "call",
+			Usage:       "call package.Service/Method [data]",
+			Description: "Call the method with the JSON data. Streams of requests are given as multiple JSON objects. If no data is given, the line is filled with a template for the request.",
+			Run:         (*shell).call,
+		},
+		{
+			Name:        "exit",
+			Usage:       "exit",
+			Description: "Exit the shell. Ctrl-D also exits the shell.",
+			Run:         (*shell).exit,
+		},
+		{
+			Name:        "header",
+			Usage:       "header key:value",
+			Description: "Send the header with every following call.",
+			Run:         (*shell).header,
+		},
+		{
+			Name:        "headers",
+			Usage:       "headers",
+			Description: "Print the headers that are sent with every call.",
+			Run:         (*shell).printHeaders,
+		},
+		{
+			Name:        "help",
+			Usage:       "help [command]",
+			Description: "Print the usage of all commands or of the given command.",
+			Run:         (*shell).help,
+		},
+		{
+			Name:        "history",
+			Usage:       "history",
+			Description: "Print the history. The up and down keys go through the history.",
+			Run:         (*shell).printHistory,
+		},
+		{
+			Name:        "methods",
+			Usage:       "methods [package.Service]",
+			Description: "Print the methods of all services or of the given service.",
+			Run:         (*shell).printMethods,
+		},
+		{
+			Name:        "quit",
+			Usage:       "quit",
+			Description: "Exit the shell.",
+			Run:         (*shell).exit,
+		},
+		{
+			Name:        "services",
+			Usage:       "services",
+			Description: "Print the services.",
+			Run:         (*shell).printServices,
+		},
+		{
+			Name:        "template",
+			Usage:       "template package.Service/Method",
+			Description: "Print a request for the method with every field set to its default value.",
+			Run:         (*shell).printTemplate,
+		},
+		{
+			Name:        "unheader",
+			Usage:       "unheader key",
+			Description: "Stop sending the header.",
+			Run:         (*shell).unheader,
+		},
+	}
+}
+
+// errExit is returned by the exit command.
+var errExit = fmt.Errorf("exit")
+
+type shell struct {
+	logger             *zap.Logger
+	prompt             string
+	headers            map[string]string
+	fileDescriptorSets []*descriptor.FileDescriptorSet
+	address            string
+	handlerProvider    HandlerProvider
+	index              *index
+
+	// set in Run
+	writer     io.Writer
+	lineReader lineReader
+	history    []string
+	// the next line is filled with this if the lineReader is interactive
+	prefill string
+}
+
+func newShell(
+	fileDescriptorSets []*descriptor.FileDescriptorSet,
+	address string,
+	handlerProvider HandlerProvider,
+	options ...ShellOption,
+) (*shell, error) {
+	shell := &shell{
+		logger:             zap.NewNop(),
+		prompt:             DefaultPrompt,
+		headers:            make(map[string]string),
+		fileDescriptorSets: fileDescriptorSets,
+		address:            address,
+		handlerProvider:    handlerProvider,
+	}
+	for _, option := range options {
+		option(shell)
+	}
+	index, err := newIndex(fileDescriptorSets)
+	if err != nil {
+		return nil, err
+	}
+	if len(index.serviceNames) == 0 {
+		return nil, fmt.Errorf("no services found")
+	}
+	shell.index = index
+	return shell, nil
+}
+
+func (s *shell) Run(reader io.Reader, writer io.Writer) error {
+	lineReader, err := newLineReader(reader, writer, s.prompt, s.getHistory, s.complete)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lineReader.Close(); err != nil {
+			s.logger.Error("close error", zap.Error(err))
+		}
+	}()
+	s.writer = writer
+	s.lineReader = lineReader
+	if lineReader.Interactive() {
+		s.println(`Type "help" for the commands. Tab completes commands, methods and request fields.`)
+	}
+	for {
+		prefill := s.prefill
+		s.prefill = ""
+		line, err := lineReader.ReadLine(prefill)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := s.runLine(line); err != nil {
+			if err == errExit {
+				return nil
+			}
+			s.println(err.Error())
+		}
+	}
+}
+
+func (s *shell) runLine(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	if len(s.history) == 0 || s.history[len(s.history)-1] != line {
+		s.history = append(s.history, line)
+	}
+	name, args, _ := splitWord(line)
+	command := getCommand(name)
+	if command == nil {
+		return fmt.Errorf("unknown command %q, type \"help\" for the commands", name)
+	}
+	return command.Run(s, strings.TrimSpace(args))
+}
+
+func (s *shell) call(args string) error {
+	methodName, data, _ := splitWord(args)
+	methodDescriptor, err := s.index.getMethodDescriptor(methodName)
+	if err != nil {
+		return err
+	}
+	if data == "" {
+		if !s.lineReader.Interactive() {
+			return fmt.Errorf("must give the request data")
+		}
+		s.prefill = "call " + methodName + " " + getTemplate(methodDescriptor.GetInputType())
+		return nil
+	}
+	return s.handlerProvider(s.headers).Invoke(
+		s.fileDescriptorSets,
+		s.address,
+		methodName,
+		strings.NewReader(data),
+		s.writer,
+	)
+}
+
+func (s *shell) exit(string) error {
+	return errExit
+}
+
+func (s *shell) header(args string) error {
+	split := strings.SplitN(args, ":", 2)
+	if len(split) != 2 || split[0] == "" {
+		return fmt.Errorf("headers must be key:value but got %q", args)
+	}
+	s.headers[split[0]] = split[1]
+	return nil
+}
+
+func (s *shell) unheader(args string) error {
+	if _, ok := s.headers[args]; !ok {
+		return fmt.Errorf("no header %q", args)
+	}
+	delete(s.headers, args)
+	return nil
+}
+
+func (s *shell) printHeaders(string) error {
+	keys := make([]string, 0, len(s.headers))
+	for key := range s.headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s.println(key + ":" + s.headers[key])
+	}
+	return nil
+}
+
+func (s *shell) help(args string) error {
+	if args != "" {
+		command := getCommand(args)
+		if command == nil {
+			return fmt.Errorf("unknown command %q", args)
+		}
+		s.println(command.Usage)
+		s.println("  " + command.Description)
+		return nil
+	}
+	for _, command := range _commands {
+		s.println(command.Usage)
+		s.println("  " + command.Description)
+	}
+	return nil
+}
+
+func (s *shell) printHistory(string) error {
+	for i, line := range s.history {
+		s.println(fmt.Sprintf("%5d  %s", i+1, line))
+	}
+	return nil
+}
+
+func (s *shell) printMethods(args string) error {
+	if args == "" {
+		for _, methodName := range s.index.methodNames {
+			s.println(methodName)
+		}
+		return nil
+	}
+	methodNames, ok := s.index.serviceNameToMethodNames[args]
+	if !ok {
+		return fmt.Errorf("unknown service %q, services must be in the form package.Service", args)
+	}
+	for _, methodName := range methodNames {
+		s.println(methodName)
+	}
+	return nil
+}
+
+func (s *shell) printServices(string) error {
+	for _, serviceName := range s.index.serviceNames {
+		s.println(serviceName)
+	}
+	return nil
+}
+
+func (s *shell) printTemplate(args string) error {
+	methodDescriptor, err := s.index.getMethodDescriptor(args)
+	if err != nil {
+		return err
+	}
+	template, err := getIndentedTemplate(methodDescriptor.GetInputType())
+	if err != nil {
+		return err
+	}
+	s.println(template)
+	return nil
+}
+
+func (s *shell) getHistory() []string {
+	return s.history
+}
+
+func (s *shell) println(line string) {
+	if _, err := fmt.Fprintln(s.writer, line); err != nil {
+		s.logger.Error("write error", zap.Error(err))
+	}
+}
+
+func getCommand(name string) *command {
+	for _, command := range _commands {
+		if command.Name == name {
+			return command
+		}
+	}
+	return nil
+}
+
+func getCommandNames() []string {
+	names := make([]string, len(_commands))
+	for i, command := range _commands {
+		names[i] = command.Name
+	}
+	return names
+}
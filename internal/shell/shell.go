@@ -0,0 +1,94 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package shell implements an interactive shell to call gRPC methods.
+package shell
+
+import (
+	"io"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/grpc"
+	"go.uber.org/zap"
+)
+
+// DefaultPrompt is the default prompt.
+const DefaultPrompt = "> "
+
+// Shell is an interactive shell to call gRPC methods.
+//
+// The shell offers tab completion of commands, services, methods and the
+// field names of requests, request templates with every field set to its
+// default value, history, and headers that are sent with every call.
+type Shell interface {
+	// Run reads commands from the reader and writes the results to the writer
+	// until the exit command is given or the reader is closed.
+	//
+	// If the reader is a terminal, lines are edited in place with tab completion
+	// and history. Otherwise, lines are read as is and no prompt is printed.
+	Run(reader io.Reader, writer io.Writer) error
+}
+
+// HandlerProvider returns a grpc.Handler that sends the given headers.
+//
+// This is called for every call so that headers can be changed in the shell.
+type HandlerProvider func(headers map[string]string) grpc.Handler
+
+// ShellOption is an option for a new Shell.
+type ShellOption func(*shell)
+
+// ShellWithLogger returns a ShellOption that uses the given logger.
+//
+// The default is to use zap.NewNop().
+func ShellWithLogger(logger *zap.Logger) ShellOption {
+	return func(shell *shell) {
+		shell.logger = logger
+	}
+}
+
+// ShellWithHeader returns a ShellOption that sends the given key/value
+// header with every call until it is removed in the shell.
+func ShellWithHeader(key string, value string) ShellOption {
+	return func(shell *shell) {
+		shell.headers[key] = value
+	}
+}
+
+// ShellWithPrompt returns a ShellOption that uses the given prompt.
+//
+// The default is to use DefaultPrompt.
+func ShellWithPrompt(prompt string) ShellOption {
+	return func(shell *shell) {
+		shell.prompt = prompt
+	}
+}
+
+// NewShell returns a new Shell that calls the given address.
+//
+// The FileDescriptorSets must include imports. They are used for completion
+// and templates, and are given to the grpc.Handler for every call.
+func NewShell(
+	fileDescriptorSets []*descriptor.FileDescriptorSet,
+	address string,
+	handlerProvider HandlerProvider,
+	options ...ShellOption,
+) (Shell, error) {
+	return newShell(fileDescriptorSets, address, handlerProvider, options...)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/prototool/internal/grpc"
+	ptesting "github.com/uber/prototool/internal/testing"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+func TestShellRun(t *testing.T) {
+	address := ptesting.RequireStartHealthServer(t, true)
+	testShellRun(
+		t,
+		address,
+		`services
+methods grpc.health.v1.Health
+template grpc.health.v1.Health/Check
+call grpc.health.v1.Health/Check {"service":"foo"}
+call grpc.health.v1.Health/Check
+call grpc.health.v1.Health/Check {"service":"bar"}
+call grpc.health.v1.Health/Foo {}
+header foo:bar
+header baz
+headers
+unheader foo
+headers
+history
+foo
+help exit
+exit
+services
+`,
+		`grpc.health.v1.Health
+grpc.reflection.v1alpha.ServerReflection
+grpc.health.v1.Health/Check
+grpc.health.v1.Health/Watch
+{
+  "service": ""
+}
+{"status":"SERVING"}
+must give the request data
+rpc error: code = NotFound desc = unknown service
+unknown method "grpc.health.v1.Health/Foo", methods must be in the form package.Service/Method
+headers must be key:value but got "baz"
+foo:bar
+    1  services
+    2  methods grpc.health.v1.Health
+    3  template grpc.health.v1.Health/Check
+    4  call grpc.health.v1.Health/Check {"service":"foo"}
+    5  call grpc.health.v1.Health/Check
+    6  call grpc.health.v1.Health/Check {"service":"bar"}
+    7  call grpc.health.v1.Health/Foo {}
+    8  header foo:bar
+    9  header baz
+   10  headers
+   11  unheader foo
+   12  headers
+   13  history
+unknown command "foo", type "help" for the commands
+exit
+  Exit the shell. Ctrl-D also exits the shell.
+`,
+	)
+}
+
+func TestShellComplete(t *testing.T) {
+	shell := newTestShell(t, "")
+	testShellComplete(t, shell, "", 0, []string{"call", "exit", "header", "headers", "help", "history", "methods", "quit", "services", "template", "unheader"}, " ")
+	testShellComplete(t, shell, "he", 0, []string{"header", "headers", "help"}, " ")
+	testShellComplete(t, shell, "  s", 2, []string{"services"}, " ")
+	testShellComplete(t, shell, "foo", 0, nil, "")
+	testShellComplete(t, shell, "methods ", 8, []string{"grpc.health.v1.Health", "grpc.reflection.v1alpha.ServerReflection"}, "")
+	testShellComplete(t, shell, "call grpc.h", 5, []string{"grpc.health.v1.Health/Check", "grpc.health.v1.Health/Watch"}, " ")
+	testShellComplete(t, shell, "template grpc.health.v1.Health/C", 9, []string{"grpc.health.v1.Health/Check"}, "")
+	testShellComplete(t, shell, "call grpc.health.v1.Health/Check {", 34, []string{`"service`}, `": `)
+	testShellComplete(t, shell, `call grpc.health.v1.Health/Check {"s`, 35, []string{"service"}, `": `)
+	testShellComplete(t, shell, `call grpc.health.v1.Health/Check {"service": "`, 0, nil, "")
+	testShellComplete(t, shell, `call grpc.health.v1.Health/Check {"service": "foo"}`, 0, nil, "")
+	testShellComplete(t, shell, `call grpc.health.v1.Health/Check {"service": "foo"} {"`, 54, []string{"service"}, `": `)
+	const reflectionMethod = "call grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo "
+	testShellComplete(t, shell, reflectionMethod+`{"host": "", "fileContainingExtension": {"c`, len(reflectionMethod)+42, []string{"containingType"}, `": `)
+	testShellComplete(t, shell, reflectionMethod+`{"file_containing_extension": {"containingType": "foo", "`, len(reflectionMethod)+57, []string{"containingType", "extensionNumber"}, `": `)
+	testShellComplete(t, shell, reflectionMethod+`{"fileContainingExtension": {}, "l`, len(reflectionMethod)+33, []string{"listServices"}, `": `)
+	testShellComplete(t, shell, reflectionMethod+`{"host": {"`, 0, nil, "")
+
+	shell.headers["foo"] = "bar"
+	testShellComplete(t, shell, "unheader ", 9, []string{"foo"}, "")
+}
+
+func TestGetTemplate(t *testing.T) {
+	testGetTemplate(t, &healthpb.HealthCheckRequest{}, `{"service":""}`)
+	testGetTemplate(t, &healthpb.HealthCheckResponse{}, `{"status":"UNKNOWN"}`)
+	// only the first field of the oneof
+	testGetTemplate(t, &reflectionpb.ServerReflectionRequest{}, `{"host":"","fileByFilename":""}`)
+	testGetTemplate(t, &reflectionpb.ServerReflectionResponse{}, `{"validHost":"","originalRequest":{"host":"","fileByFilename":""},"fileDescriptorResponse":{"fileDescriptorProto":[""]}}`)
+	// recursive messages are null
+	messageDescriptor, err := desc.LoadMessageDescriptorForMessage(&descriptor.DescriptorProto{})
+	require.NoError(t, err)
+	assert.Contains(t, getTemplate(messageDescriptor), `"nestedType":[null]`)
+	assert.Contains(t, getTemplate(messageDescriptor), `"options":{"messageSetWireFormat":false,`)
+}
+
+func TestTerminalLineReader(t *testing.T) {
+	shell := newTestShell(t, "")
+	shell.history = []string{"services", "methods"}
+	testTerminalLineReader(t, shell, "", "services\r", "services")
+	testTerminalLineReader(t, shell, "foo", "\r", "foo")
+	// backspace, Ctrl-A, insert
+	testTerminalLineReader(t, shell, "", "ervicez\x7f\x7fe\x01s\r", "service")
+	// left arrow, right arrow, Ctrl-K
+	testTerminalLineReader(t, shell, "", "servixx\x1b[D\x1b[D\x1b[Cc\x0b\r", "servixc")
+	// up arrow, up arrow, down arrow
+	testTerminalLineReader(t, shell, "", "foo\x1b[A\x1b[A\x1b[B\r", "methods")
+	// up arrow, down arrow restores the line
+	testTerminalLineReader(t, shell, "", "foo\x1b[A\x1b[B\r", "foo")
+	// Ctrl-W, Ctrl-U
+	testTerminalLineReader(t, shell, "", "foo bar\x17baz\r", "foo baz")
+	testTerminalLineReader(t, shell, "", "foo bar\x1b[D\x1b[D\x15\r", "ar")
+	// Ctrl-C
+	testTerminalLineReader(t, shell, "", "foo\x03bar\r", "bar")
+	// tab completes
+	testTerminalLineReader(t, shell, "", "se\t\r", "services ")
+	testTerminalLineReader(t, shell, "", "call grpc.h\t\r", "call grpc.health.v1.Health/")
+	testTerminalLineReader(t, shell, "", "call grpc.health.v1.Health/Check {\t\r", `call grpc.health.v1.Health/Check {"service": `)
+	testTerminalLineReader(t, shell, "", "he\t\r", "he")
+	// Ctrl-D on an empty line
+	_, err := newTestTerminalLineReader(shell, "\x04").ReadLine("")
+	assert.Equal(t, io.EOF, err)
+}
+
+func testShellRun(t *testing.T, address string, input string, expectedOutput string) {
+	shell := newTestShell(t, address)
+	output := bytes.NewBuffer(nil)
+	require.NoError(t, shell.Run(strings.NewReader(input), output))
+	assert.Equal(t, expectedOutput, output.String())
+}
+
+func testShellComplete(t *testing.T, shell *shell, text string, expectedStart int, expectedCandidates []string, expectedSuffix string) {
+	completion := shell.complete(text)
+	if expectedCandidates == nil {
+		assert.Nil(t, completion, text)
+		return
+	}
+	require.NotNil(t, completion, text)
+	assert.Equal(t, expectedStart, completion.Start, text)
+	assert.Equal(t, expectedCandidates, completion.Candidates, text)
+	assert.Equal(t, expectedSuffix, completion.Suffix, text)
+}
+
+func testGetTemplate(t *testing.T, message proto.Message, expectedTemplate string) {
+	messageDescriptor, err := desc.LoadMessageDescriptorForMessage(message)
+	require.NoError(t, err)
+	assert.Equal(t, expectedTemplate, getTemplate(messageDescriptor))
+}
+
+func testTerminalLineReader(t *testing.T, shell *shell, prefill string, input string, expectedLine string) {
+	line, err := newTestTerminalLineReader(shell, input).ReadLine(prefill)
+	require.NoError(t, err)
+	assert.Equal(t, expectedLine, line, input)
+}
+
+func newTestTerminalLineReader(shell *shell, input string) *terminalLineReader {
+	return newTerminalLineReader(
+		strings.NewReader(input),
+		bytes.NewBuffer(nil),
+		DefaultPrompt,
+		shell.getHistory,
+		shell.complete,
+		func() error { return nil },
+	)
+}
+
+func newTestShell(t *testing.T, address string) *shell {
+	fileDescriptorSets := ptesting.RequireGetMessageFileDescriptorSets(
+		t,
+		&healthpb.HealthCheckRequest{},
+		&reflectionpb.ServerReflectionRequest{},
+	)
+	shell, err := newShell(
+		fileDescriptorSets,
+		address,
+		func(headers map[string]string) grpc.Handler {
+			var handlerOptions []grpc.HandlerOption
+			for key, value := range headers {
+				handlerOptions = append(handlerOptions, grpc.HandlerWithHeader(key, value))
+			}
+			return grpc.NewHandler(handlerOptions...)
+		},
+	)
+	require.NoError(t, err)
+	return shell
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
+)
+
+// _wellKnownTypeToTemplate is the template of the well-known types
+// that have a special JSON representation.
+var _wellKnownTypeToTemplate = map[string]string{
+	// there is no default type for an Any, and an Any without a type is invalid
+	"google.protobuf.Any":         "null",
+	"google.protobuf.BoolValue":   "false",
+	"google.protobuf.BytesValue":  `""`,
+	"google.protobuf.DoubleValue": "0",
+	"google.protobuf.Duration":    `"0s"`,
+	"google.protobuf.FieldMask":   `""`,
+	"google.protobuf.FloatValue":  "0",
+	"google.protobuf.Int32Value":  "0",
+	"google.protobuf.Int64Value":  "0",
+	"google.protobuf.ListValue":   "[]",
+	"google.protobuf.StringValue": `""`,
+	"google.protobuf.Struct":      "{}",
+	"google.protobuf.Timestamp":   `"1970-01-01T00:00:00Z"`,
+	"google.protobuf.UInt32Value": "0",
+	"google.protobuf.UInt64Value": "0",
+	"google.protobuf.Value":       "null",
+}
+
+// getTemplate returns a compact JSON message with every field set to its default value.
+//
+// Repeated fields have a single element and maps are empty. Only the first field of
+// a oneof is set, and messages that are already being templated are set to null so
+// that recursive messages terminate.
+func getTemplate(messageDescriptor *desc.MessageDescriptor) string {
+	buffer := bytes.NewBuffer(nil)
+	writeMessageTemplate(buffer, messageDescriptor, make(map[string]struct{}))
+	return buffer.String()
+}
+
+// getIndentedTemplate returns the result of getTemplate indented by two spaces.
+func getIndentedTemplate(messageDescriptor *desc.MessageDescriptor) (string, error) {
+	buffer := bytes.NewBuffer(nil)
+	if err := json.Indent(buffer, []byte(getTemplate(messageDescriptor)), "", "  "); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func writeMessageTemplate(buffer *bytes.Buffer, messageDescriptor *desc.MessageDescriptor, seen map[string]struct{}) {
+	messageName := messageDescriptor.GetFullyQualifiedName()
+	if template, ok := _wellKnownTypeToTemplate[messageName]; ok {
+		buffer.WriteString(template)
+		return
+	}
+	if _, ok := seen[messageName]; ok {
+		buffer.WriteString("null")
+		return
+	}
+	seen[messageName] = struct{}{}
+	defer delete(seen, messageName)
+	buffer.WriteString("{")
+	first := true
+	for _, fieldDescriptor := range messageDescriptor.GetFields() {
+		if oneOfDescriptor := fieldDescriptor.GetOneOf(); oneOfDescriptor != nil && oneOfDescriptor.GetChoices()[0] != fieldDescriptor {
+			continue
+		}
+		if !first {
+			buffer.WriteString(",")
+		}
+		first = false
+		writeString(buffer, fieldDescriptor.GetJSONName())
+		buffer.WriteString(":")
+		writeFieldTemplate(buffer, fieldDescriptor, seen)
+	}
+	buffer.WriteString("}")
+}
+
+func writeFieldTemplate(buffer *bytes.Buffer, fieldDescriptor *desc.FieldDescriptor, seen map[string]struct{}) {
+	if fieldDescriptor.IsMap() {
+		buffer.WriteString("{}")
+		return
+	}
+	if fieldDescriptor.IsRepeated() {
+		buffer.WriteString("[")
+		writeValueTemplate(buffer, fieldDescriptor, seen)
+		buffer.WriteString("]")
+		return
+	}
+	writeValueTemplate(buffer, fieldDescriptor, seen)
+}
+
+func writeValueTemplate(buffer *bytes.Buffer, fieldDescriptor *desc.FieldDescriptor, seen map[string]struct{}) {
+	switch fieldDescriptor.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+		writeMessageTemplate(buffer, fieldDescriptor.GetMessageType(), seen)
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		writeString(buffer, fieldDescriptor.GetEnumType().GetValues()[0].GetName())
+	case descriptor.FieldDescriptorProto_TYPE_STRING, descriptor.FieldDescriptorProto_TYPE_BYTES:
+		buffer.WriteString(`""`)
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		buffer.WriteString("false")
+	default:
+		buffer.WriteString("0")
+	}
+}
+
+func writeString(buffer *bytes.Buffer, s string) {
+	// json.Marshal cannot fail for a string
+	data, _ := json.Marshal(s)
+	buffer.Write(data)
+}
@@ -23,16 +23,23 @@ package testing
 import (
 	"errors"
 	"fmt"
+	"net"
 	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/jhump/protoreflect/desc"
 	"github.com/stretchr/testify/require"
 	"github.com/uber/prototool/internal/file"
 	"github.com/uber/prototool/internal/protoc"
 	reflectv1 "github.com/uber/prototool/internal/reflect/gen/uber/proto/reflect/v1"
 	"go.uber.org/multierr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 var jsonMarshaler = &jsonpb.Marshaler{Indent: "  "}
@@ -47,12 +54,22 @@ func RequireGetFileDescriptorSets(t *testing.T, workDirPath string, dirPath stri
 
 // GetFileDescriptorSets gets the FileDescriptorSets that result from compiling the given dirPath.
 func GetFileDescriptorSets(workDirPath string, dirPath string) (protoc.FileDescriptorSets, error) {
+	return getFileDescriptorSets(workDirPath, dirPath, protoc.CompilerWithFileDescriptorSet())
+}
+
+// GetFileDescriptorSetsWithSourceInfo gets the FileDescriptorSets that result from compiling
+// the given dirPath, including source code info.
+func GetFileDescriptorSetsWithSourceInfo(workDirPath string, dirPath string) (protoc.FileDescriptorSets, error) {
+	return getFileDescriptorSets(workDirPath, dirPath, protoc.CompilerWithFileDescriptorSetFullControl(true, true))
+}
+
+func getFileDescriptorSets(workDirPath string, dirPath string, compilerOption protoc.CompilerOption) (protoc.FileDescriptorSets, error) {
 	protoSet, err := file.NewProtoSetProvider().GetForDir(workDirPath, dirPath)
 	if err != nil {
 		return nil, err
 	}
 	compileResult, err := protoc.NewCompiler(
-		protoc.CompilerWithFileDescriptorSet(),
+		compilerOption,
 		protoc.CompilerWithCachePath(filepath.Join(workDirPath, "testcache")),
 	).Compile(protoSet)
 	if err != nil {
@@ -99,3 +116,35 @@ func PrintPackageSetJSON(packageSet *reflectv1.PackageSet) error {
 	fmt.Println(s)
 	return nil
 }
+
+// RequireGetMessageFileDescriptorSets gets a FileDescriptorSet for the file
+// of each given compiled-in message with require calls.
+func RequireGetMessageFileDescriptorSets(t *testing.T, messages ...proto.Message) []*descriptor.FileDescriptorSet {
+	fileDescriptorSets := make([]*descriptor.FileDescriptorSet, 0, len(messages))
+	for _, message := range messages {
+		messageDescriptor, err := desc.LoadMessageDescriptorForMessage(message)
+		require.NoError(t, err)
+		fileDescriptorSets = append(fileDescriptorSets, desc.ToFileDescriptorSet(messageDescriptor.GetFile()))
+	}
+	return fileDescriptorSets
+}
+
+// RequireStartHealthServer starts a gRPC health server on localhost that
+// reports the service foo as serving, and returns its address.
+//
+// The server is stopped when the test completes. If registerReflection
+// is true, the server reflection service is also registered.
+func RequireStartHealthServer(t *testing.T, registerReflection bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	if registerReflection {
+		reflection.Register(grpcServer)
+	}
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().String()
+}
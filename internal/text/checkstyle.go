@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package text
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// the version of the format, which is the version that most tools emit
+const checkstyleVersion = "4.3"
+
+type checkstyleFailureReporter struct{}
+
+func newCheckstyleFailureReporter() *checkstyleFailureReporter {
+	return &checkstyleFailureReporter{}
+}
+
+func (r *checkstyleFailureReporter) ReportFailures(writer io.Writer, failures ...*Failure) error {
+	checkstyle := &checkstyle{
+		Version: checkstyleVersion,
+	}
+	for _, filenameFailures := range groupFailures(failures, func(failure *Failure) string { return failure.Filename }) {
+		checkstyleFile := &checkstyleFile{
+			Name: getFailureFilename(filenameFailures[0]),
+		}
+		for _, failure := range filenameFailures {
+			// the line is required, so unknown lines are the first line as with Failure.String
+			line := failure.Line
+			if line == 0 {
+				line = 1
+			}
+			checkstyleFile.Errors = append(
+				checkstyleFile.Errors,
+				&checkstyleError{
+					Line:     line,
+					Column:   failure.Column,
+					Severity: "error",
+					Message:  failure.Message,
+					Source:   failure.LintID,
+				},
+			)
+		}
+		checkstyle.Files = append(checkstyle.Files, checkstyleFile)
+	}
+	return writeXML(writer, checkstyle)
+}
+
+type checkstyle struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string             `xml:"name,attr"`
+	Errors []*checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr,omitempty"`
+}
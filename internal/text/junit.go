@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package text
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// the name of the test cases of Failures with no ID
+const junitNoIDTestCaseName = "FAILURE"
+
+type junitFailureReporter struct {
+	name string
+}
+
+func newJUnitFailureReporter(name string) *junitFailureReporter {
+	return &junitFailureReporter{
+		name: name,
+	}
+}
+
+func (r *junitFailureReporter) ReportFailures(writer io.Writer, failures ...*Failure) error {
+	testSuites := &junitTestSuites{
+		Name: r.name,
+		// the test suites must not be omitted if there are no failures
+		TestSuites: make([]*junitTestSuite, 0),
+	}
+	for _, filenameFailures := range groupFailures(failures, func(failure *Failure) string { return failure.Filename }) {
+		filename := getFailureFilename(filenameFailures[0])
+		testSuite := &junitTestSuite{
+			Name: filename,
+		}
+		for _, idFailures := range groupFailures(filenameFailures, func(failure *Failure) string { return failure.LintID }) {
+			name := idFailures[0].LintID
+			if name == "" {
+				name = junitNoIDTestCaseName
+			}
+			message := idFailures[0].Message
+			if len(idFailures) > 1 {
+				message = fmt.Sprintf("%d failures", len(idFailures))
+			}
+			lines := make([]string, 0, len(idFailures))
+			for _, failure := range idFailures {
+				lines = append(lines, failure.String())
+			}
+			testSuite.TestCases = append(
+				testSuite.TestCases,
+				&junitTestCase{
+					Name:      name,
+					ClassName: filename,
+					Failure: &junitFailure{
+						Message: message,
+						Type:    idFailures[0].LintID,
+						Text:    strings.Join(lines, "\n"),
+					},
+				},
+			)
+		}
+		testSuite.Tests = len(testSuite.TestCases)
+		testSuite.Failures = len(testSuite.TestCases)
+		testSuites.Tests += testSuite.Tests
+		testSuites.Failures += testSuite.Failures
+		testSuites.TestSuites = append(testSuites.TestSuites, testSuite)
+	}
+	return writeXML(writer, testSuites)
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	Name       string            `xml:"name,attr,omitempty"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package text
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// FailureReporter reports Failures.
+type FailureReporter interface {
+	// ReportFailures reports the Failures to the writer in the given order.
+	//
+	// Reporters that group Failures expect all the Failures of a command
+	// to be reported at once.
+	ReportFailures(writer io.Writer, failures ...*Failure) error
+}
+
+// NewTextFailureReporter returns a new FailureReporter that prints each
+// Failure on a line with the given ordered fields.
+//
+// If no fields are given, DefaultFailureFields are used.
+func NewTextFailureReporter(fields ...FailureField) FailureReporter {
+	return newTextFailureReporter(fields...)
+}
+
+// NewJSONFailureReporter returns a new FailureReporter that prints each
+// Failure as a JSON object on a line.
+func NewJSONFailureReporter() FailureReporter {
+	return newJSONFailureReporter()
+}
+
+// NewSARIFFailureReporter returns a new FailureReporter that prints the
+// Failures as a SARIF 2.1.0 log with a single run of the given tool.
+//
+// The given rules are the IDs that were checked and their purposes. Failures
+// with an ID that is not in the rules are added to the rules without a
+// description, and Failures with no ID are printed without a rule.
+func NewSARIFFailureReporter(toolName string, toolVersion string, idToPurpose map[string]string) FailureReporter {
+	return newSARIFFailureReporter(toolName, toolVersion, idToPurpose)
+}
+
+// NewJUnitFailureReporter returns a new FailureReporter that prints the
+// Failures as JUnit XML with the given name for the test suites.
+//
+// There is a test suite for every file, and a failed test case for every ID
+// within the file with all the Failures of the ID.
+func NewJUnitFailureReporter(name string) FailureReporter {
+	return newJUnitFailureReporter(name)
+}
+
+// NewCheckstyleFailureReporter returns a new FailureReporter that prints
+// the Failures as Checkstyle XML.
+//
+// There is a file element for every file, and the ID of each Failure is
+// the source of its error element.
+func NewCheckstyleFailureReporter() FailureReporter {
+	return newCheckstyleFailureReporter()
+}
+
+type textFailureReporter struct {
+	fields []FailureField
+}
+
+func newTextFailureReporter(fields ...FailureField) *textFailureReporter {
+	return &textFailureReporter{
+		fields: fields,
+	}
+}
+
+func (r *textFailureReporter) ReportFailures(writer io.Writer, failures ...*Failure) error {
+	bufWriter := bufio.NewWriter(writer)
+	for _, failure := range failures {
+		if err := failure.Fprintln(bufWriter, r.fields...); err != nil {
+			return err
+		}
+	}
+	return bufWriter.Flush()
+}
+
+type jsonFailureReporter struct{}
+
+func newJSONFailureReporter() *jsonFailureReporter {
+	return &jsonFailureReporter{}
+}
+
+func (r *jsonFailureReporter) ReportFailures(writer io.Writer, failures ...*Failure) error {
+	bufWriter := bufio.NewWriter(writer)
+	for _, failure := range failures {
+		data, err := json.Marshal(failure)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(bufWriter, string(data)); err != nil {
+			return err
+		}
+	}
+	return bufWriter.Flush()
+}
+
+// groupFailures groups the Failures by the given key in the order that
+// each key is first seen.
+func groupFailures(failures []*Failure, getKey func(*Failure) string) [][]*Failure {
+	var groups [][]*Failure
+	keyToIndex := make(map[string]int)
+	for _, failure := range failures {
+		key := getKey(failure)
+		index, ok := keyToIndex[key]
+		if !ok {
+			index = len(groups)
+			keyToIndex[key] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], failure)
+	}
+	return groups
+}
+
+// getFailureFilename returns the filename of the Failure as printed
+// by Failure.String.
+func getFailureFilename(failure *Failure) string {
+	if failure.Filename == "" {
+		return "<input>"
+	}
+	return failure.Filename
+}
+
+func writeXML(writer io.Writer, value interface{}) error {
+	data, err := xml.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
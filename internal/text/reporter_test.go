@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextFailureReporter(t *testing.T) {
+	testFailureReporter(
+		t,
+		NewTextFailureReporter(),
+		`a.proto:2:3:foo
+<input>:1:1:bar
+`,
+		newTestFailure("a.proto", 2, 3, "FOO", "foo"),
+		newTestFailure("", 0, 0, "", "bar"),
+	)
+	testFailureReporter(
+		t,
+		NewTextFailureReporter(FailureFieldFilename, FailureFieldID),
+		`a.proto:FOO
+`,
+		newTestFailure("a.proto", 2, 3, "FOO", "foo"),
+	)
+}
+
+func TestJSONFailureReporter(t *testing.T) {
+	testFailureReporter(
+		t,
+		NewJSONFailureReporter(),
+		`{"filename":"a.proto","line":2,"column":3,"lint_id":"FOO","message":"foo"}
+{"message":"bar"}
+`,
+		newTestFailure("a.proto", 2, 3, "FOO", "foo"),
+		newTestFailure("", 0, 0, "", "bar"),
+	)
+}
+
+func TestJUnitFailureReporter(t *testing.T) {
+	testFailureReporter(
+		t,
+		NewJUnitFailureReporter("prototool"),
+		`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="prototool" tests="3" failures="3">
+  <testsuite name="a.proto" tests="2" failures="2">
+    <testcase name="FOO" classname="a.proto">
+      <failure message="2 failures" type="FOO">a.proto:2:3:FOO foo&#xA;a.proto:4:1:FOO &lt;foo&gt;</failure>
+    </testcase>
+    <testcase name="BAR" classname="a.proto">
+      <failure message="bar" type="BAR">a.proto:3:1:BAR bar</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="&lt;input&gt;" tests="1" failures="1">
+    <testcase name="FAILURE" classname="&lt;input&gt;">
+      <failure message="system">&lt;input&gt;:1:1:system</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		newTestFailure("a.proto", 2, 3, "FOO", "foo"),
+		newTestFailure("a.proto", 3, 1, "BAR", "bar"),
+		newTestFailure("a.proto", 4, 1, "FOO", "<foo>"),
+		newTestFailure("", 0, 0, "", "system"),
+	)
+	testFailureReporter(
+		t,
+		NewJUnitFailureReporter("prototool"),
+		`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="prototool" tests="0" failures="0"></testsuites>
+`,
+	)
+}
+
+func TestCheckstyleFailureReporter(t *testing.T) {
+	testFailureReporter(
+		t,
+		NewCheckstyleFailureReporter(),
+		`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="a.proto">
+    <error line="2" column="3" severity="error" message="foo" source="FOO"></error>
+    <error line="1" severity="error" message="&#34;bar&#34;" source="BAR"></error>
+  </file>
+  <file name="b.proto">
+    <error line="4" column="1" severity="error" message="baz"></error>
+  </file>
+</checkstyle>
+`,
+		newTestFailure("a.proto", 2, 3, "FOO", "foo"),
+		newTestFailure("a.proto", 0, 0, "BAR", `"bar"`),
+		newTestFailure("b.proto", 4, 1, "", "baz"),
+	)
+	testFailureReporter(
+		t,
+		NewCheckstyleFailureReporter(),
+		`<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3"></checkstyle>
+`,
+	)
+}
+
+func testFailureReporter(t *testing.T, failureReporter FailureReporter, expected string, failures ...*Failure) {
+	buffer := bytes.NewBuffer(nil)
+	assert.NoError(t, failureReporter.ReportFailures(buffer, failures...))
+	assert.Equal(t, expected, buffer.String())
+}
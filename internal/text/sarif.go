@@ -0,0 +1,197 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package text
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+)
+
+type sarifFailureReporter struct {
+	toolName    string
+	toolVersion string
+	idToPurpose map[string]string
+}
+
+func newSARIFFailureReporter(toolName string, toolVersion string, idToPurpose map[string]string) *sarifFailureReporter {
+	return &sarifFailureReporter{
+		toolName:    toolName,
+		toolVersion: toolVersion,
+		idToPurpose: idToPurpose,
+	}
+}
+
+func (r *sarifFailureReporter) ReportFailures(writer io.Writer, failures ...*Failure) error {
+	idToRuleIndex := make(map[string]int)
+	for id := range r.idToPurpose {
+		idToRuleIndex[id] = 0
+	}
+	for _, failure := range failures {
+		if failure.LintID != "" {
+			idToRuleIndex[failure.LintID] = 0
+		}
+	}
+	ids := make([]string, 0, len(idToRuleIndex))
+	for id := range idToRuleIndex {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	rules := make([]*sarifRule, 0, len(ids))
+	for i, id := range ids {
+		rule := &sarifRule{ID: id}
+		if purpose := r.idToPurpose[id]; purpose != "" {
+			rule.ShortDescription = &sarifMessage{Text: purpose}
+		}
+		rules = append(rules, rule)
+		idToRuleIndex[id] = i
+	}
+	// results must not be null for a run that was performed
+	results := make([]*sarifResult, 0, len(failures))
+	for _, failure := range failures {
+		result := &sarifResult{
+			RuleID:  failure.LintID,
+			Level:   "error",
+			Message: sarifMessage{Text: failure.Message},
+		}
+		if ruleIndex, ok := idToRuleIndex[failure.LintID]; ok {
+			result.RuleIndex = &ruleIndex
+		}
+		if failure.Filename != "" || failure.Path != "" {
+			location := &sarifLocation{}
+			if failure.Filename != "" {
+				location.PhysicalLocation = &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: getSARIFURI(failure.Filename)},
+				}
+				// lines and columns are one-based in SARIF and zero if unknown in Failures
+				if failure.Line > 0 {
+					location.PhysicalLocation.Region = &sarifRegion{StartLine: failure.Line}
+					if failure.Column > 0 {
+						location.PhysicalLocation.Region.StartColumn = failure.Column
+					}
+				}
+			}
+			if failure.Path != "" {
+				location.LogicalLocations = []*sarifLogicalLocation{{FullyQualifiedName: failure.Path}}
+			}
+			result.Locations = []*sarifLocation{location}
+		}
+		results = append(results, result)
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(
+		&sarifLog{
+			Schema:  sarifSchema,
+			Version: sarifVersion,
+			Runs: []*sarifRun{
+				{
+					Tool: sarifTool{
+						Driver: sarifDriver{
+							Name:    r.toolName,
+							Version: r.toolVersion,
+							Rules:   rules,
+						},
+					},
+					Results: results,
+				},
+			},
+		},
+	)
+}
+
+// getSARIFURI returns the URI reference of the file, which is relative
+// if the filename is relative.
+func getSARIFURI(filename string) string {
+	uri := &url.URL{Path: filepath.ToSlash(filename)}
+	if filepath.IsAbs(filename) {
+		uri.Scheme = "file"
+	}
+	return uri.String()
+}
+
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string       `json:"name"`
+	Version string       `json:"version,omitempty"`
+	Rules   []*sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId,omitempty"`
+	RuleIndex *int             `json:"ruleIndex,omitempty"`
+	Level     string           `json:"level"`
+	Message   sarifMessage     `json:"message"`
+	Locations []*sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation  `json:"physicalLocation,omitempty"`
+	LogicalLocations []*sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSARIFFailureReporter(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	assert.NoError(
+		t,
+		NewSARIFFailureReporter(
+			"prototool",
+			"1.0.0",
+			map[string]string{
+				"FOO": "Checks foo.",
+				"BAR": "Checks bar.",
+			},
+		).ReportFailures(
+			buffer,
+			newTestFailure("a/b.proto", 2, 3, "FOO", "foo"),
+			newTestFailure("/a/b c.proto", 0, 0, "BAZ", "baz"),
+			newTestFailure("", 0, 0, "", "system"),
+		),
+	)
+	assert.JSONEq(
+		t,
+		`{
+  "$schema": "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "prototool",
+          "version": "1.0.0",
+          "rules": [
+            {"id": "BAR", "shortDescription": {"text": "Checks bar."}},
+            {"id": "BAZ"},
+            {"id": "FOO", "shortDescription": {"text": "Checks foo."}}
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "FOO",
+          "ruleIndex": 2,
+          "level": "error",
+          "message": {"text": "foo"},
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {"uri": "a/b.proto"},
+                "region": {"startLine": 2, "startColumn": 3}
+              }
+            }
+          ]
+        },
+        {
+          "ruleId": "BAZ",
+          "ruleIndex": 1,
+          "level": "error",
+          "message": {"text": "baz"},
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {"uri": "file:///a/b%20c.proto"}
+              }
+            }
+          ]
+        },
+        {
+          "level": "error",
+          "message": {"text": "system"}
+        }
+      ]
+    }
+  ]
+}`,
+		buffer.String(),
+	)
+}
+
+func TestSARIFFailureReporterPaths(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	withFilename := newTestFailure("a/b.proto", 2, 3, "FOO", "foo")
+	withFilename.Path = "foo.v1.Foo"
+	withoutFilename := newTestFailure("", 0, 0, "FOO", "bar")
+	withoutFilename.Path = "bar.v1"
+	assert.NoError(
+		t,
+		NewSARIFFailureReporter("prototool", "", nil).ReportFailures(buffer, withFilename, withoutFilename),
+	)
+	assert.JSONEq(
+		t,
+		`{
+  "$schema": "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "prototool",
+          "rules": [
+            {"id": "FOO"}
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "FOO",
+          "ruleIndex": 0,
+          "level": "error",
+          "message": {"text": "foo"},
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {"uri": "a/b.proto"},
+                "region": {"startLine": 2, "startColumn": 3}
+              },
+              "logicalLocations": [{"fullyQualifiedName": "foo.v1.Foo"}]
+            }
+          ]
+        },
+        {
+          "ruleId": "FOO",
+          "ruleIndex": 0,
+          "level": "error",
+          "message": {"text": "bar"},
+          "locations": [
+            {
+              "logicalLocations": [{"fullyQualifiedName": "bar.v1"}]
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}`,
+		buffer.String(),
+	)
+}
+
+func TestSARIFFailureReporterNoFailures(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	assert.NoError(t, NewSARIFFailureReporter("prototool", "", nil).ReportFailures(buffer))
+	assert.JSONEq(
+		t,
+		`{
+  "$schema": "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {"driver": {"name": "prototool"}},
+      "results": []
+    }
+  ]
+}`,
+		buffer.String(),
+	)
+}
@@ -113,6 +113,9 @@ type Failure struct {
 	Column   int    `json:"column,omitempty"`
 	LintID   string `json:"lint_id,omitempty"`
 	Message  string `json:"message,omitempty"`
+	// Path is the path of the Protobuf element the Failure is for, if any,
+	// such as the fully-qualified name of a message.
+	Path string `json:"path,omitempty"`
 }
 
 // FailureWriter is a writer that Failure.Println can accept.
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package watch watches directories for changes to files.
+package watch
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultDebounce is the default duration to wait for more changes
+// before returning the changed files.
+const DefaultDebounce = 100 * time.Millisecond
+
+// Watcher watches directories for changes to files.
+//
+// A Watcher is not thread-safe.
+type Watcher interface {
+	// Add watches the given directory and all its subdirectories,
+	// except for hidden directories such as .git.
+	//
+	// Subdirectories created after this call are also watched.
+	Add(dirPath string) error
+	// Next blocks until files have changed, and returns the paths of
+	// the changed files once no more changes have been seen for the
+	// debounce duration. This is so that bursts of saves, for example
+	// from formatting a directory, are handled at once.
+	//
+	// The returned paths are absolute, sorted and unique. Files that were
+	// created, written, removed or renamed are all returned.
+	//
+	// Returns an error if the Watcher is closed.
+	Next() ([]string, error)
+	// Close stops watching.
+	Close() error
+}
+
+// WatcherOption is an option for a new Watcher.
+type WatcherOption func(*watcher)
+
+// WatcherWithLogger returns a WatcherOption that uses the given logger.
+//
+// The default is to use zap.NewNop().
+func WatcherWithLogger(logger *zap.Logger) WatcherOption {
+	return func(watcher *watcher) {
+		watcher.logger = logger
+	}
+}
+
+// WatcherWithDebounce returns a WatcherOption that waits for the given
+// duration without changes before returning the changed files.
+//
+// The default is DefaultDebounce.
+func WatcherWithDebounce(debounce time.Duration) WatcherOption {
+	return func(watcher *watcher) {
+		watcher.debounce = debounce
+	}
+}
+
+// NewWatcher returns a new Watcher.
+func NewWatcher(options ...WatcherOption) (Watcher, error) {
+	return newWatcher(options...)
+}
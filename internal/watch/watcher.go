@@ -0,0 +1,180 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package watch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/uber/prototool/internal/file"
+	"go.uber.org/zap"
+)
+
+type watcher struct {
+	logger    *zap.Logger
+	debounce  time.Duration
+	fsWatcher *fsnotify.Watcher
+	dirPaths  map[string]struct{}
+}
+
+func newWatcher(options ...WatcherOption) (*watcher, error) {
+	watcher := &watcher{
+		logger:   zap.NewNop(),
+		debounce: DefaultDebounce,
+		dirPaths: make(map[string]struct{}),
+	}
+	for _, option := range options {
+		option(watcher)
+	}
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	watcher.fsWatcher = fsWatcher
+	return watcher, nil
+}
+
+func (w *watcher) Add(dirPath string) error {
+	_, err := w.add(dirPath)
+	return err
+}
+
+func (w *watcher) Next() ([]string, error) {
+	filePaths := make(map[string]struct{})
+	// nil until the first change, receiving from a nil channel blocks forever
+	var debounceC <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return nil, errors.New("watcher is closed")
+			}
+			// editors and other tools change permissions and
+			// timestamps all the time, these are not changes we care about
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			w.logger.Debug("file changed", zap.String("path", event.Name), zap.Stringer("op", event.Op))
+			filePaths[event.Name] = struct{}{}
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				// files could be created in a new directory before we
+				// watch it, so we count all of them as changed
+				createdFilePaths, err := w.add(event.Name)
+				if err != nil {
+					return nil, err
+				}
+				for _, createdFilePath := range createdFilePaths {
+					filePaths[createdFilePath] = struct{}{}
+				}
+			}
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				w.remove(event.Name)
+			}
+			debounceC = time.After(w.debounce)
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return nil, errors.New("watcher is closed")
+			}
+			return nil, err
+		case <-debounceC:
+			sortedFilePaths := make([]string, 0, len(filePaths))
+			for filePath := range filePaths {
+				sortedFilePaths = append(sortedFilePaths, filePath)
+			}
+			sort.Strings(sortedFilePaths)
+			return sortedFilePaths, nil
+		}
+	}
+}
+
+func (w *watcher) Close() error {
+	return w.fsWatcher.Close()
+}
+
+// add watches the path and all directories under it if the path is a directory,
+// and returns the files under the path.
+//
+// Nothing is done if the path is not a directory or no longer exists.
+func (w *watcher) add(path string) ([]string, error) {
+	path, err := file.AbsClean(path)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !fileInfo.IsDir() {
+		return nil, nil
+	}
+	var filePaths []string
+	err = filepath.Walk(
+		path,
+		func(filePath string, fileInfo os.FileInfo, err error) error {
+			if err != nil {
+				// the directory could be removed while walking
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !fileInfo.IsDir() {
+				filePaths = append(filePaths, filePath)
+				return nil
+			}
+			if filePath != path && strings.HasPrefix(fileInfo.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if _, ok := w.dirPaths[filePath]; ok {
+				return nil
+			}
+			w.logger.Debug("watching directory", zap.String("path", filePath))
+			if err := w.fsWatcher.Add(filePath); err != nil {
+				return err
+			}
+			w.dirPaths[filePath] = struct{}{}
+			return nil
+		},
+	)
+	return filePaths, err
+}
+
+// remove stops watching the path and all directories under it, if they were watched.
+//
+// This is done when a path is removed or renamed, so that the path is watched
+// again if a directory with the same path is created.
+func (w *watcher) remove(path string) {
+	for dirPath := range w.dirPaths {
+		if dirPath == path || strings.HasPrefix(dirPath, path+string(filepath.Separator)) {
+			// the watch is already gone if the directory was removed
+			_ = w.fsWatcher.Remove(dirPath)
+			delete(w.dirPaths, dirPath)
+		}
+	}
+}
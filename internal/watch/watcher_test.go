@@ -0,0 +1,104 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package watch
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	tmpDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tmpDirPath) }()
+	// the temporary directory could be a symlink, for example on Darwin
+	tmpDirPath, err = filepath.EvalSymlinks(tmpDirPath)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDirPath, "a"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDirPath, ".git"), 0755))
+
+	watcher, err := NewWatcher(WatcherWithDebounce(200 * time.Millisecond))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, watcher.Close()) }()
+	require.NoError(t, watcher.Add(tmpDirPath))
+
+	// a burst of changes is returned at once
+	writeFile(t, tmpDirPath, "a/a.proto")
+	writeFile(t, tmpDirPath, "a/b.proto")
+	writeFile(t, tmpDirPath, ".git/HEAD")
+	writeFile(t, tmpDirPath, "a/a.proto")
+	assert.Equal(
+		t,
+		[]string{
+			filepath.Join(tmpDirPath, "a", "a.proto"),
+			filepath.Join(tmpDirPath, "a", "b.proto"),
+		},
+		next(t, watcher),
+	)
+
+	// new directories are watched
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDirPath, "b", "c"), 0755))
+	writeFile(t, tmpDirPath, "b/c/c.proto")
+	// b/c may or may not be returned depending on if b was watched before b/c was created
+	filePaths := next(t, watcher)
+	assert.Contains(t, filePaths, filepath.Join(tmpDirPath, "b"))
+	assert.Contains(t, filePaths, filepath.Join(tmpDirPath, "b", "c", "c.proto"))
+	writeFile(t, tmpDirPath, "b/c/c.proto")
+	assert.Equal(t, []string{filepath.Join(tmpDirPath, "b", "c", "c.proto")}, next(t, watcher))
+
+	// removed directories are watched again when created again
+	require.NoError(t, os.RemoveAll(filepath.Join(tmpDirPath, "b")))
+	assert.Contains(t, next(t, watcher), filepath.Join(tmpDirPath, "b"))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDirPath, "b"), 0755))
+	assert.Equal(t, []string{filepath.Join(tmpDirPath, "b")}, next(t, watcher))
+	writeFile(t, tmpDirPath, "b/b.proto")
+	assert.Equal(t, []string{filepath.Join(tmpDirPath, "b", "b.proto")}, next(t, watcher))
+}
+
+func writeFile(t *testing.T, dirPath string, relFilePath string) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dirPath, relFilePath), []byte("foo"), 0644))
+}
+
+func next(t *testing.T, watcher Watcher) []string {
+	type result struct {
+		filePaths []string
+		err       error
+	}
+	resultC := make(chan result, 1)
+	go func() {
+		filePaths, err := watcher.Next()
+		resultC <- result{filePaths: filePaths, err: err}
+	}()
+	select {
+	case result := <-resultC:
+		require.NoError(t, result.err)
+		return result.filePaths
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for changes")
+		return nil
+	}
+}